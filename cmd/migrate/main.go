@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"go-modular-monolith/internal/bootstrap"
+	"go-modular-monolith/internal/shared/config"
+	"go-modular-monolith/internal/shared/database"
+	"go-modular-monolith/pkg/migrate"
+	"go-modular-monolith/pkg/module"
+)
+
+const usage = `Uso: migrate <comando> [argumentos]
+
+Comandos:
+  up                      aplica todas as migrações pendentes
+  down [n]                reverte as últimas n migrações aplicadas (padrão 1)
+  status                  lista as migrações e o estado de cada uma
+  to <módulo> <versão>    aplica ou reverte o módulo até a versão (0 reverte tudo)
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	// Apenas os módulos habilitados, como no servidor
+	modules, err := module.NewManager(bootstrap.Modules(cfg), cfg.DisabledModules)
+	if err != nil {
+		log.Fatalf("Invalid module configuration: %v", err)
+	}
+
+	db, err := database.Connect(database.GetDefaultConfig())
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatalf("Failed to get underlying sql.DB: %v", err)
+	}
+	defer sqlDB.Close()
+
+	migrator, err := bootstrap.NewMigrator(db, modules)
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+
+	if err := run(context.Background(), migrator, os.Args[1], os.Args[2:]); err != nil {
+		log.Fatalf("Migration failed: %v", err)
+	}
+}
+
+func run(ctx context.Context, migrator *migrate.Migrator, command string, args []string) error {
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		report("Applied", applied)
+		return err
+
+	case "down":
+		steps := 1
+		if len(args) > 0 {
+			n, err := strconv.Atoi(args[0])
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid number of steps: %s", args[0])
+			}
+			steps = n
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		report("Reverted", reverted)
+		return err
+
+	case "to":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: migrate to <module> <version>")
+		}
+		version, err := strconv.Atoi(args[1])
+		if err != nil || version < 0 {
+			return fmt.Errorf("invalid version: %s", args[1])
+		}
+		changed, err := migrator.To(ctx, args[0], version)
+		report("Migrated", changed)
+		return err
+
+	case "status":
+		return printStatus(ctx, migrator)
+
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("unknown command: %s", command)
+	}
+}
+
+func report(verb string, migrations []migrate.Migration) {
+	if len(migrations) == 0 {
+		fmt.Println("Nothing to do")
+		return
+	}
+	for _, migration := range migrations {
+		fmt.Printf("%s %s\n", verb, migration.ID())
+	}
+}
+
+func printStatus(ctx context.Context, migrator *migrate.Migrator) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "MODULE\tVERSION\tNAME\tSTATE\tAPPLIED AT")
+	for _, status := range statuses {
+		appliedAt := "-"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Local().Format(time.DateTime)
+		}
+		fmt.Fprintf(w, "%s\t%04d\t%s\t%s\t%s\n", status.Module, status.Version, status.Name, status.State, appliedAt)
+	}
+	return w.Flush()
+}
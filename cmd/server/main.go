@@ -10,8 +10,20 @@ import (
 	"time"
 
 	"go-modular-monolith/internal/bootstrap"
+	"go-modular-monolith/internal/shared/audit"
+	"go-modular-monolith/internal/shared/deadletter"
+	"go-modular-monolith/internal/shared/debug"
+	"go-modular-monolith/internal/shared/eventmetrics"
+	"go-modular-monolith/internal/shared/eventstore"
+	"go-modular-monolith/internal/shared/middleware"
+	"go-modular-monolith/internal/shared/outbox"
+	sagaStore "go-modular-monolith/internal/shared/saga"
+	"go-modular-monolith/internal/shared/sse"
 	"go-modular-monolith/pkg/container"
 	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/events"
+	"go-modular-monolith/pkg/module"
+	"go-modular-monolith/pkg/saga"
 
 	"github.com/gin-gonic/gin"
 )
@@ -27,25 +39,47 @@ func main() {
 	logger := container.MustGet("logger").(contracts.Logger)
 	logger.Info("Starting Go Modular Monolith")
 
+	modules := container.MustGet("modules").(*module.Manager)
+	for _, name := range modules.Disabled() {
+		logger.Info("Module disabled", contracts.Field{Key: "module", Value: name})
+	}
+
 	// Configurar Gin
 	router := gin.Default()
 
 	// Middleware global
 	router.Use(gin.Logger())
 	router.Use(gin.Recovery())
+	router.Use(middleware.RequestID())
+	router.Use(middleware.IfMatch())
+	router.Use(middleware.Scope(container))
+	router.Use(middleware.Actor())
 
 	// Health check endpoint
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"status":    "ok",
-			"timestamp": time.Now(),
-		})
-	})
+	router.GET("/health", healthHandler(modules, container))
+
+	// Registrar rotas dos módulos habilitados
+	modules.RegisterRoutes(router.Group("/api/v1"), container)
+	registerAdminRoutes(router, container)
+
+	// Inscrever consumidores de eventos antes de iniciar o relay
+	eventBus := container.MustGet("eventbus").(*events.EventBus)
+	if err := modules.Subscribe(container, eventBus); err != nil {
+		log.Fatalf("Failed to subscribe modules to events: %v", err)
+	}
 
-	// Registrar rotas dos módulos
-	registerUserRoutes(router, container)
-	registerProductRoutes(router, container)
-	registerOrderRoutes(router, container)
+	// Retomar ou compensar sagas interrompidas (definições já registradas pelos serviços)
+	coordinator := container.MustGet("sagaCoordinator").(*saga.Coordinator)
+	recovered, err := coordinator.Recover(context.Background())
+	if err != nil {
+		logger.Error("Failed to recover sagas", contracts.Field{Key: "error", Value: err})
+	} else if recovered != (saga.RecoveryResult{}) {
+		logger.Info("Sagas recovered",
+			contracts.Field{Key: "resumed", Value: recovered.Resumed},
+			contracts.Field{Key: "compensated", Value: recovered.Compensated},
+			contracts.Field{Key: "failed", Value: recovered.Failed},
+		)
+	}
 
 	// Configurar servidor HTTP
 	server := &http.Server{
@@ -53,13 +87,20 @@ func main() {
 		Handler: router,
 	}
 
-	// Iniciar servidor em goroutine
-	go func() {
-		logger.Info("Server starting on port 8080")
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Failed to start server: %v", err)
-		}
-	}()
+	// Conexões SSE são longas: encerrá-las para o Shutdown não aguardar o timeout.
+	// O broker só existe com o módulo stream habilitado.
+	if broker, err := container.Get("sseBroker"); err == nil {
+		server.RegisterOnShutdown(broker.(*sse.Broker).Close)
+	}
+
+	// O servidor é o último hook registrado: inicia depois de todos os serviços
+	// e é o primeiro a parar, antes do relay, do bus e do banco
+	container.Append(serverHook(server, logger))
+
+	// Iniciar relay do outbox, serviços dos módulos e servidor
+	if err := container.Start(context.Background()); err != nil {
+		log.Fatalf("Failed to start application: %v", err)
+	}
 
 	// Aguardar sinal de interrupção
 	quit := make(chan os.Signal, 1)
@@ -68,56 +109,98 @@ func main() {
 
 	logger.Info("Shutting down server...")
 
-	// Graceful shutdown
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	// Graceful shutdown: hooks em ordem inversa, cada um com o próprio prazo
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
 
-	if err := server.Shutdown(ctx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+	if err := container.Stop(ctx); err != nil {
+		logger.Error("Graceful shutdown failed", contracts.Field{Key: "error", Value: err.Error()})
 	}
 
 	logger.Info("Server exited")
 }
 
-// registerUserRoutes registra as rotas do módulo de usuário
-func registerUserRoutes(router *gin.Engine, container *container.Container) {
-	userHandler := container.MustGet("userHandler").(contracts.UserHandler)
-
-	userGroup := router.Group("/api/v1/users")
-	{
-		userGroup.POST("/", userHandler.CreateUser)
-		userGroup.GET("/:id", userHandler.GetUser)
-		userGroup.PUT("/:id", userHandler.UpdateUser)
-		userGroup.DELETE("/:id", userHandler.DeleteUser)
-		userGroup.POST("/validate", userHandler.ValidateUser)
+// serverHook inicia o servidor HTTP em background e o encerra no shutdown
+func serverHook(server *http.Server, logger contracts.Logger) container.Hook {
+	return container.Hook{
+		Name: "httpServer",
+		OnStart: func(context.Context) error {
+			go func() {
+				logger.Info("Server starting on port 8080")
+				if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+					log.Fatalf("Failed to start server: %v", err)
+				}
+			}()
+			return nil
+		},
+		OnStop:  server.Shutdown,
+		Timeout: 30 * time.Second,
 	}
 }
 
-// registerProductRoutes registra as rotas do módulo de produto
-func registerProductRoutes(router *gin.Engine, container *container.Container) {
-	productHandler := container.MustGet("productHandler").(contracts.ProductHandler)
+// healthHandler verifica os módulos habilitados; responde 503 se algum falhar
+func healthHandler(modules *module.Manager, c *container.Container) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		checkCtx, cancel := context.WithTimeout(ctx.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		status := "ok"
+		code := http.StatusOK
+		results := gin.H{}
+		for name, err := range modules.Health(checkCtx, c) {
+			if err != nil {
+				status = "degraded"
+				code = http.StatusServiceUnavailable
+				results[name] = gin.H{"status": "error", "error": err.Error()}
+				continue
+			}
+			results[name] = gin.H{"status": "ok"}
+		}
 
-	productGroup := router.Group("/api/v1/products")
-	{
-		productGroup.POST("/", productHandler.CreateProduct)
-		productGroup.GET("/", productHandler.GetProducts)
-		productGroup.GET("/:id", productHandler.GetProduct)
-		productGroup.PUT("/:id", productHandler.UpdateProduct)
-		productGroup.DELETE("/:id", productHandler.DeleteProduct)
-		productGroup.PUT("/:id/stock", productHandler.UpdateStock)
+		ctx.JSON(code, gin.H{
+			"status":    status,
+			"timestamp": time.Now(),
+			"modules":   results,
+		})
 	}
 }
 
-// registerOrderRoutes registra as rotas do módulo de pedidos
-func registerOrderRoutes(router *gin.Engine, container *container.Container) {
-	orderHandler := container.MustGet("orderHandler").(contracts.OrderHandler)
+// registerAdminRoutes registra as rotas de administração da infraestrutura
+func registerAdminRoutes(router *gin.Engine, c *container.Container) {
+	deadLetterHandler := container.MustResolve[*deadletter.Handler](c)
+	eventStoreHandler := container.MustResolve[*eventstore.Handler](c)
+	eventMetricsHandler := container.MustResolve[*eventmetrics.Handler](c)
+	sagaHandler := container.MustResolve[*sagaStore.Handler](c)
+	debugHandler := container.MustResolve[*debug.Handler](c)
+	auditHandler := container.MustResolve[*audit.Handler](c)
 
-	orderGroup := router.Group("/api/v1/orders")
+	adminGroup := router.Group("/api/v1/admin")
 	{
-		orderGroup.POST("/", orderHandler.CreateOrder)
-		orderGroup.GET("/:id", orderHandler.GetOrder)
-		orderGroup.PUT("/:id/status", orderHandler.UpdateOrderStatus)
-		orderGroup.POST("/:id/cancel", orderHandler.CancelOrder)
-		orderGroup.GET("/user/:user_id", orderHandler.GetOrdersByUser)
+		// O outbox só existe com banco (STORAGE=database)
+		if outboxHandler, err := container.Resolve[*outbox.Handler](c); err == nil {
+			adminGroup.GET("/outbox", outboxHandler.GetMetrics)
+			adminGroup.POST("/outbox/:id/requeue", outboxHandler.RequeueEvent)
+		}
+
+		adminGroup.GET("/dead-letters", deadLetterHandler.ListDeadLetters)
+		adminGroup.DELETE("/dead-letters", deadLetterHandler.PurgeDeadLetters)
+		adminGroup.GET("/dead-letters/:id", deadLetterHandler.GetDeadLetter)
+		adminGroup.POST("/dead-letters/:id/redrive", deadLetterHandler.RedriveDeadLetter)
+		adminGroup.DELETE("/dead-letters/:id", deadLetterHandler.DeleteDeadLetter)
+
+		adminGroup.GET("/events", eventStoreHandler.ListEvents)
+		adminGroup.POST("/events/replay", eventStoreHandler.ReplayEvents)
+
+		adminGroup.GET("/audit", auditHandler.ListEntries)
+
+		adminGroup.GET("/sagas", sagaHandler.ListSagas)
+		adminGroup.POST("/sagas/recover", sagaHandler.RecoverSagas)
+		adminGroup.GET("/sagas/:id", sagaHandler.GetSaga)
+
+		adminGroup.GET("/container/graph", debugHandler.GetContainerGraph)
+
+		// Métricas de latência do Event Bus
+		metricsGroup := adminGroup.Group("/metrics")
+		metricsGroup.GET("/events", eventMetricsHandler.GetMetrics)
 	}
 }
@@ -9,23 +9,63 @@ import (
 	"testing"
 
 	"go-modular-monolith/internal/bootstrap"
-	// "go-modular-monolith/pkg/container"
+	"go-modular-monolith/internal/shared/audit"
+	"go-modular-monolith/internal/shared/middleware"
+	"go-modular-monolith/pkg/container"
 	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/module"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-func TestUserAPI(t *testing.T) {
-	// Setup
-	gin.SetMode(gin.TestMode)
+// storages são os modos em que os testes de API sobem a aplicação
+var storages = []string{"sqlite", "memory"}
 
-	container, err := bootstrap.Bootstrap()
+// setupAPI sobe a aplicação com as rotas dos módulos registradas como em main.
+// Em "sqlite" usa um SQLite em memória com as migrações aplicadas; em "memory"
+// usa os repositórios em memória (STORAGE=memory).
+func setupAPI(t *testing.T, storage string) (*gin.Engine, *container.Container) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	if storage == "memory" {
+		t.Setenv("STORAGE", "memory")
+	} else {
+		t.Setenv("STORAGE", "database")
+		t.Setenv("DATABASE_URL", "sqlite://:memory:")
+		t.Setenv("MIGRATIONS_AUTO_APPLY", "true")
+	}
+
+	c, err := bootstrap.Bootstrap()
 	require.NoError(t, err)
+	t.Cleanup(func() {
+		c.Stop(context.Background())
+	})
 
 	router := gin.New()
-	// registerUserRoutes(router, container)
+	router.Use(middleware.RequestID())
+	router.Use(middleware.IfMatch())
+	router.Use(middleware.Scope(c))
+	router.Use(middleware.Actor())
+
+	modules := c.MustGet("modules").(*module.Manager)
+	modules.RegisterRoutes(router.Group("/api/v1"), c)
+
+	return router, c
+}
+
+func TestUserAPI(t *testing.T) {
+	for _, storage := range storages {
+		t.Run(storage, func(t *testing.T) {
+			testUserAPI(t, storage)
+		})
+	}
+}
+
+func testUserAPI(t *testing.T, storage string) {
+	// Setup
+	router, container := setupAPI(t, storage)
 
 	// Test Create User
 	t.Run("Create User", func(t *testing.T) {
@@ -80,6 +120,44 @@ func TestUserAPI(t *testing.T) {
 		assert.Equal(t, "getuser", response.Username)
 	})
 
+	// Test Get Current User
+	t.Run("Get Current User", func(t *testing.T) {
+		userService := container.MustGet("userService").(contracts.UserService)
+		tokens := container.MustGet("tokenGenerator").(contracts.TokenGenerator)
+
+		createdUser, err := userService.CreateUser(context.Background(), contracts.CreateUserRequest{
+			Username: "meuser",
+			Email:    "me@example.com",
+			Password: "password123",
+		})
+		require.NoError(t, err)
+		token, err := tokens.GenerateAccessToken(createdUser.ID)
+		require.NoError(t, err)
+
+		me := func(token string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me", nil)
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		w := me(token)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response contracts.User
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, createdUser.ID, response.ID)
+
+		assert.Equal(t, http.StatusUnauthorized, me("").Code)
+		assert.Equal(t, http.StatusUnauthorized, me("access_token_for_"+createdUser.ID).Code)
+
+		// O token de um usuário excluído não autentica mais
+		require.NoError(t, userService.DeleteUser(context.Background(), createdUser.ID))
+		assert.Equal(t, http.StatusUnauthorized, me(token).Code)
+	})
+
 	// Test Update User
 	t.Run("Update User", func(t *testing.T) {
 		// Criar usuário
@@ -113,6 +191,12 @@ func TestUserAPI(t *testing.T) {
 		err = json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
 		assert.Equal(t, "updateduser", response.Username)
+		assert.Equal(t, `"2"`, w.Header().Get("ETag"))
+
+		// A alteração foi gravada
+		stored, err := userService.GetUserByID(context.Background(), createdUser.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "updateduser", stored.Username)
 	})
 
 	// Test Validate User
@@ -149,18 +233,291 @@ func TestUserAPI(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, "validateuser", response.Username)
 	})
+
+	// Um usuário excluído mantém email e username até o purge
+	t.Run("Register Email Of Deleted User", func(t *testing.T) {
+		register := func() *httptest.ResponseRecorder {
+			body, _ := json.Marshal(contracts.CreateUserRequest{
+				Username: "deleteduser",
+				Email:    "deleted@example.com",
+				Password: "password123",
+			})
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/users/", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		w := register()
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		assert.Equal(t, http.StatusConflict, register().Code)
+
+		var created contracts.User
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/users/"+created.ID, nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusNoContent, w.Code)
+
+		w = register()
+		assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "already exists")
+	})
+}
+
+func TestOrderAPI(t *testing.T) {
+	for _, storage := range storages {
+		t.Run(storage, func(t *testing.T) {
+			testOrderAPI(t, storage)
+		})
+	}
 }
 
-// Função helper para registrar rotas (copiada do main.go)
-// func registerUserRoutes(router *gin.Engine, container *container.Container) {
-// 	userService := container.MustGet("userService").(contracts.UserService)
-
-// 	userGroup := router.Group("/api/v1/users")
-// 	{
-// 		userGroup.POST("/", createUserHandler(userService))
-// 		userGroup.GET("/:id", getUserHandler(userService))
-// 		userGroup.PUT("/:id", updateUserHandler(userService))
-// 		userGroup.DELETE("/:id", deleteUserHandler(userService))
-// 		userGroup.POST("/validate", validateUserHandler(userService))
-// 	}
-// }
+func testOrderAPI(t *testing.T, storage string) {
+	router, c := setupAPI(t, storage)
+
+	userService := c.MustGet("userService").(contracts.UserService)
+	user, err := userService.CreateUser(context.Background(), contracts.CreateUserRequest{
+		Username: "buyer",
+		Email:    "buyer@example.com",
+		Password: "password123",
+	})
+	require.NoError(t, err)
+
+	// Produtos do teste, sem depender do seed das migrações
+	productService := c.MustGet("productService").(contracts.ProductService)
+	createProduct := func(name string, stock int) string {
+		product, err := productService.CreateProduct(context.Background(), contracts.CreateProductRequest{
+			Name:       name,
+			Price:      10,
+			Stock:      stock,
+			CategoryID: "cat-test",
+		})
+		require.NoError(t, err)
+		return product.ID
+	}
+	quasar := createProduct("Teclado Quasar", 10)
+	nebula := createProduct("Mouse Nebula", 1)
+
+	request := func(method, path string, payload interface{}) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		if payload != nil {
+			require.NoError(t, json.NewEncoder(&body).Encode(payload))
+		}
+		req := httptest.NewRequest(method, path, &body)
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	stockOf := func(id string) int {
+		w := request(http.MethodGet, "/api/v1/products/"+id, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var product contracts.Product
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &product))
+		return product.Stock
+	}
+
+	t.Run("Filter Products By Name", func(t *testing.T) {
+		w := request(http.MethodGet, "/api/v1/products/?name=quasar", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var products []contracts.Product
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &products))
+		require.Len(t, products, 1)
+		assert.Equal(t, quasar, products[0].ID)
+	})
+
+	t.Run("Create And Cancel Order", func(t *testing.T) {
+		initialStock := stockOf(quasar)
+
+		w := request(http.MethodPost, "/api/v1/orders/", contracts.CreateOrderRequest{
+			UserID: user.ID,
+			Items:  []contracts.CreateOrderItem{{ProductID: quasar, Quantity: 2}},
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var order contracts.Order
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &order))
+		assert.Equal(t, initialStock-2, stockOf(quasar))
+
+		w = request(http.MethodPost, "/api/v1/orders/"+order.ID+"/cancel", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, initialStock, stockOf(quasar))
+	})
+
+	t.Run("Failed Order Keeps Stock", func(t *testing.T) {
+		initialStock := stockOf(quasar)
+
+		// O segundo item não tem estoque: a reserva do primeiro é desfeita
+		w := request(http.MethodPost, "/api/v1/orders/", contracts.CreateOrderRequest{
+			UserID: user.ID,
+			Items: []contracts.CreateOrderItem{
+				{ProductID: quasar, Quantity: 1},
+				{ProductID: nebula, Quantity: 2},
+			},
+		})
+		assert.NotEqual(t, http.StatusCreated, w.Code)
+		assert.Equal(t, initialStock, stockOf(quasar))
+	})
+
+	t.Run("Delete Restore And Purge Order", func(t *testing.T) {
+		w := request(http.MethodPost, "/api/v1/orders/", contracts.CreateOrderRequest{
+			UserID: user.ID,
+			Items:  []contracts.CreateOrderItem{{ProductID: quasar, Quantity: 1}},
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var order contracts.Order
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &order))
+
+		deletedOrders := func() []string {
+			w := request(http.MethodGet, "/api/v1/admin/orders/deleted", nil)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			var orders []contracts.Order
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &orders))
+			ids := make([]string, len(orders))
+			for i, o := range orders {
+				ids[i] = o.ID
+			}
+			return ids
+		}
+
+		// Um pedido pendente ainda reserva estoque: precisa ser cancelado antes
+		reservedStock := stockOf(quasar)
+		w = request(http.MethodDelete, "/api/v1/orders/"+order.ID, nil)
+		require.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+		assert.Equal(t, http.StatusOK, request(http.MethodGet, "/api/v1/orders/"+order.ID, nil).Code)
+		require.Equal(t, http.StatusOK, request(http.MethodPost, "/api/v1/orders/"+order.ID+"/cancel", nil).Code)
+		assert.Equal(t, reservedStock+1, stockOf(quasar))
+
+		w = request(http.MethodDelete, "/api/v1/orders/"+order.ID, nil)
+		require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+		assert.Equal(t, http.StatusNotFound, request(http.MethodGet, "/api/v1/orders/"+order.ID, nil).Code)
+		assert.Contains(t, deletedOrders(), order.ID)
+
+		w = request(http.MethodPost, "/api/v1/admin/orders/"+order.ID+"/restore", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, http.StatusOK, request(http.MethodGet, "/api/v1/orders/"+order.ID, nil).Code)
+		assert.NotContains(t, deletedOrders(), order.ID)
+		assert.Equal(t, reservedStock+1, stockOf(quasar), "delete and restore do not touch stock")
+
+		// Só pedidos excluídos podem ser removidos definitivamente
+		assert.Equal(t, http.StatusNotFound, request(http.MethodDelete, "/api/v1/admin/orders/"+order.ID, nil).Code)
+		require.Equal(t, http.StatusNoContent, request(http.MethodDelete, "/api/v1/orders/"+order.ID, nil).Code)
+		require.Equal(t, http.StatusNoContent, request(http.MethodDelete, "/api/v1/admin/orders/"+order.ID, nil).Code)
+		assert.NotContains(t, deletedOrders(), order.ID)
+		assert.Equal(t, http.StatusNotFound, request(http.MethodPost, "/api/v1/admin/orders/"+order.ID+"/restore", nil).Code)
+	})
+
+	t.Run("Update Product With If-Match", func(t *testing.T) {
+		id := createProduct("Monitor Pulsar", 5)
+
+		update := func(etag string, price float64) *httptest.ResponseRecorder {
+			body, _ := json.Marshal(contracts.UpdateProductRequest{Price: &price})
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/products/"+id, bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			if etag != "" {
+				req.Header.Set("If-Match", etag)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		w := request(http.MethodGet, "/api/v1/products/"+id, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		etag := w.Header().Get("ETag")
+		assert.Equal(t, `"1"`, etag)
+
+		w = update(etag, 20)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, `"2"`, w.Header().Get("ETag"))
+
+		// O segundo admin ainda tem o ETag antigo: a alteração é recusada
+		w = update(etag, 30)
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code, w.Body.String())
+		assert.Equal(t, http.StatusPreconditionFailed, update(`W/"2"`, 30).Code, "weak ETags never match")
+
+		w = update("*", 40)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+	})
+	t.Run("Audit Trail", func(t *testing.T) {
+		auditHandler := container.MustResolve[*audit.Handler](c)
+		router.GET("/api/v1/admin/audit", auditHandler.ListEntries)
+
+		token, err := c.MustGet("tokenGenerator").(contracts.TokenGenerator).GenerateAccessToken(user.ID)
+		require.NoError(t, err)
+
+		send := func(method, path, requestID string, payload interface{}) *httptest.ResponseRecorder {
+			body, _ := json.Marshal(payload)
+			req := httptest.NewRequest(method, path, bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+token)
+			req.Header.Set(middleware.RequestIDHeader, requestID)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+		listAudit := func(query string) []contracts.AuditEntry {
+			w := request(http.MethodGet, "/api/v1/admin/audit?"+query, nil)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			var response struct {
+				Entries []contracts.AuditEntry `json:"entries"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			return response.Entries
+		}
+
+		id := createProduct("Headset Vega", 3)
+		price := 25.0
+		w := send(http.MethodPut, "/api/v1/products/"+id, "audit-price", contracts.UpdateProductRequest{Price: &price})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		// Mais recente primeiro: a alteração de preço e depois a criação
+		entries := listAudit("entity_type=product&entity_id=" + id)
+		require.Len(t, entries, 2)
+		assert.Equal(t, contracts.AuditActionUpdate, entries[0].Action)
+		assert.Equal(t, user.ID, entries[0].Actor)
+		assert.Equal(t, "audit-price", entries[0].RequestID)
+		assert.JSONEq(t, "10", string(entries[0].Changes["price"].Before))
+		assert.JSONEq(t, "25", string(entries[0].Changes["price"].After))
+		assert.NotContains(t, entries[0].Changes, "name")
+		assert.Equal(t, contracts.AuditActionCreate, entries[1].Action)
+		assert.Equal(t, contracts.SystemActor, entries[1].Actor)
+
+		assert.Len(t, listAudit("actor="+user.ID+"&entity_id="+id+"&limit=1"), 1)
+		assert.Empty(t, listAudit("entity_id="+id+"&offset=2"))
+
+		// limit nunca devolve a tabela inteira
+		for _, query := range []string{"limit=0", "limit=-1", "limit=abc", "offset=-1"} {
+			assert.Equal(t, http.StatusBadRequest, request(http.MethodGet, "/api/v1/admin/audit?"+query, nil).Code, query)
+		}
+		w = request(http.MethodGet, "/api/v1/admin/audit?limit=100000", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), `"limit":500`)
+
+		// O purge guarda o registro removido como before
+		purged := createProduct("Cabo Quasar", 2)
+		require.Equal(t, http.StatusNoContent, send(http.MethodDelete, "/api/v1/products/"+purged, "audit-delete", nil).Code)
+		require.Equal(t, http.StatusNoContent, send(http.MethodDelete, "/api/v1/admin/products/"+purged, "audit-purge", nil).Code)
+		entries = listAudit("action=purge&entity_id=" + purged)
+		require.Len(t, entries, 1)
+		assert.Equal(t, user.ID, entries[0].Actor)
+		assert.JSONEq(t, `"Cabo Quasar"`, string(entries[0].Changes["name"].Before))
+		assert.JSONEq(t, "2", string(entries[0].Changes["stock"].Before))
+
+		// Um pedido que falha desfaz a reserva de estoque e o registro dela
+		w = send(http.MethodPost, "/api/v1/orders/", "audit-failed-order", contracts.CreateOrderRequest{
+			UserID: user.ID,
+			Items: []contracts.CreateOrderItem{
+				{ProductID: id, Quantity: 1},
+				{ProductID: nebula, Quantity: 5},
+			},
+		})
+		require.NotEqual(t, http.StatusCreated, w.Code)
+		assert.Empty(t, listAudit("request_id=audit-failed-order"))
+	})
+}
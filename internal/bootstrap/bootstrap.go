@@ -1,25 +1,28 @@
 package bootstrap
 
 import (
+	"context"
+	"fmt"
 	"log"
 
 	"go-modular-monolith/internal/modules/user/adapters"
-	userHandler "go-modular-monolith/internal/modules/user/handler"
-	userRepository "go-modular-monolith/internal/modules/user/repository"
-	userService "go-modular-monolith/internal/modules/user/service"
-
-	productHandler "go-modular-monolith/internal/modules/product/handler"
-	productRepository "go-modular-monolith/internal/modules/product/repository"
-	productService "go-modular-monolith/internal/modules/product/service"
-
-	orderHandler "go-modular-monolith/internal/modules/order/handler"
-	orderRepository "go-modular-monolith/internal/modules/order/repository"
-	orderService "go-modular-monolith/internal/modules/order/service"
 
+	"go-modular-monolith/internal/shared/audit"
+	"go-modular-monolith/internal/shared/config"
 	"go-modular-monolith/internal/shared/database"
+	"go-modular-monolith/internal/shared/deadletter"
+	"go-modular-monolith/internal/shared/debug"
+	"go-modular-monolith/internal/shared/eventmetrics"
+	"go-modular-monolith/internal/shared/eventstore"
+	"go-modular-monolith/internal/shared/middleware"
+	"go-modular-monolith/internal/shared/outbox"
+	sagaStore "go-modular-monolith/internal/shared/saga"
 	"go-modular-monolith/pkg/container"
 	"go-modular-monolith/pkg/contracts"
 	"go-modular-monolith/pkg/events"
+	"go-modular-monolith/pkg/migrate"
+	"go-modular-monolith/pkg/module"
+	"go-modular-monolith/pkg/saga"
 
 	"gorm.io/gorm"
 )
@@ -28,39 +31,94 @@ import (
 func Bootstrap() (*container.Container, error) {
 	c := container.NewContainer()
 
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	c.Register("config", cfg)
+
+	// Módulos habilitados, em ordem de dependência
+	modules, err := module.NewManager(Modules(cfg), cfg.DisabledModules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid module configuration: %w", err)
+	}
+	c.Register("modules", modules)
+
 	// Registrar infraestrutura
-	registerInfrastructure(c)
+	if err := registerInfrastructure(c, cfg, modules); err != nil {
+		return nil, err
+	}
+
+	// Registrar serviços dos módulos
+	if err := modules.Register(c); err != nil {
+		return nil, err
+	}
+
+	// Registrar handlers de administração
+	registerHandlers(c, cfg)
 
-	// Registrar serviços de domínio
-	registerDomainServices(c)
+	// Registrar serviços por requisição
+	registerScoped(c)
 
-	// Registrar handlers HTTP
-	registerHandlers(c)
+	// Dependências ausentes, não declaradas e ciclos falham aqui, e não na primeira
+	// resolução. Validate constrói os singletons: os serviços de background já
+	// registram aqui os hooks de início e parada.
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid dependency graph: %w", err)
+	}
 
 	return c, nil
 }
 
-func registerInfrastructure(c *container.Container) {
-	// Database Connection
-	c.RegisterSingleton("database", func() interface{} {
-		config := database.GetDefaultConfig()
-		db, err := database.Connect(config)
-		if err != nil {
-			log.Fatalf("Failed to connect to database: %v", err)
-		}
+func registerInfrastructure(c *container.Container, cfg *config.Config, modules *module.Manager) error {
+	// Banco, transações, publicação de eventos e stores que dependem do storage
+	switch cfg.Storage {
+	case config.StorageDatabase:
+		registerDatabaseStorage(c, cfg, modules)
+	case config.StorageMemory:
+		registerMemoryStorage(c)
+	default:
+		return fmt.Errorf("invalid storage: %s", cfg.Storage)
+	}
 
-		// Executar migrações
-		if err := database.AutoMigrate(db); err != nil {
-			log.Fatalf("Failed to run database migrations: %v", err)
-		}
+	// Event Registry (tipos de evento e structs de payload)
+	c.RegisterSingleton("eventRegistry", func() interface{} {
+		return events.NewDefaultRegistry()
+	})
 
-		return db
+	// Métricas de latência do publish e dos handlers
+	c.RegisterSingleton("eventMetrics", func() interface{} {
+		return events.NewLatencyMetrics()
 	})
 
 	// Event Bus
 	c.RegisterSingleton("eventbus", func() interface{} {
-		return events.NewEventBus()
-	})
+		deadLetters := c.MustGet("deadLetterStore").(events.DeadLetterStore)
+		eventLog := c.MustGet("eventLog").(events.EventLog)
+		registry := c.MustGet("eventRegistry").(*events.Registry)
+		logger := c.MustGet("logger").(contracts.Logger)
+		metrics := c.MustGet("eventMetrics").(*events.LatencyMetrics)
+
+		bus := newEventBus(cfg, deadLetters, eventLog, registry, logger, metrics)
+		// Drena os eventos pendentes no shutdown
+		c.Append(container.Hook{Name: "eventbus", OnStop: bus.Close})
+		return bus
+	}, container.DependsOn(
+		"deadLetterStore", "eventLog", "eventRegistry", "logger", "eventMetrics",
+	))
+
+	c.RegisterSingleton("sagaCoordinator", func() interface{} {
+		store := c.MustGet("sagaStore").(saga.Store)
+		txManager := c.MustGet("transactionManager").(contracts.TransactionManager)
+		logger := c.MustGet("logger").(contracts.Logger)
+		return saga.NewCoordinator(store, txManager, logger, saga.WithLease(cfg.SagaLease))
+	}, container.DependsOn("sagaStore", "transactionManager", "logger"))
+
+	// Auditor (trilha de auditoria das alterações feitas pelos serviços)
+	c.RegisterSingleton("auditor", func() interface{} {
+		auditLog := c.MustGet("auditLog").(contracts.AuditLog)
+		return audit.NewAuditor(auditLog)
+	}, container.DependsOn("auditLog"))
 
 	// Logger (implementação simples)
 	c.RegisterSingleton("logger", func() interface{} {
@@ -77,93 +135,184 @@ func registerInfrastructure(c *container.Container) {
 		return &MockEmailService{}
 	})
 
-	// Token Generator (implementação mock)
+	// Token Generator (JWT HS256 assinado com JWT_SECRET)
 	c.RegisterSingleton("tokenGenerator", func() interface{} {
-		return &MockTokenGenerator{}
+		return adapters.NewHMACTokenGenerator(cfg.JWTSecret, cfg.JWTAccessTokenTTL, cfg.JWTRefreshTokenTTL)
 	})
 
-	// User Repository (implementação MySQL)
-	c.RegisterSingleton("userRepository", func() interface{} {
-		db := c.MustGet("database").(*gorm.DB)
-		return userRepository.NewMySQLUserRepository(db)
-	})
+	return nil
+}
 
-	// Product Repository (implementação MySQL)
-	c.RegisterSingleton("productRepository", func() interface{} {
-		db := c.MustGet("database").(*gorm.DB)
-		return productRepository.NewMySQLProductRepository(db)
-	})
+// registerHandlers registra os handlers de administração pelo tipo: as rotas
+// de main os resolvem com container.Resolve, sem chave string nem type assertion
+func registerHandlers(c *container.Container, cfg *config.Config) {
+	// Outbox Handler (administração); sem banco não há outbox
+	if cfg.Storage == config.StorageDatabase {
+		container.Provide(c, func(c *container.Container) (*outbox.Handler, error) {
+			relay := c.MustGet("outboxRelay").(*outbox.Relay)
+			store := c.MustGet("outboxStore").(*outbox.Store)
+			return outbox.NewHandler(relay, store), nil
+		}, container.DependsOn("outboxRelay", "outboxStore"))
+	}
+
+	// Dead Letter Handler (administração)
+	container.Provide(c, func(c *container.Container) (*deadletter.Handler, error) {
+		store := c.MustGet("deadLetterStore").(events.DeadLetterStore)
+		bus := c.MustGet("eventbus").(*events.EventBus)
+		return deadletter.NewHandler(store, bus), nil
+	}, container.DependsOn("deadLetterStore", "eventbus"))
+
+	// Saga Handler (consulta e recuperação)
+	container.Provide(c, func(c *container.Container) (*sagaStore.Handler, error) {
+		store := c.MustGet("sagaStore").(saga.Store)
+		coordinator := c.MustGet("sagaCoordinator").(*saga.Coordinator)
+		return sagaStore.NewHandler(store, coordinator), nil
+	}, container.DependsOn("sagaStore", "sagaCoordinator"))
 
-	// Order Repository (implementação MySQL)
-	c.RegisterSingleton("orderRepository", func() interface{} {
-		db := c.MustGet("database").(*gorm.DB)
-		return orderRepository.NewMySQLOrderRepository(db)
+	// Debug Handler (grafo de dependências do container)
+	container.Provide(c, func(c *container.Container) (*debug.Handler, error) {
+		return debug.NewHandler(c), nil
 	})
+
+	// Event Store Handler (consulta e replay)
+	container.Provide(c, func(c *container.Container) (*eventstore.Handler, error) {
+		eventLog := c.MustGet("eventLog").(events.EventLog)
+		bus := c.MustGet("eventbus").(*events.EventBus)
+		return eventstore.NewHandler(eventLog, bus), nil
+	}, container.DependsOn("eventLog", "eventbus"))
+
+	// Event Metrics Handler (latência do publish e dos handlers)
+	container.Provide(c, func(c *container.Container) (*eventmetrics.Handler, error) {
+		metrics := c.MustGet("eventMetrics").(*events.LatencyMetrics)
+		return eventmetrics.NewHandler(metrics), nil
+	}, container.DependsOn("eventMetrics"))
+
+	// Audit Handler (consulta à trilha de auditoria)
+	container.Provide(c, func(c *container.Container) (*audit.Handler, error) {
+		auditLog := c.MustGet("auditLog").(contracts.AuditLog)
+		return audit.NewHandler(auditLog), nil
+	}, container.DependsOn("auditLog"))
 }
 
-func registerDomainServices(c *container.Container) {
-	// User Service
-	c.RegisterSingleton("userService", func() interface{} {
-		userRepo := c.MustGet("userRepository").(contracts.UserRepository)
-		passwordHasher := c.MustGet("passwordHasher").(contracts.PasswordHasher)
-		emailService := c.MustGet("emailService").(contracts.EmailService)
-		tokenGenerator := c.MustGet("tokenGenerator").(contracts.TokenGenerator)
-		eventPublisher := c.MustGet("eventbus").(contracts.EventPublisher)
-		logger := c.MustGet("logger").(contracts.Logger)
+// registerScoped registra os serviços de infraestrutura criados uma vez por
+// requisição pelo middleware.Scope e descartados ao fim dela
+func registerScoped(c *container.Container) {
+	// Logger com o ID da requisição
+	c.RegisterScoped(middleware.RequestLoggerKey, func(s *container.Scope) interface{} {
+		logger := s.MustGet("logger").(contracts.Logger)
+		return logger.With(contracts.Field{Key: "request_id", Value: s.MustGet(middleware.RequestIDKey)})
+	}, container.DependsOn("logger"))
+}
 
-		return userService.NewUserService(
-			userRepo,
-			passwordHasher,
-			emailService,
-			tokenGenerator,
-			eventPublisher,
-			logger,
-		)
-	})
+// NewMigrator cria o executor das migrações da infraestrutura compartilhada e
+// dos módulos habilitados, nessa ordem, no dialeto da conexão (mysql ou sqlite)
+func NewMigrator(db *gorm.DB, modules *module.Manager) (*migrate.Migrator, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
 
-	// Product Service
-	c.RegisterSingleton("productService", func() interface{} {
-		productRepo := c.MustGet("productRepository").(contracts.ProductRepository)
-		eventPublisher := c.MustGet("eventbus").(contracts.EventPublisher)
+	sources := append(
+		[]migrate.Source{{Module: database.SharedMigrationsModule, FS: database.Migrations()}},
+		modules.Migrations()...,
+	)
 
-		return productService.NewProductService(
-			productRepo,
-			eventPublisher,
-		)
-	})
+	dialect := db.Dialector.Name()
+	for i, source := range sources {
+		if sources[i], err = source.Dialect(dialect); err != nil {
+			return nil, err
+		}
+	}
 
-	// Order Service
-	c.RegisterSingleton("orderService", func() interface{} {
-		orderRepo := c.MustGet("orderRepository").(contracts.OrderRepository)
-		productSvc := c.MustGet("productService").(contracts.ProductService)
-		userSvc := c.MustGet("userService").(contracts.UserService)
-		eventPublisher := c.MustGet("eventbus").(contracts.EventPublisher)
-
-		return orderService.NewOrderService(
-			orderRepo,
-			productSvc,
-			userSvc,
-			eventPublisher,
-		)
-	})
+	migrations, err := migrate.Load(sources...)
+	if err != nil {
+		return nil, err
+	}
+	return migrate.New(sqlDB, migrations), nil
 }
 
-func registerHandlers(c *container.Container) {
-	// User Handler
-	c.RegisterSingleton("userHandler", func() interface{} {
-		userService := c.MustGet("userService").(contracts.UserService)
-		return userHandler.NewUserHandler(userService)
-	})
+// checkMigrations impede a inicialização com migrações pendentes, a menos que
+// MIGRATIONS_AUTO_APPLY permita aplicá-las
+func checkMigrations(ctx context.Context, db *gorm.DB, cfg *config.Config, modules *module.Manager) error {
+	migrator, err := NewMigrator(db, modules)
+	if err != nil {
+		return err
+	}
 
-	// Product Handler
-	c.RegisterSingleton("productHandler", func() interface{} {
-		productSvc := c.MustGet("productService").(contracts.ProductService)
-		return productHandler.NewProductHandler(productSvc)
-	})
+	pending, err := migrator.Pending(ctx)
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		return nil
+	}
 
-	// Order Handler
-	c.RegisterSingleton("orderHandler", func() interface{} {
-		orderSvc := c.MustGet("orderService").(contracts.OrderService)
-		return orderHandler.NewOrderHandler(orderSvc)
-	})
+	if !cfg.MigrationsAutoApply {
+		return fmt.Errorf("%d pending migrations, starting with %s; run 'go run ./cmd/migrate up' or set MIGRATIONS_AUTO_APPLY=true",
+			len(pending), pending[0].ID())
+	}
+
+	applied, err := migrator.Up(ctx)
+	for _, migration := range applied {
+		log.Printf("Applied migration %s", migration.ID())
+	}
+	return err
+}
+
+// newEventBus cria o event bus no driver e modo definidos pela configuração.
+// No driver disk a entrega é sempre assíncrona e EVENTBUS_MODE é ignorado.
+func newEventBus(cfg *config.Config, deadLetters events.DeadLetterStore, eventLog events.EventLog, registry *events.Registry, logger contracts.Logger, metrics *events.LatencyMetrics) *events.EventBus {
+	retryPolicy := events.DefaultRetryPolicy()
+	retryPolicy.MaxAttempts = cfg.EventBusMaxAttempts
+	retryPolicy.InitialBackoff = cfg.EventBusRetryBackoff
+	retryPolicy.MaxBackoff = cfg.EventBusRetryMaxWait
+
+	opts := []events.Option{
+		events.WithDefaultRetryPolicy(retryPolicy),
+		events.WithDeadLetterStore(deadLetters),
+		events.WithEventLog(eventLog),
+		events.WithRegistry(registry),
+		events.WithPublishInterceptors(
+			events.Logging(logger),
+			metrics.Interceptor(),
+		),
+		// Recover fica por dentro do logging e das métricas para que panics
+		// sejam registrados como erros da tentativa
+		events.WithHandlerInterceptors(
+			events.Logging(logger),
+			metrics.Interceptor(),
+			events.Recover(),
+			events.Timeout(cfg.EventBusTimeout),
+		),
+	}
+
+	switch {
+	case cfg.EventBusDriver == "disk":
+		// Fila durável em disco: cada inscrição consome a partir do seu offset
+		queue, err := events.OpenDiskQueue(events.DiskConfig{
+			Dir:   cfg.EventBusDiskDir,
+			Fsync: cfg.EventBusDiskFsync,
+		})
+		if err != nil {
+			log.Fatalf("Failed to open event bus disk queue: %v", err)
+		}
+		opts = append(opts, events.WithDiskQueue(queue))
+
+	case cfg.EventBusDriver != "memory":
+		log.Fatalf("Invalid event bus driver: %s", cfg.EventBusDriver)
+
+	case cfg.EventBusMode == "async":
+		backpressure, err := events.ParseBackpressurePolicy(cfg.EventBusBackpressure)
+		if err != nil {
+			log.Fatalf("Invalid event bus configuration: %v", err)
+		}
+
+		opts = append(opts, events.WithAsyncDispatch(events.AsyncConfig{
+			Workers:      cfg.EventBusWorkers,
+			QueueSize:    cfg.EventBusQueueSize,
+			Backpressure: backpressure,
+		}))
+	}
+
+	return events.NewEventBus(opts...)
 }
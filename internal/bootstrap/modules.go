@@ -0,0 +1,24 @@
+package bootstrap
+
+import (
+	"go-modular-monolith/internal/modules/order"
+	"go-modular-monolith/internal/modules/product"
+	"go-modular-monolith/internal/modules/stream"
+	"go-modular-monolith/internal/modules/user"
+	"go-modular-monolith/internal/modules/webhook"
+	"go-modular-monolith/internal/shared/config"
+	"go-modular-monolith/pkg/module"
+)
+
+// Modules lista os módulos da aplicação. A ordem da lista não importa: o
+// module.Manager inicia cada módulo depois das dependências que ele declara.
+// Para adicionar um módulo, implemente module.Module e inclua-o aqui.
+func Modules(cfg *config.Config) []module.Module {
+	return []module.Module{
+		user.NewModule(cfg),
+		product.NewModule(cfg),
+		order.NewModule(cfg),
+		webhook.NewModule(cfg),
+		stream.NewModule(cfg),
+	}
+}
@@ -0,0 +1,189 @@
+package bootstrap
+
+import (
+	"context"
+	"log"
+
+	orderRepository "go-modular-monolith/internal/modules/order/repository"
+	productRepository "go-modular-monolith/internal/modules/product/repository"
+	userRepository "go-modular-monolith/internal/modules/user/repository"
+
+	"go-modular-monolith/internal/shared/audit"
+	"go-modular-monolith/internal/shared/config"
+	"go-modular-monolith/internal/shared/database"
+	"go-modular-monolith/internal/shared/deadletter"
+	"go-modular-monolith/internal/shared/eventstore"
+	"go-modular-monolith/internal/shared/outbox"
+	sagaStore "go-modular-monolith/internal/shared/saga"
+	"go-modular-monolith/pkg/container"
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/events"
+	"go-modular-monolith/pkg/module"
+	"go-modular-monolith/pkg/saga"
+
+	"gorm.io/gorm"
+)
+
+// registerDatabaseStorage registra a conexão com o banco e os serviços
+// persistidos nele (STORAGE=database)
+func registerDatabaseStorage(c *container.Container, cfg *config.Config, modules *module.Manager) {
+	// Database Connection
+	c.RegisterSingleton("database", func() interface{} {
+		config := database.GetDefaultConfig()
+		db, err := database.Connect(config)
+		if err != nil {
+			log.Fatalf("Failed to connect to database: %v", err)
+		}
+
+		sqlDB, err := db.DB()
+		if err != nil {
+			log.Fatalf("Failed to get underlying sql.DB: %v", err)
+		}
+
+		// Migrações da infraestrutura e dos módulos habilitados
+		if err := checkMigrations(context.Background(), db, cfg, modules); err != nil {
+			log.Fatalf("Failed to run database migrations: %v", err)
+		}
+
+		c.Append(container.Hook{
+			Name: "database",
+			OnStop: func(ctx context.Context) error {
+				return sqlDB.Close()
+			},
+		})
+
+		return db
+	})
+
+	// Dead Letter Store (eventos que esgotaram as tentativas)
+	c.RegisterSingleton("deadLetterStore", func() interface{} {
+		db := c.MustGet("database").(*gorm.DB)
+		return deadletter.NewGormStore(db)
+	}, container.DependsOn("database"))
+
+	// Event Log (append-only, base para replay)
+	c.RegisterSingleton("eventLog", func() interface{} {
+		db := c.MustGet("database").(*gorm.DB)
+		return eventstore.NewGormEventLog(db)
+	}, container.DependsOn("database"))
+
+	// Audit Log (append-only, gravado na transação da alteração)
+	c.RegisterSingleton("auditLog", func() interface{} {
+		db := c.MustGet("database").(*gorm.DB)
+		return audit.NewGormAuditLog(db)
+	}, container.DependsOn("database"))
+
+	// Transaction Manager (transações propagadas pelo contexto)
+	c.RegisterSingleton("transactionManager", func() interface{} {
+		db := c.MustGet("database").(*gorm.DB)
+		return database.NewTransactionManager(db)
+	}, container.DependsOn("database"))
+
+	// Unit of Work (transação com repositórios ligados a ela)
+	c.RegisterSingleton("unitOfWork", func() interface{} {
+		db := c.MustGet("database").(*gorm.DB)
+		return database.NewDatabase(db, database.Repositories{
+			User: func(tx *gorm.DB) contracts.UserRepository {
+				return userRepository.NewMySQLUserRepository(tx)
+			},
+			Product: productRepository.NewMySQLProductRepository,
+			Order:   orderRepository.NewMySQLOrderRepository,
+		})
+	}, container.DependsOn("database"))
+
+	// Outbox: eventos gravados na transação do agregado e entregues ao bus pelo relay
+	c.RegisterSingleton("outboxStore", func() interface{} {
+		db := c.MustGet("database").(*gorm.DB)
+		return outbox.NewStore(db)
+	}, container.DependsOn("database"))
+
+	c.RegisterSingleton("eventPublisher", func() interface{} {
+		store := c.MustGet("outboxStore").(*outbox.Store)
+		bus := c.MustGet("eventbus").(contracts.EventPublisher)
+		registry := c.MustGet("eventRegistry").(*events.Registry)
+		return outbox.NewPublisher(store, bus, registry)
+	}, container.DependsOn("outboxStore", "eventbus", "eventRegistry"))
+
+	c.RegisterSingleton("outboxRelay", func() interface{} {
+		store := c.MustGet("outboxStore").(*outbox.Store)
+		bus := c.MustGet("eventbus").(*events.EventBus)
+		logger := c.MustGet("logger").(contracts.Logger)
+
+		relay := outbox.NewRelay(store, bus, logger, outbox.RelayConfig{
+			PollInterval: cfg.OutboxPollInterval,
+			BatchSize:    cfg.OutboxBatchSize,
+			Retry: events.RetryPolicy{
+				MaxAttempts:    cfg.OutboxMaxAttempts,
+				InitialBackoff: cfg.OutboxRetryBackoff,
+				MaxBackoff:     cfg.OutboxRetryMaxWait,
+				Multiplier:     2,
+				Jitter:         0.2,
+			},
+			LockTimeout: cfg.OutboxLockTimeout,
+		})
+		c.Append(container.Hook{
+			Name: "outboxRelay",
+			OnStart: func(context.Context) error {
+				relay.Start()
+				return nil
+			},
+			OnStop: relay.Stop,
+		})
+		return relay
+	}, container.DependsOn("outboxStore", "eventbus", "logger"))
+
+	// Sagas: estado persistido a cada passo para retomar ou compensar após restart
+	c.RegisterSingleton("sagaStore", func() interface{} {
+		db := c.MustGet("database").(*gorm.DB)
+		return sagaStore.NewGormStore(db)
+	}, container.DependsOn("database"))
+}
+
+// registerMemoryStorage registra os mesmos serviços sem banco (STORAGE=memory).
+// Tudo se perde ao reiniciar: não há outbox, e os eventos vão ao bus após o
+// commit da transação em memória.
+func registerMemoryStorage(c *container.Container) {
+	c.RegisterSingleton("deadLetterStore", func() interface{} {
+		return events.NewMemoryDeadLetterStore()
+	})
+
+	c.RegisterSingleton("eventLog", func() interface{} {
+		return events.NewMemoryEventLog()
+	})
+
+	c.RegisterSingleton("auditLog", func() interface{} {
+		return audit.NewMemoryAuditLog()
+	})
+
+	c.RegisterSingleton("transactionManager", func() interface{} {
+		return database.NewMemoryTransactionManager()
+	})
+
+	// Unit of Work sobre os repositórios em memória dos módulos habilitados
+	c.RegisterSingleton("unitOfWork", func() interface{} {
+		users, _ := optional(c, "userRepository").(contracts.UserRepository)
+		products, _ := optional(c, "productRepository").(contracts.ProductRepository)
+		orders, _ := optional(c, "orderRepository").(contracts.OrderRepository)
+		return database.NewMemoryDatabase(users, products, orders)
+	}, container.DependsOnOptional("userRepository", "productRepository", "orderRepository"))
+
+	c.RegisterSingleton("eventPublisher", func() interface{} {
+		bus := c.MustGet("eventbus").(contracts.EventPublisher)
+		registry := c.MustGet("eventRegistry").(*events.Registry)
+		logger := c.MustGet("logger").(contracts.Logger)
+		return outbox.NewMemoryPublisher(bus, registry, logger)
+	}, container.DependsOn("eventbus", "eventRegistry", "logger"))
+
+	c.RegisterSingleton("sagaStore", func() interface{} {
+		return saga.NewMemoryStore()
+	})
+}
+
+// optional resolve um serviço que pode não estar registrado (módulo desligado)
+func optional(c *container.Container, name string) interface{} {
+	service, err := c.Get(name)
+	if err != nil {
+		return nil
+	}
+	return service
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"go-modular-monolith/pkg/contracts"
@@ -73,6 +74,15 @@ func (oa *OrderAggregate) Cancel() error {
 	return nil
 }
 
+// CheckDeletable só permite excluir pedidos cancelados ou entregues: os demais
+// ainda reservam estoque, que a exclusão não devolveria
+func (oa *OrderAggregate) CheckDeletable() error {
+	if oa.order.Status != contracts.OrderStatusCancelled && oa.order.Status != contracts.OrderStatusDelivered {
+		return fmt.Errorf("%w: order is %s, cancel it before deleting", contracts.ErrInvalidState, oa.order.Status)
+	}
+	return nil
+}
+
 // AddItem adiciona um item ao pedido
 func (oa *OrderAggregate) AddItem(item contracts.OrderItem) error {
 	if err := validateOrderItem(item); err != nil {
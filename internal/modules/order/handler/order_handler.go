@@ -1,9 +1,11 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	"go-modular-monolith/internal/shared/middleware"
 	"go-modular-monolith/pkg/contracts"
 
 	"github.com/gin-gonic/gin"
@@ -31,6 +33,7 @@ func (h *OrderHandler) CreateOrder(c *gin.Context) {
 		return
 	}
 
+	middleware.SetETag(c, createdOrder.Version)
 	c.JSON(http.StatusCreated, createdOrder)
 }
 
@@ -42,6 +45,7 @@ func (h *OrderHandler) GetOrder(c *gin.Context) {
 		return
 	}
 
+	middleware.SetETag(c, order.Version)
 	c.JSON(http.StatusOK, order)
 }
 
@@ -108,7 +112,7 @@ func (h *OrderHandler) UpdateOrderStatus(c *gin.Context) {
 	}
 
 	if err := h.orderService.UpdateOrderStatus(c.Request.Context(), id, req.Status); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
@@ -119,9 +123,61 @@ func (h *OrderHandler) CancelOrder(c *gin.Context) {
 	id := c.Param("id")
 
 	if err := h.orderService.CancelOrder(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Order cancelled successfully"})
 }
+
+func (h *OrderHandler) DeleteOrder(c *gin.Context) {
+	if err := h.orderService.DeleteOrder(c.Request.Context(), c.Param("id")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *OrderHandler) ListDeletedOrders(c *gin.Context) {
+	orders, err := h.orderService.ListDeletedOrders(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, orders)
+}
+
+func (h *OrderHandler) RestoreOrder(c *gin.Context) {
+	order, err := h.orderService.RestoreOrder(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	middleware.SetETag(c, order.Version)
+	c.JSON(http.StatusOK, order)
+}
+
+func (h *OrderHandler) PurgeOrder(c *gin.Context) {
+	if err := h.orderService.PurgeOrder(c.Request.Context(), c.Param("id")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, contracts.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, contracts.ErrPreconditionFailed):
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+	case errors.Is(err, contracts.ErrVersionConflict), errors.Is(err, contracts.ErrInvalidState):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
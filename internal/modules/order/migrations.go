@@ -0,0 +1,15 @@
+package order
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed migrations/*/*.sql
+var migrationFiles embed.FS
+
+// Migrations retorna as migrações SQL do módulo
+func (m *Module) Migrations() fs.FS {
+	files, _ := fs.Sub(migrationFiles, "migrations")
+	return files
+}
@@ -0,0 +1,22 @@
+-- IF NOT EXISTS: bancos criados pelo antigo AutoMigrate já têm as tabelas
+CREATE TABLE IF NOT EXISTS orders (
+    id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    total DECIMAL(10,2) NOT NULL,
+    created_at DATETIME(3),
+    updated_at DATETIME(3),
+    PRIMARY KEY (id),
+    INDEX idx_orders_user_id (user_id)
+);
+
+CREATE TABLE IF NOT EXISTS order_items (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    order_id VARCHAR(36) NOT NULL,
+    product_id VARCHAR(36) NOT NULL,
+    quantity BIGINT NOT NULL,
+    price DECIMAL(10,2) NOT NULL,
+    PRIMARY KEY (id),
+    INDEX idx_order_items_order_id (order_id),
+    CONSTRAINT fk_orders_items FOREIGN KEY (order_id) REFERENCES orders (id)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE orders
+    DROP INDEX idx_orders_deleted_at,
+    DROP COLUMN deleted_at;
@@ -0,0 +1,4 @@
+-- Soft delete: linhas com deleted_at preenchido ficam fora das consultas
+ALTER TABLE orders
+    ADD COLUMN deleted_at DATETIME(3) NULL,
+    ADD INDEX idx_orders_deleted_at (deleted_at);
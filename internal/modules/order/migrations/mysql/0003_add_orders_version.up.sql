@@ -0,0 +1,2 @@
+-- Controle de concorrência otimista: incrementada a cada Update
+ALTER TABLE orders ADD COLUMN version INT NOT NULL DEFAULT 1;
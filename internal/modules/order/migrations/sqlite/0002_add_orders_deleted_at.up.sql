@@ -0,0 +1,4 @@
+-- Soft delete: linhas com deleted_at preenchido ficam fora das consultas
+ALTER TABLE orders ADD COLUMN deleted_at DATETIME;
+
+CREATE INDEX idx_orders_deleted_at ON orders (deleted_at);
@@ -0,0 +1,117 @@
+package order
+
+import (
+	"context"
+
+	orderHandler "go-modular-monolith/internal/modules/order/handler"
+	orderRepository "go-modular-monolith/internal/modules/order/repository"
+	orderService "go-modular-monolith/internal/modules/order/service"
+	"go-modular-monolith/internal/shared/config"
+	"go-modular-monolith/internal/shared/database"
+	"go-modular-monolith/pkg/container"
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/module"
+	"go-modular-monolith/pkg/saga"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Module é o módulo de pedidos
+type Module struct {
+	module.Base
+	cfg *config.Config
+}
+
+// NewModule cria o módulo de pedidos
+func NewModule(cfg *config.Config) *Module {
+	return &Module{cfg: cfg}
+}
+
+// Name retorna o nome do módulo
+func (m *Module) Name() string {
+	return "order"
+}
+
+// Dependencies: pedidos validam o usuário e reservam estoque de produtos
+func (m *Module) Dependencies() []string {
+	return []string{"user", "product"}
+}
+
+// Register registra repositório, serviço e handler de pedidos
+func (m *Module) Register(c *container.Container) error {
+	// Order Repository (em memória com STORAGE=memory, senão MySQL/SQLite)
+	if m.cfg.Storage == config.StorageMemory {
+		c.RegisterSingleton("orderRepository", func() interface{} {
+			return orderRepository.NewMemoryOrderRepository()
+		})
+	} else {
+		c.RegisterSingleton("orderRepository", func() interface{} {
+			db := c.MustGet("database").(*gorm.DB)
+			return orderRepository.NewMySQLOrderRepository(db)
+		}, container.DependsOn("database"))
+	}
+
+	// Order Service
+	c.RegisterSingleton("orderService", func() interface{} {
+		orderRepo := c.MustGet("orderRepository").(contracts.OrderRepository)
+		productSvc := c.MustGet("productService").(contracts.ProductService)
+		userSvc := c.MustGet("userService").(contracts.UserService)
+		eventPublisher := c.MustGet("eventPublisher").(contracts.EventPublisher)
+		unitOfWork := c.MustGet("unitOfWork").(contracts.Database)
+		auditor := c.MustGet("auditor").(contracts.Auditor)
+		sagas := c.MustGet("sagaCoordinator").(*saga.Coordinator)
+
+		return orderService.NewOrderService(
+			orderRepo,
+			productSvc,
+			userSvc,
+			eventPublisher,
+			unitOfWork,
+			auditor,
+			sagas,
+		)
+	}, container.DependsOn(
+		"orderRepository", "productService", "userService", "eventPublisher", "unitOfWork", "auditor", "sagaCoordinator",
+	))
+
+	// Order Handler
+	c.RegisterSingleton("orderHandler", func() interface{} {
+		orderSvc := c.MustGet("orderService").(contracts.OrderService)
+		return orderHandler.NewOrderHandler(orderSvc)
+	}, container.DependsOn("orderService"))
+
+	return nil
+}
+
+// RegisterRoutes registra as rotas do módulo de pedidos
+func (m *Module) RegisterRoutes(router *gin.RouterGroup, c *container.Container) {
+	orderHandler := c.MustGet("orderHandler").(contracts.OrderHandler)
+
+	orderGroup := router.Group("/orders")
+	{
+		orderGroup.POST("/", orderHandler.CreateOrder)
+		orderGroup.GET("/:id", orderHandler.GetOrder)
+		orderGroup.PUT("/:id/status", orderHandler.UpdateOrderStatus)
+		orderGroup.POST("/:id/cancel", orderHandler.CancelOrder)
+		orderGroup.DELETE("/:id", orderHandler.DeleteOrder)
+		orderGroup.GET("/user/:user_id", orderHandler.GetOrdersByUser)
+	}
+
+	// Administração dos pedidos excluídos
+	adminGroup := router.Group("/admin/orders")
+	{
+		adminGroup.GET("/deleted", orderHandler.ListDeletedOrders)
+		adminGroup.POST("/:id/restore", orderHandler.RestoreOrder)
+		adminGroup.DELETE("/:id", orderHandler.PurgeOrder)
+	}
+}
+
+// Health verifica a conexão e as tabelas de pedidos
+func (m *Module) Health(ctx context.Context, c *container.Container) error {
+	if m.cfg.Storage == config.StorageMemory {
+		return nil // Sem banco a verificar
+	}
+	db := c.MustGet("database").(*gorm.DB)
+	return database.CheckTables(ctx, db, &database.OrderModel{}, &database.OrderItemModel{})
+}
@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go-modular-monolith/internal/shared/database"
+	"go-modular-monolith/pkg/contracts"
+)
+
+// memoryOrderRepository implementa OrderRepository em memória.
+// Alterações feitas em uma transação em memória são desfeitas no rollback.
+type memoryOrderRepository struct {
+	orders map[string]*contracts.Order
+	mu     sync.RWMutex
+}
+
+// NewMemoryOrderRepository cria um repositório de pedidos em memória
+func NewMemoryOrderRepository() contracts.OrderRepository {
+	return &memoryOrderRepository{
+		orders: make(map[string]*contracts.Order),
+	}
+}
+
+func (r *memoryOrderRepository) Create(ctx context.Context, order *contracts.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.orders[order.ID]; exists {
+		return fmt.Errorf("failed to create order: order %s %w", order.ID, contracts.ErrAlreadyExists)
+	}
+	order.Version = 1
+	r.orders[order.ID] = copyOrder(order)
+	database.OnRollback(ctx, r.undoCreate(order.ID))
+	return nil
+}
+
+func (r *memoryOrderRepository) GetByID(ctx context.Context, id string) (*contracts.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	order, exists := r.orders[id]
+	if !exists || order.DeletedAt != nil {
+		return nil, fmt.Errorf("order %w", contracts.ErrNotFound)
+	}
+	return copyOrder(order), nil
+}
+
+// GetByUserID retorna os pedidos do usuário do mais antigo para o mais recente
+func (r *memoryOrderRepository) GetByUserID(ctx context.Context, userID string) ([]*contracts.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	orders := make([]*contracts.Order, 0)
+	for _, order := range r.orders {
+		if order.UserID == userID && order.DeletedAt == nil {
+			orders = append(orders, copyOrder(order))
+		}
+	}
+
+	sort.Slice(orders, func(i, j int) bool {
+		if !orders[i].CreatedAt.Equal(orders[j].CreatedAt) {
+			return orders[i].CreatedAt.Before(orders[j].CreatedAt)
+		}
+		return orders[i].ID < orders[j].ID
+	})
+
+	return orders, nil
+}
+
+func (r *memoryOrderRepository) Update(ctx context.Context, order *contracts.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, exists := r.orders[order.ID]
+	if !exists || previous.DeletedAt != nil {
+		return fmt.Errorf("order %w", contracts.ErrNotFound)
+	}
+	if previous.Version != order.Version {
+		return fmt.Errorf("order %w", contracts.ErrVersionConflict)
+	}
+
+	// Como no MySQL, a data de criação e a exclusão não são alteradas
+	updated := copyOrder(order)
+	updated.CreatedAt = previous.CreatedAt
+	updated.DeletedAt = nil
+	updated.Version++
+	r.orders[order.ID] = updated
+	order.Version = updated.Version
+	database.OnRollback(ctx, r.undoUpdate(previous))
+	return nil
+}
+
+func (r *memoryOrderRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, exists := r.orders[id]
+	if !exists || previous.DeletedAt != nil {
+		return fmt.Errorf("order %w", contracts.ErrNotFound)
+	}
+	deleted := copyOrder(previous)
+	now := time.Now()
+	deleted.DeletedAt = &now
+	r.orders[id] = deleted
+	database.OnRollback(ctx, r.undoDelete(id))
+	return nil
+}
+
+// ListDeleted lista os pedidos excluídos, do mais antigo para o mais recente
+func (r *memoryOrderRepository) ListDeleted(ctx context.Context) ([]*contracts.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	orders := make([]*contracts.Order, 0)
+	for _, order := range r.orders {
+		if order.DeletedAt != nil {
+			orders = append(orders, copyOrder(order))
+		}
+	}
+
+	sort.Slice(orders, func(i, j int) bool {
+		if !orders[i].DeletedAt.Equal(*orders[j].DeletedAt) {
+			return orders[i].DeletedAt.Before(*orders[j].DeletedAt)
+		}
+		return orders[i].ID < orders[j].ID
+	})
+
+	return orders, nil
+}
+
+func (r *memoryOrderRepository) Restore(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, exists := r.orders[id]
+	if !exists || previous.DeletedAt == nil {
+		return fmt.Errorf("deleted order %w", contracts.ErrNotFound)
+	}
+	restored := copyOrder(previous)
+	restored.DeletedAt = nil
+	restored.UpdatedAt = time.Now()
+	r.orders[id] = restored
+	database.OnRollback(ctx, r.undoRestore(id, previous.DeletedAt))
+	return nil
+}
+
+func (r *memoryOrderRepository) Purge(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, exists := r.orders[id]
+	if !exists || previous.DeletedAt == nil {
+		return fmt.Errorf("deleted order %w", contracts.ErrNotFound)
+	}
+	delete(r.orders, id)
+	database.OnRollback(ctx, r.undoPurge(previous))
+	return nil
+}
+
+// As funções de undo desfazem apenas a própria alteração, sobre o estado atual
+// do pedido: sem isolamento, outras transações podem tê-lo alterado depois.
+
+func (r *memoryOrderRepository) undoCreate(id string) func() {
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.orders, id)
+	}
+}
+
+// undoUpdate volta os campos ao valor anterior, preservando uma exclusão feita
+// depois da atualização. A versão avança para invalidar ETags já entregues.
+func (r *memoryOrderRepository) undoUpdate(previous *contracts.Order) func() {
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		current, exists := r.orders[previous.ID]
+		if !exists {
+			return
+		}
+		restored := copyOrder(previous)
+		restored.DeletedAt = current.DeletedAt
+		restored.Version = current.Version + 1
+		r.orders[previous.ID] = restored
+	}
+}
+
+func (r *memoryOrderRepository) undoDelete(id string) func() {
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if current, exists := r.orders[id]; exists {
+			restored := copyOrder(current)
+			restored.DeletedAt = nil
+			r.orders[id] = restored
+		}
+	}
+}
+
+func (r *memoryOrderRepository) undoRestore(id string, deletedAt *time.Time) func() {
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if current, exists := r.orders[id]; exists {
+			deleted := copyOrder(current)
+			deleted.DeletedAt = deletedAt
+			r.orders[id] = deleted
+		}
+	}
+}
+
+func (r *memoryOrderRepository) undoPurge(previous *contracts.Order) func() {
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if _, exists := r.orders[previous.ID]; !exists {
+			r.orders[previous.ID] = previous
+		}
+	}
+}
+
+// copyOrder copia o pedido e os itens, para que o chamador não altere o estado guardado
+func copyOrder(order *contracts.Order) *contracts.Order {
+	copied := *order
+	copied.Items = append([]contracts.OrderItem(nil), order.Items...)
+	if order.DeletedAt != nil {
+		deletedAt := *order.DeletedAt
+		copied.DeletedAt = &deletedAt
+	}
+	return &copied
+}
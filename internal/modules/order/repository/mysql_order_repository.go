@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"go-modular-monolith/internal/shared/database"
@@ -26,11 +27,15 @@ func NewMySQLOrderRepository(db *gorm.DB) contracts.OrderRepository {
 func (r *mysqlOrderRepository) Create(ctx context.Context, order *contracts.Order) error {
 	orderModel := &database.OrderModel{}
 	orderModel.FromContract(order)
+	orderModel.Version = 1
 
 	// Usar transação para garantir consistência entre order e order_items
-	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err := database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		// Criar o pedido
 		if err := tx.Create(orderModel).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return fmt.Errorf("failed to create order: %w", contracts.ErrAlreadyExists)
+			}
 			return fmt.Errorf("failed to create order: %w", err)
 		}
 
@@ -41,6 +46,7 @@ func (r *mysqlOrderRepository) Create(ctx context.Context, order *contracts.Orde
 		return err
 	}
 
+	order.Version = orderModel.Version
 	return nil
 }
 
@@ -48,9 +54,9 @@ func (r *mysqlOrderRepository) Create(ctx context.Context, order *contracts.Orde
 func (r *mysqlOrderRepository) GetByID(ctx context.Context, id string) (*contracts.Order, error) {
 	var orderModel database.OrderModel
 
-	if err := r.db.WithContext(ctx).Preload("Items").Where("id = ?", id).First(&orderModel).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Preload("Items", orderItems).Where("id = ?", id).First(&orderModel).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("order not found")
+			return nil, fmt.Errorf("order %w", contracts.ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get order by ID: %w", err)
 	}
@@ -58,11 +64,11 @@ func (r *mysqlOrderRepository) GetByID(ctx context.Context, id string) (*contrac
 	return orderModel.ToContract(), nil
 }
 
-// GetByUserID busca todos os pedidos de um usuário
+// GetByUserID busca todos os pedidos de um usuário, do mais antigo para o mais recente
 func (r *mysqlOrderRepository) GetByUserID(ctx context.Context, userID string) ([]*contracts.Order, error) {
 	var orderModels []database.OrderModel
 
-	if err := r.db.WithContext(ctx).Preload("Items").Where("user_id = ?", userID).Find(&orderModels).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Preload("Items", orderItems).Where("user_id = ?", userID).Order("created_at, id").Find(&orderModels).Error; err != nil {
 		return nil, fmt.Errorf("failed to get orders by user ID: %w", err)
 	}
 
@@ -74,17 +80,18 @@ func (r *mysqlOrderRepository) GetByUserID(ctx context.Context, userID string) (
 	return orders, nil
 }
 
-// Update atualiza um pedido existente
+// Update atualiza um pedido existente, se ainda estiver na versão informada
 func (r *mysqlOrderRepository) Update(ctx context.Context, order *contracts.Order) error {
 	orderModel := &database.OrderModel{}
 	orderModel.FromContract(order)
 
 	// Usar transação para atualizar order e order_items
-	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err := database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		// Atualizar o pedido principal (sem os items)
-		result := tx.Model(&database.OrderModel{}).Where("id = ?", order.ID).Updates(map[string]interface{}{
+		result := tx.Model(&database.OrderModel{}).Where("id = ? AND version = ?", order.ID, order.Version).Updates(map[string]interface{}{
 			"status":     orderModel.Status,
 			"total":      orderModel.Total,
+			"version":    order.Version + 1,
 			"updated_at": orderModel.UpdatedAt,
 		})
 
@@ -93,7 +100,7 @@ func (r *mysqlOrderRepository) Update(ctx context.Context, order *contracts.Orde
 		}
 
 		if result.RowsAffected == 0 {
-			return fmt.Errorf("order not found")
+			return fmt.Errorf("order %w", database.VersionMismatch(ctx, tx, &database.OrderModel{}, order.ID))
 		}
 
 		// Remover items existentes
@@ -110,26 +117,76 @@ func (r *mysqlOrderRepository) Update(ctx context.Context, order *contracts.Orde
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	order.Version++
+	return nil
 }
 
-// Delete remove um pedido do banco de dados
+// Delete marca o pedido como excluído (soft delete); os itens são mantidos
 func (r *mysqlOrderRepository) Delete(ctx context.Context, id string) error {
-	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Remover items primeiro (foreign key constraint)
+	result := database.Conn(ctx, r.db).Where("id = ?", id).Delete(&database.OrderModel{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete order: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("order %w", contracts.ErrNotFound)
+	}
+
+	return nil
+}
+
+// ListDeleted lista os pedidos excluídos, do mais antigo para o mais recente
+func (r *mysqlOrderRepository) ListDeleted(ctx context.Context) ([]*contracts.Order, error) {
+	var orderModels []database.OrderModel
+
+	if err := database.Conn(ctx, r.db).Unscoped().Preload("Items", orderItems).Where("deleted_at IS NOT NULL").Order("deleted_at, id").Find(&orderModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to list deleted orders: %w", err)
+	}
+
+	orders := make([]*contracts.Order, len(orderModels))
+	for i, model := range orderModels {
+		orders[i] = model.ToContract()
+	}
+
+	return orders, nil
+}
+
+// Restore desfaz a exclusão de um pedido
+func (r *mysqlOrderRepository) Restore(ctx context.Context, id string) error {
+	result := database.Conn(ctx, r.db).Unscoped().Model(&database.OrderModel{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return fmt.Errorf("failed to restore order: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("deleted order %w", contracts.ErrNotFound)
+	}
+
+	return nil
+}
+
+// Purge remove definitivamente um pedido excluído e seus itens
+func (r *mysqlOrderRepository) Purge(ctx context.Context, id string) error {
+	err := database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// Remover items primeiro (foreign key constraint); se o pedido não
+		// estiver excluído, a transação desfaz a remoção
 		if err := tx.Where("order_id = ?", id).Delete(&database.OrderItemModel{}).Error; err != nil {
-			return fmt.Errorf("failed to delete order items: %w", err)
+			return fmt.Errorf("failed to purge order items: %w", err)
 		}
 
-		// Remover o pedido
-		result := tx.Where("id = ?", id).Delete(&database.OrderModel{})
+		result := tx.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).Delete(&database.OrderModel{})
 		if result.Error != nil {
-			return fmt.Errorf("failed to delete order: %w", result.Error)
+			return fmt.Errorf("failed to purge order: %w", result.Error)
 		}
 
 		if result.RowsAffected == 0 {
-			return fmt.Errorf("order not found")
+			return fmt.Errorf("deleted order %w", contracts.ErrNotFound)
 		}
 
 		return nil
@@ -137,3 +194,8 @@ func (r *mysqlOrderRepository) Delete(ctx context.Context, id string) error {
 
 	return err
 }
+
+// orderItems carrega os itens na ordem em que foram gravados
+func orderItems(db *gorm.DB) *gorm.DB {
+	return db.Order("id")
+}
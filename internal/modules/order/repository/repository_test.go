@@ -0,0 +1,29 @@
+package repository_test
+
+import (
+	"testing"
+
+	"go-modular-monolith/internal/modules/order"
+	"go-modular-monolith/internal/modules/order/repository"
+	"go-modular-monolith/internal/shared/database/databasetest"
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/contracts/contractstest"
+	"go-modular-monolith/pkg/migrate"
+)
+
+func TestOrderRepository(t *testing.T) {
+	t.Run("memory", func(t *testing.T) {
+		contractstest.TestOrderRepository(t, func(t *testing.T) contracts.OrderRepository {
+			return repository.NewMemoryOrderRepository()
+		})
+	})
+
+	migrations := migrate.Source{Module: "order", FS: new(order.Module).Migrations()}
+	for name, url := range databasetest.URLs() {
+		t.Run(name, func(t *testing.T) {
+			contractstest.TestOrderRepository(t, func(t *testing.T) contracts.OrderRepository {
+				return repository.NewMySQLOrderRepository(databasetest.Open(t, url, migrations))
+			})
+		})
+	}
+}
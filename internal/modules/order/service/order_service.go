@@ -3,11 +3,13 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"go-modular-monolith/internal/modules/order/domain"
 	"go-modular-monolith/pkg/contracts"
 	"go-modular-monolith/pkg/events"
+	"go-modular-monolith/pkg/saga"
 
 	"github.com/google/uuid"
 )
@@ -18,6 +20,9 @@ type OrderService struct {
 	productService contracts.ProductService // Para validar produtos e verificar estoque
 	userService    contracts.UserService    // Para validar usuários
 	eventPublisher contracts.EventPublisher
+	unitOfWork     contracts.Database
+	auditor        contracts.Auditor
+	placeOrder     *saga.Saga[placeOrderData]
 }
 
 // NewOrderService cria uma nova instância do serviço de pedidos
@@ -26,128 +31,68 @@ func NewOrderService(
 	productService contracts.ProductService,
 	userService contracts.UserService,
 	eventPublisher contracts.EventPublisher,
+	unitOfWork contracts.Database,
+	auditor contracts.Auditor,
+	sagas *saga.Coordinator,
 ) contracts.OrderService {
-	return &OrderService{
+	s := &OrderService{
 		orderRepo:      orderRepo,
 		productService: productService,
 		userService:    userService,
 		eventPublisher: eventPublisher,
+		unitOfWork:     unitOfWork,
+		auditor:        auditor,
 	}
+	s.placeOrder = saga.MustRegister(sagas, s.placeOrderDefinition())
+	return s
 }
 
-// CreateOrder cria um novo pedido
+// CreateOrder cria um novo pedido executando a saga order.placement: valida o
+// usuário, reserva o estoque, grava o pedido e publica o evento, cada passo na
+// própria unidade de trabalho. Se um passo falha, os anteriores são compensados
+// em ordem inversa e o erro do passo é retornado.
 func (s *OrderService) CreateOrder(ctx context.Context, req contracts.CreateOrderRequest) (*contracts.Order, error) {
-	// Validar se o usuário existe
-	user, err := s.userService.GetUserByID(ctx, req.UserID)
-	if err != nil || user == nil {
-		return nil, errors.New("invalid user ID")
+	if len(req.Items) == 0 {
+		return nil, errors.New("order must have at least one item")
 	}
-
-	// Cache de produtos para evitar múltiplas consultas
-	productCache := make(map[string]*contracts.Product)
-
-	// Validar produtos, calcular preços e cachear produtos
-	orderItems := make([]contracts.OrderItem, len(req.Items))
-	for i, item := range req.Items {
-		// Buscar produto (apenas uma vez por produto único)
-		product, exists := productCache[item.ProductID]
-		if !exists {
-			product, err = s.productService.GetProductByID(ctx, item.ProductID)
-			if err != nil || product == nil {
-				return nil, errors.New("invalid product ID: " + item.ProductID)
-			}
-			// Cachear produto para futuras consultas
-			productCache[item.ProductID] = product
-		}
-
-		// Verificar se há estoque suficiente
-		if product.Stock < item.Quantity {
-			return nil, errors.New("insufficient stock for product: " + product.Name)
-		}
-
-		orderItems[i] = contracts.OrderItem{
-			ProductID: item.ProductID,
-			Quantity:  item.Quantity,
-			Price:     product.Price, // Usar o preço atual do produto
+	for _, item := range req.Items {
+		if item.Quantity <= 0 {
+			return nil, errors.New("quantity must be greater than zero")
 		}
 	}
 
-	// Gerar ID único para o pedido
-	orderID := uuid.New().String()
-
-	// Criar entidade de domínio
-	order, err := domain.NewOrder(orderID, req.UserID, orderItems)
-	if err != nil {
+	data := &placeOrderData{OrderID: uuid.New().String(), Request: req}
+	if _, err := s.placeOrder.Run(ctx, data); err != nil {
 		return nil, err
 	}
 
-	// Criar aggregate e validar
-	orderAggregate := domain.NewOrderAggregate(order)
-	if err := orderAggregate.IsValid(); err != nil {
-		return nil, err
-	}
-
-	// Reduzir estoque dos produtos (usar cache para evitar consultas desnecessárias)
-	updatedProducts := make(map[string]int) // Controla quantidade consumida por produto
-
-	for _, item := range orderItems {
-		// Usar produto do cache
-		product := productCache[item.ProductID]
-
-		// Somar quantidade total se o mesmo produto aparece múltiplas vezes no pedido
-		totalQuantityUsed := updatedProducts[item.ProductID] + item.Quantity
-
-		// Calcular novo estoque (estoque atual - quantidade do item)
-		newStock := product.Stock - item.Quantity
-		if newStock < 0 {
-			return nil, errors.New("insufficient stock for product: " + product.Name)
-		}
-
-		// Atualizar cache local apenas se necessário
-		if updatedProducts[item.ProductID] == 0 || totalQuantityUsed != updatedProducts[item.ProductID] {
+	return data.Order, nil
+}
 
-			// Atualizar cache com novo estoque
-			product.Stock = newStock
-			updatedProducts[item.ProductID] = totalQuantityUsed
-		}
+// withinUnitOfWork executa fn em uma transação do banco. O contexto passado
+// a fn carrega a transação, então os serviços de outros módulos e o outbox
+// participam dela.
+func (s *OrderService) withinUnitOfWork(ctx context.Context, fn func(ctx context.Context, tx contracts.Transaction) error) error {
+	tx, err := s.unitOfWork.BeginTx(ctx)
+	if err != nil {
+		return err
 	}
 
-	// Aplicar todas as atualizações de estoque no banco
-	for k, v := range productCache {
-		if err := s.productService.UpdateStock(ctx, k, v.Stock); err != nil {
-			return nil, errors.New("failed to update stock for product: " + k)
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
 		}
-	}
+	}()
 
-	// Persistir pedido
-	orderToSave := orderAggregate.GetOrder()
-	if err := s.orderRepo.Create(ctx, &orderToSave.Order); err != nil {
-		// Se falhar, reverter estoque usando o cache (mais eficiente)
-		for productID, quantityUsed := range updatedProducts {
-			if product := productCache[productID]; product != nil {
-				restoredStock := product.Stock + quantityUsed
-				s.productService.UpdateStock(ctx, productID, restoredStock) // Reverter
-			}
+	if err := fn(tx.Context(ctx), tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return errors.Join(err, rbErr)
 		}
-		return nil, errors.New("failed to create order")
-	}
-
-	// Publicar evento
-	event := contracts.Event{
-		Type:      events.OrderCreatedEventType,
-		Timestamp: time.Now(),
-		Payload: contracts.OrderCreatedEvent{
-			OrderID: orderID,
-			UserID:  req.UserID,
-			Total:   orderToSave.Total,
-		},
-	}
-
-	if err := s.eventPublisher.Publish(ctx, event); err != nil {
-		// Log do erro mas não falhar a operação
+		return err
 	}
 
-	return &orderToSave.Order, nil
+	return tx.Commit()
 }
 
 // GetOrderByID obtém um pedido por ID
@@ -194,8 +139,8 @@ func (s *OrderService) UpdateOrderStatus(ctx context.Context, id string, status
 		return errors.New("order ID cannot be empty")
 	}
 
-	// Buscar pedido existente
-	existingOrder, err := s.orderRepo.GetByID(ctx, id)
+	// Buscar pedido existente no primário: uma réplica atrasada perderia alterações
+	existingOrder, err := s.orderRepo.GetByID(contracts.WithPrimaryReads(ctx), id)
 	if err != nil {
 		return err
 	}
@@ -204,6 +149,11 @@ func (s *OrderService) UpdateOrderStatus(ctx context.Context, id string, status
 		return errors.New("order not found")
 	}
 
+	// Versão pedida pelo cliente (If-Match)
+	if err := contracts.CheckIfMatch(ctx, existingOrder.Version); err != nil {
+		return err
+	}
+
 	// Criar domain object
 	orderDomain := &domain.Order{
 		Order: *existingOrder,
@@ -215,90 +165,228 @@ func (s *OrderService) UpdateOrderStatus(ctx context.Context, id string, status
 		return err
 	}
 
-	// Persistir alterações
+	// Persistir alterações; uma atualização concorrente resulta em ErrVersionConflict
 	updatedOrder := orderAggregate.GetOrder()
-	if err := s.orderRepo.Update(ctx, &updatedOrder.Order); err != nil {
-		return errors.New("failed to update order")
-	}
+	return s.withinUnitOfWork(ctx, func(ctx context.Context, tx contracts.Transaction) error {
+		if err := tx.OrderRepository().Update(ctx, &updatedOrder.Order); err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
+		}
 
-	// Publicar evento
-	event := contracts.Event{
-		Type:      events.OrderStatusUpdatedEventType,
-		Timestamp: time.Now(),
-		Payload: map[string]interface{}{
-			"order_id":   id,
-			"new_status": string(status),
-			"old_status": string(existingOrder.Status),
-		},
-	}
+		// Publicar evento
+		event := contracts.Event{
+			Type:      events.OrderStatusUpdatedEventType,
+			Timestamp: time.Now(),
+			Payload: contracts.OrderStatusUpdatedEvent{
+				OrderID:   id,
+				UserID:    existingOrder.UserID,
+				OldStatus: existingOrder.Status,
+				NewStatus: status,
+			},
+		}
 
-	if err := s.eventPublisher.Publish(ctx, event); err != nil {
-		// Log do erro mas não falhar a operação
-	}
+		if err := s.eventPublisher.Publish(ctx, event); err != nil {
+			// Log do erro mas não falhar a operação
+		}
 
-	return nil
+		return s.auditor.Record(ctx, contracts.AuditEntityOrder, id, contracts.AuditActionUpdate, existingOrder, &updatedOrder.Order)
+	})
 }
 
-// CancelOrder cancela um pedido
+// CancelOrder cancela um pedido. A leitura do pedido, a devolução do estoque,
+// o cancelamento e o evento rodam na mesma unidade de trabalho: qualquer
+// falha desfaz o cancelamento inteiro.
 func (s *OrderService) CancelOrder(ctx context.Context, id string) error {
 	if id == "" {
 		return errors.New("order ID cannot be empty")
 	}
 
-	// Buscar pedido existente
-	existingOrder, err := s.orderRepo.GetByID(ctx, id)
-	if err != nil {
-		return err
-	}
+	return s.withinUnitOfWork(ctx, func(ctx context.Context, tx contracts.Transaction) error {
+		// Buscar pedido existente
+		existingOrder, err := tx.OrderRepository().GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
 
-	if existingOrder == nil {
-		return errors.New("order not found")
-	}
+		if existingOrder == nil {
+			return errors.New("order not found")
+		}
 
-	// Criar domain object
-	orderDomain := &domain.Order{
-		Order: *existingOrder,
-	}
+		if err := contracts.CheckIfMatch(ctx, existingOrder.Version); err != nil {
+			return err
+		}
 
-	// Criar aggregate e cancelar
-	orderAggregate := domain.NewOrderAggregate(orderDomain)
-	if err := orderAggregate.Cancel(); err != nil {
-		return err
-	}
+		// Criar aggregate e cancelar
+		orderAggregate := domain.NewOrderAggregate(&domain.Order{Order: *existingOrder})
+		if err := orderAggregate.Cancel(); err != nil {
+			return err
+		}
 
-	// Restaurar estoque dos produtos (se o pedido ainda estava pendente/confirmado)
-	if existingOrder.Status == contracts.OrderStatusPending || existingOrder.Status == contracts.OrderStatusConfirmed {
-		for _, item := range existingOrder.Items {
-			// Buscar produto para ter o estoque atual e restaurar
-			if product, err := s.productService.GetProductByID(ctx, item.ProductID); err == nil && product != nil {
-				restoredStock := product.Stock + item.Quantity
-				if err := s.productService.UpdateStock(ctx, item.ProductID, restoredStock); err != nil {
-					// Log do erro mas continuar o cancelamento
+		if existingOrder.Status == contracts.OrderStatusPending || existingOrder.Status == contracts.OrderStatusConfirmed {
+			for _, item := range existingOrder.Items {
+				if _, err := s.productService.AdjustStock(ctx, item.ProductID, item.Quantity); err != nil {
+					return fmt.Errorf("failed to restore stock for product %s: %w", item.ProductID, err)
 				}
 			}
 		}
+
+		cancelledOrder := orderAggregate.GetOrder()
+		if err := tx.OrderRepository().Update(ctx, &cancelledOrder.Order); err != nil {
+			return fmt.Errorf("failed to cancel order: %w", err)
+		}
+
+		if err := s.auditor.Record(ctx, contracts.AuditEntityOrder, id, contracts.AuditActionUpdate, existingOrder, &cancelledOrder.Order); err != nil {
+			return err
+		}
+
+		event := contracts.Event{
+			Type:      events.OrderCancelledEventType,
+			Timestamp: time.Now(),
+			Payload: contracts.OrderCancelledEvent{
+				OrderID: id,
+				UserID:  existingOrder.UserID,
+				Total:   existingOrder.Total,
+			},
+		}
+
+		if err := s.eventPublisher.Publish(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish order cancelled event: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// DeleteOrder exclui um pedido (soft delete); ele pode ser restaurado até o
+// purge. Só pedidos cancelados ou entregues podem ser excluídos: os demais
+// ainda reservam estoque, e a exclusão retorna contracts.ErrInvalidState.
+func (s *OrderService) DeleteOrder(ctx context.Context, id string) error {
+	if id == "" {
+		return errors.New("order ID cannot be empty")
 	}
 
-	// Persistir alterações
-	cancelledOrder := orderAggregate.GetOrder()
-	if err := s.orderRepo.Update(ctx, &cancelledOrder.Order); err != nil {
-		return errors.New("failed to cancel order")
+	return s.withinUnitOfWork(ctx, func(ctx context.Context, tx contracts.Transaction) error {
+		existingOrder, err := tx.OrderRepository().GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := domain.NewOrderAggregate(&domain.Order{Order: *existingOrder}).CheckDeletable(); err != nil {
+			return err
+		}
+
+		if err := tx.OrderRepository().Delete(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete order: %w", err)
+		}
+
+		if err := s.auditor.Record(ctx, contracts.AuditEntityOrder, id, contracts.AuditActionDelete, existingOrder, nil); err != nil {
+			return err
+		}
+
+		event := contracts.Event{
+			Type:      events.OrderDeletedEventType,
+			Timestamp: time.Now(),
+			Payload: contracts.OrderDeletedEvent{
+				OrderID: id,
+				UserID:  existingOrder.UserID,
+			},
+		}
+
+		if err := s.eventPublisher.Publish(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish order deleted event: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// ListDeletedOrders lista os pedidos excluídos
+func (s *OrderService) ListDeletedOrders(ctx context.Context) ([]*contracts.Order, error) {
+	return s.orderRepo.ListDeleted(ctx)
+}
+
+// RestoreOrder desfaz a exclusão de um pedido
+func (s *OrderService) RestoreOrder(ctx context.Context, id string) (*contracts.Order, error) {
+	var restored *contracts.Order
+	err := s.withinUnitOfWork(ctx, func(ctx context.Context, tx contracts.Transaction) error {
+		if err := tx.OrderRepository().Restore(ctx, id); err != nil {
+			return fmt.Errorf("failed to restore order: %w", err)
+		}
+
+		order, err := tx.OrderRepository().GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.auditor.Record(ctx, contracts.AuditEntityOrder, id, contracts.AuditActionRestore, nil, order); err != nil {
+			return err
+		}
+
+		event := contracts.Event{
+			Type:      events.OrderRestoredEventType,
+			Timestamp: time.Now(),
+			Payload: contracts.OrderRestoredEvent{
+				OrderID: id,
+				UserID:  order.UserID,
+			},
+		}
+
+		if err := s.eventPublisher.Publish(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish order restored event: %w", err)
+		}
+
+		restored = order
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Publicar evento
-	event := contracts.Event{
-		Type:      events.OrderCancelledEventType,
-		Timestamp: time.Now(),
-		Payload: map[string]interface{}{
-			"order_id": id,
-			"user_id":  existingOrder.UserID,
-			"total":    existingOrder.Total,
-		},
+	return restored, nil
+}
+
+// PurgeOrder remove definitivamente um pedido excluído e seus itens
+func (s *OrderService) PurgeOrder(ctx context.Context, id string) error {
+	return s.withinUnitOfWork(ctx, func(ctx context.Context, tx contracts.Transaction) error {
+		order, err := deletedOrder(ctx, tx.OrderRepository(), id)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.OrderRepository().Purge(ctx, id); err != nil {
+			return fmt.Errorf("failed to purge order: %w", err)
+		}
+
+		// O snapshot removido, com os itens, fica só na trilha de auditoria
+		if err := s.auditor.Record(ctx, contracts.AuditEntityOrder, id, contracts.AuditActionPurge, order, nil); err != nil {
+			return err
+		}
+
+		event := contracts.Event{
+			Type:      events.OrderPurgedEventType,
+			Timestamp: time.Now(),
+			Payload:   contracts.OrderPurgedEvent{OrderID: id},
+		}
+
+		if err := s.eventPublisher.Publish(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish order purged event: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// deletedOrder busca o pedido entre os excluídos
+func deletedOrder(ctx context.Context, repo contracts.OrderRepository, id string) (*contracts.Order, error) {
+	orders, err := repo.ListDeleted(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted orders: %w", err)
 	}
 
-	if err := s.eventPublisher.Publish(ctx, event); err != nil {
-		// Log do erro mas não falhar a operação
+	for _, order := range orders {
+		if order.ID == id {
+			return order, nil
+		}
 	}
 
-	return nil
+	return nil, fmt.Errorf("deleted order %w", contracts.ErrNotFound)
 }
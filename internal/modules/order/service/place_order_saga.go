@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-modular-monolith/internal/modules/order/domain"
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/events"
+	"go-modular-monolith/pkg/saga"
+)
+
+// PlaceOrderSagaName identifica a saga de criação de pedidos no store
+const PlaceOrderSagaName = "order.placement"
+
+// placeOrderData é o estado persistido da saga de criação de pedido
+type placeOrderData struct {
+	OrderID  string                       `json:"order_id"`
+	Request  contracts.CreateOrderRequest `json:"request"`
+	Reserved []contracts.OrderItem        `json:"reserved,omitempty"` // Itens com estoque reservado e preço vigente
+	Order    *contracts.Order             `json:"order,omitempty"`
+}
+
+// placeOrderDefinition declara os passos da criação de pedido. Cada passo (e
+// cada compensação) roda em uma unidade de trabalho aninhada na transação em
+// que o coordenador grava o progresso: o passo e o progresso são confirmados
+// juntos. Uma saga interrompida é compensada na recuperação: o cliente não
+// recebeu resposta e pode repetir a requisição.
+func (s *OrderService) placeOrderDefinition() saga.Definition[placeOrderData] {
+	return saga.Definition[placeOrderData]{
+		Name:     PlaceOrderSagaName,
+		Recovery: saga.RecoverCompensate,
+		Steps: []saga.Step[placeOrderData]{
+			{Name: "validate_user", Action: s.inUnitOfWork(s.validateUser)},
+			{Name: "reserve_stock", Action: s.inUnitOfWork(s.reserveStock), Compensate: s.inUnitOfWork(s.releaseStock)},
+			{Name: "persist_order", Action: s.inUnitOfWork(s.persistOrder), Compensate: s.inUnitOfWork(s.deleteOrder)},
+			{Name: "publish_order_created", Action: s.inUnitOfWork(s.publishOrderCreated)},
+		},
+	}
+}
+
+// placeOrderStep é um passo da saga executado com a transação da unidade de trabalho
+type placeOrderStep func(ctx context.Context, tx contracts.Transaction, data *placeOrderData) error
+
+func (s *OrderService) inUnitOfWork(step placeOrderStep) func(ctx context.Context, data *placeOrderData) error {
+	return func(ctx context.Context, data *placeOrderData) error {
+		return s.withinUnitOfWork(ctx, func(ctx context.Context, tx contracts.Transaction) error {
+			return step(ctx, tx, data)
+		})
+	}
+}
+
+// validateUser confere que o usuário do pedido existe
+func (s *OrderService) validateUser(ctx context.Context, tx contracts.Transaction, data *placeOrderData) error {
+	user, err := tx.UserRepository().GetByID(ctx, data.Request.UserID)
+	if err != nil || user == nil {
+		return errors.New("invalid user ID")
+	}
+	return nil
+}
+
+// reserveStock reduz o estoque de cada produto, somando itens repetidos.
+// Uma falha no meio desfaz as reservas anteriores do passo.
+func (s *OrderService) reserveStock(ctx context.Context, tx contracts.Transaction, data *placeOrderData) error {
+	quantities := make(map[string]int)
+	var productIDs []string
+	for _, item := range data.Request.Items {
+		if _, exists := quantities[item.ProductID]; !exists {
+			productIDs = append(productIDs, item.ProductID)
+		}
+		quantities[item.ProductID] += item.Quantity
+	}
+
+	reserved := make([]contracts.OrderItem, 0, len(productIDs))
+	for _, productID := range productIDs {
+		product, err := s.productService.AdjustStock(ctx, productID, -quantities[productID])
+		if err != nil {
+			return fmt.Errorf("failed to reserve stock for product %s: %w", productID, err)
+		}
+
+		reserved = append(reserved, contracts.OrderItem{
+			ProductID: productID,
+			Quantity:  quantities[productID],
+			Price:     product.Price, // Usar o preço atual do produto
+		})
+	}
+
+	data.Reserved = reserved
+	return nil
+}
+
+// releaseStock devolve o estoque reservado
+func (s *OrderService) releaseStock(ctx context.Context, tx contracts.Transaction, data *placeOrderData) error {
+	for _, item := range data.Reserved {
+		if _, err := s.productService.AdjustStock(ctx, item.ProductID, item.Quantity); err != nil {
+			return fmt.Errorf("failed to release stock for product %s: %w", item.ProductID, err)
+		}
+	}
+	return nil
+}
+
+// persistOrder cria o pedido com os preços reservados
+func (s *OrderService) persistOrder(ctx context.Context, tx contracts.Transaction, data *placeOrderData) error {
+	prices := make(map[string]float64, len(data.Reserved))
+	for _, item := range data.Reserved {
+		prices[item.ProductID] = item.Price
+	}
+
+	orderItems := make([]contracts.OrderItem, len(data.Request.Items))
+	for i, item := range data.Request.Items {
+		orderItems[i] = contracts.OrderItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			Price:     prices[item.ProductID],
+		}
+	}
+
+	order, err := domain.NewOrder(data.OrderID, data.Request.UserID, orderItems)
+	if err != nil {
+		return err
+	}
+
+	orderAggregate := domain.NewOrderAggregate(order)
+	if err := orderAggregate.IsValid(); err != nil {
+		return err
+	}
+
+	orderToSave := orderAggregate.GetOrder()
+	if err := tx.OrderRepository().Create(ctx, &orderToSave.Order); err != nil {
+		return fmt.Errorf("failed to persist order: %w", err)
+	}
+
+	if err := s.auditor.Record(ctx, contracts.AuditEntityOrder, orderToSave.ID, contracts.AuditActionCreate, nil, &orderToSave.Order); err != nil {
+		return err
+	}
+
+	data.Order = &orderToSave.Order
+	return nil
+}
+
+// deleteOrder remove definitivamente o pedido que não chegou a ser anunciado
+func (s *OrderService) deleteOrder(ctx context.Context, tx contracts.Transaction, data *placeOrderData) error {
+	if err := tx.OrderRepository().Delete(ctx, data.OrderID); err != nil {
+		return fmt.Errorf("failed to delete order: %w", err)
+	}
+	if err := tx.OrderRepository().Purge(ctx, data.OrderID); err != nil {
+		return fmt.Errorf("failed to purge order: %w", err)
+	}
+	return s.auditor.Record(ctx, contracts.AuditEntityOrder, data.OrderID, contracts.AuditActionPurge, data.Order, nil)
+}
+
+// publishOrderCreated publica o evento de pedido criado (outbox, na transação do passo)
+func (s *OrderService) publishOrderCreated(ctx context.Context, tx contracts.Transaction, data *placeOrderData) error {
+	event := contracts.Event{
+		Type:      events.OrderCreatedEventType,
+		Timestamp: time.Now(),
+		Payload: contracts.OrderCreatedEvent{
+			OrderID: data.Order.ID,
+			UserID:  data.Order.UserID,
+			Total:   data.Order.Total,
+		},
+	}
+
+	if err := s.eventPublisher.Publish(ctx, event); err != nil {
+		return fmt.Errorf("failed to publish order created event: %w", err)
+	}
+	return nil
+}
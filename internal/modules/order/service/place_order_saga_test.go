@@ -0,0 +1,178 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	orderRepository "go-modular-monolith/internal/modules/order/repository"
+	"go-modular-monolith/internal/modules/order/service"
+	productRepository "go-modular-monolith/internal/modules/product/repository"
+	productService "go-modular-monolith/internal/modules/product/service"
+	userRepository "go-modular-monolith/internal/modules/user/repository"
+	"go-modular-monolith/internal/shared/audit"
+	"go-modular-monolith/internal/shared/database"
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/events"
+	"go-modular-monolith/pkg/saga"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...contracts.Field)           {}
+func (nopLogger) Info(string, ...contracts.Field)            {}
+func (nopLogger) Warn(string, ...contracts.Field)            {}
+func (nopLogger) Error(string, ...contracts.Field)           {}
+func (nopLogger) Fatal(string, ...contracts.Field)           {}
+func (l nopLogger) With(...contracts.Field) contracts.Logger { return l }
+
+// publisher registra os eventos e recusa os do tipo failing
+type publisher struct {
+	failing   string
+	published []string
+}
+
+func (p *publisher) Publish(ctx context.Context, event contracts.Event) error {
+	if event.Type == p.failing {
+		return errors.New("broker unavailable")
+	}
+	p.published = append(p.published, event.Type)
+	return nil
+}
+
+func (p *publisher) Subscribe(string, contracts.EventHandler) (contracts.Subscription, error) {
+	return nil, errors.New("not supported")
+}
+
+type placement struct {
+	orders    contracts.OrderService
+	orderRepo contracts.OrderRepository
+	products  contracts.ProductRepository
+	sagas     *saga.MemoryStore
+	auditLog  *audit.MemoryAuditLog
+	publisher *publisher
+	userID    string
+	productID string
+}
+
+// newPlacement monta o serviço de pedidos sobre os repositórios em memória,
+// com um usuário e um produto com estoque 10
+func newPlacement(t *testing.T) *placement {
+	t.Helper()
+	ctx := context.Background()
+
+	p := &placement{
+		orderRepo: orderRepository.NewMemoryOrderRepository(),
+		products:  productRepository.NewMemoryProductRepository(),
+		sagas:     saga.NewMemoryStore(),
+		auditLog:  audit.NewMemoryAuditLog(),
+		publisher: &publisher{},
+		userID:    "user-1",
+		productID: "product-1",
+	}
+	users := userRepository.NewMemoryUserRepository()
+	require.NoError(t, users.Create(ctx, &contracts.User{ID: p.userID, Username: "buyer", Email: "buyer@example.com"}))
+	require.NoError(t, p.products.Create(ctx, &contracts.Product{ID: p.productID, Name: "Teclado", Price: 25, Stock: 10, CategoryID: "cat"}))
+
+	txManager := database.NewMemoryTransactionManager()
+	auditor := audit.NewAuditor(p.auditLog)
+	p.orders = service.NewOrderService(
+		p.orderRepo,
+		productService.NewProductService(p.products, p.publisher, txManager, auditor),
+		nil,
+		p.publisher,
+		database.NewMemoryDatabase(users, p.products, p.orderRepo),
+		auditor,
+		saga.NewCoordinator(p.sagas, txManager, nopLogger{}),
+	)
+	return p
+}
+
+func (p *placement) stock(t *testing.T) int {
+	t.Helper()
+	product, err := p.products.GetByID(context.Background(), p.productID)
+	require.NoError(t, err)
+	return product.Stock
+}
+
+func (p *placement) instance(t *testing.T) *saga.Instance {
+	t.Helper()
+	instances, err := p.sagas.List(context.Background(), saga.Filter{})
+	require.NoError(t, err)
+	require.Len(t, instances, 1)
+	assert.Equal(t, service.PlaceOrderSagaName, instances[0].Name)
+	return instances[0]
+}
+
+func TestCreateOrderRunsPlacementSaga(t *testing.T) {
+	p := newPlacement(t)
+
+	order, err := p.orders.CreateOrder(context.Background(), contracts.CreateOrderRequest{
+		UserID: p.userID,
+		Items:  []contracts.CreateOrderItem{{ProductID: p.productID, Quantity: 2}, {ProductID: p.productID, Quantity: 1}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 75.0, order.Total, "priced at the reserved price")
+	assert.Equal(t, 7, p.stock(t))
+	assert.Equal(t, []string{events.ProductStockUpdatedEventType, events.OrderCreatedEventType}, p.publisher.published)
+
+	instance := p.instance(t)
+	assert.Equal(t, saga.StatusCompleted, instance.Status)
+	assert.Equal(t, 4, instance.Step)
+}
+
+// A publicação falha depois do pedido gravado: o pedido é removido e o
+// estoque devolvido pelas compensações
+func TestCreateOrderCompensatesCompletedSteps(t *testing.T) {
+	ctx := context.Background()
+	p := newPlacement(t)
+	p.publisher.failing = events.OrderCreatedEventType
+
+	_, err := p.orders.CreateOrder(ctx, contracts.CreateOrderRequest{
+		UserID: p.userID,
+		Items:  []contracts.CreateOrderItem{{ProductID: p.productID, Quantity: 3}},
+	})
+	require.ErrorContains(t, err, "failed at step publish_order_created")
+	assert.Equal(t, 10, p.stock(t))
+
+	instance := p.instance(t)
+	assert.Equal(t, saga.StatusCompensated, instance.Status)
+	assert.Contains(t, instance.LastError, "broker unavailable")
+
+	orders, err := p.orderRepo.GetByUserID(ctx, p.userID)
+	require.NoError(t, err)
+	assert.Empty(t, orders)
+	deleted, err := p.orderRepo.ListDeleted(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, deleted, "the compensation purges the order")
+
+	entries, err := p.auditLog.List(ctx, contracts.AuditQuery{EntityType: contracts.AuditEntityOrder})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, contracts.AuditActionPurge, entries[0].Action)
+	assert.Equal(t, contracts.AuditActionCreate, entries[1].Action)
+}
+
+func TestCreateOrderFailsBeforeReservingStock(t *testing.T) {
+	p := newPlacement(t)
+
+	_, err := p.orders.CreateOrder(context.Background(), contracts.CreateOrderRequest{
+		UserID: "missing",
+		Items:  []contracts.CreateOrderItem{{ProductID: p.productID, Quantity: 1}},
+	})
+	require.ErrorContains(t, err, "failed at step validate_user: invalid user ID")
+	assert.Equal(t, 10, p.stock(t))
+	assert.Empty(t, p.publisher.published)
+	assert.Equal(t, saga.StatusCompensated, p.instance(t).Status)
+
+	// Estoque insuficiente: a reserva falha inteira, sem nada a compensar
+	_, err = p.orders.CreateOrder(context.Background(), contracts.CreateOrderRequest{
+		UserID: p.userID,
+		Items:  []contracts.CreateOrderItem{{ProductID: p.productID, Quantity: 11}},
+	})
+	require.ErrorContains(t, err, "failed at step reserve_stock")
+	assert.Equal(t, 10, p.stock(t))
+}
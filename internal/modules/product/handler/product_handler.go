@@ -1,9 +1,11 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	"go-modular-monolith/internal/shared/middleware"
 	"go-modular-monolith/pkg/contracts"
 
 	"github.com/gin-gonic/gin"
@@ -31,6 +33,7 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 		return
 	}
 
+	middleware.SetETag(c, createdProduct.Version)
 	c.JSON(http.StatusCreated, createdProduct)
 }
 
@@ -42,6 +45,7 @@ func (h *ProductHandler) GetProduct(c *gin.Context) {
 		return
 	}
 
+	middleware.SetETag(c, product.Version)
 	c.JSON(http.StatusOK, product)
 }
 
@@ -56,10 +60,11 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 
 	updatedProduct, err := h.productService.UpdateProduct(c.Request.Context(), id, req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
+	middleware.SetETag(c, updatedProduct.Version)
 	c.JSON(http.StatusOK, updatedProduct)
 }
 
@@ -132,9 +137,52 @@ func (h *ProductHandler) UpdateStock(c *gin.Context) {
 	}
 
 	if err := h.productService.UpdateStock(c.Request.Context(), id, req.Stock); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Stock updated successfully"})
 }
+
+func (h *ProductHandler) ListDeletedProducts(c *gin.Context) {
+	products, err := h.productService.ListDeletedProducts(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, products)
+}
+
+func (h *ProductHandler) RestoreProduct(c *gin.Context) {
+	product, err := h.productService.RestoreProduct(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	middleware.SetETag(c, product.Version)
+	c.JSON(http.StatusOK, product)
+}
+
+func (h *ProductHandler) PurgeProduct(c *gin.Context) {
+	if err := h.productService.PurgeProduct(c.Request.Context(), c.Param("id")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, contracts.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, contracts.ErrPreconditionFailed):
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+	case errors.Is(err, contracts.ErrVersionConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,12 @@
+-- IF NOT EXISTS: bancos criados pelo antigo AutoMigrate já têm a tabela
+CREATE TABLE IF NOT EXISTS products (
+    id VARCHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(500),
+    price DECIMAL(10,2) NOT NULL,
+    stock BIGINT NOT NULL DEFAULT 0,
+    category_id VARCHAR(36) NOT NULL,
+    created_at DATETIME(3),
+    updated_at DATETIME(3),
+    PRIMARY KEY (id)
+);
@@ -0,0 +1,10 @@
+CREATE TABLE products (
+    id VARCHAR(36) NOT NULL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(500),
+    price DECIMAL(10,2) NOT NULL,
+    stock INTEGER NOT NULL DEFAULT 0,
+    category_id VARCHAR(36) NOT NULL,
+    created_at DATETIME,
+    updated_at DATETIME
+);
@@ -0,0 +1 @@
+DELETE FROM products WHERE id IN ('prod-001', 'prod-002', 'prod-003', 'prod-004', 'prod-005', 'prod-006', 'prod-007', 'prod-008', 'prod-009', 'prod-010', 'prod-011', 'prod-012');
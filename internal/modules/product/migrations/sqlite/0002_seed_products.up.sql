@@ -0,0 +1,14 @@
+-- Catálogo inicial
+INSERT INTO products (id, name, description, price, stock, category_id, created_at, updated_at) VALUES
+    ('prod-001', 'iPhone 15 Pro Max', 'Apple iPhone 15 Pro Max 256GB - Titânio Natural com câmera profissional de 48MP', 8999.99, 15, 'electronics', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+    ('prod-002', 'MacBook Air M2', 'MacBook Air 13" com chip M2, 8GB RAM, 256GB SSD - Cor Meia-noite', 12999.99, 8, 'computers', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+    ('prod-003', 'Samsung Galaxy S24 Ultra', 'Samsung Galaxy S24 Ultra 512GB - Preto com S Pen incluída e câmera de 200MP', 7499.99, 12, 'electronics', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+    ('prod-004', 'Dell XPS 13', 'Notebook Dell XPS 13 Intel Core i7, 16GB RAM, 512GB SSD, Tela InfinityEdge', 9999.99, 6, 'computers', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+    ('prod-005', 'AirPods Pro (3ª geração)', 'Apple AirPods Pro com cancelamento ativo de ruído e case de carregamento MagSafe', 2499.99, 25, 'accessories', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+    ('prod-006', 'Sony WH-1000XM5', 'Fone de ouvido Sony WH-1000XM5 com cancelamento de ruído premium e 30h de bateria', 1899.99, 18, 'accessories', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+    ('prod-007', 'iPad Air (5ª geração)', 'iPad Air 10.9" com chip M1, 256GB, Wi-Fi + Cellular - Azul-céu', 6499.99, 10, 'tablets', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+    ('prod-008', 'Nintendo Switch OLED', 'Console Nintendo Switch modelo OLED com tela de 7" e 64GB de armazenamento', 2799.99, 20, 'gaming', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+    ('prod-009', 'PlayStation 5', 'Console Sony PlayStation 5 com SSD ultrarrápido e controle DualSense', 4999.99, 5, 'gaming', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+    ('prod-010', 'Microsoft Surface Pro 9', 'Surface Pro 9 Intel i7, 16GB RAM, 512GB SSD com teclado Type Cover incluso', 11999.99, 7, 'tablets', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+    ('prod-011', 'LG OLED C3 55"', 'Smart TV LG OLED C3 55" 4K com webOS, Dolby Vision IQ e Gaming Hub', 6999.99, 4, 'tv', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+    ('prod-012', 'Apple Watch Series 9', 'Apple Watch Series 9 GPS 45mm caixa de alumínio com pulseira esportiva', 3999.99, 14, 'wearables', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP);
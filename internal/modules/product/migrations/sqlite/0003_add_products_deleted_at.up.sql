@@ -0,0 +1,4 @@
+-- Soft delete: linhas com deleted_at preenchido ficam fora das consultas
+ALTER TABLE products ADD COLUMN deleted_at DATETIME;
+
+CREATE INDEX idx_products_deleted_at ON products (deleted_at);
@@ -0,0 +1,103 @@
+package product
+
+import (
+	"context"
+
+	productHandler "go-modular-monolith/internal/modules/product/handler"
+	productRepository "go-modular-monolith/internal/modules/product/repository"
+	productService "go-modular-monolith/internal/modules/product/service"
+	"go-modular-monolith/internal/shared/config"
+	"go-modular-monolith/internal/shared/database"
+	"go-modular-monolith/pkg/container"
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/module"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Module é o módulo de produtos
+type Module struct {
+	module.Base
+	cfg *config.Config
+}
+
+// NewModule cria o módulo de produtos
+func NewModule(cfg *config.Config) *Module {
+	return &Module{cfg: cfg}
+}
+
+// Name retorna o nome do módulo
+func (m *Module) Name() string {
+	return "product"
+}
+
+// Register registra repositório, serviço e handler de produtos
+func (m *Module) Register(c *container.Container) error {
+	// Product Repository (em memória com STORAGE=memory, senão MySQL/SQLite)
+	if m.cfg.Storage == config.StorageMemory {
+		c.RegisterSingleton("productRepository", func() interface{} {
+			return productRepository.NewMemoryProductRepository()
+		})
+	} else {
+		c.RegisterSingleton("productRepository", func() interface{} {
+			db := c.MustGet("database").(*gorm.DB)
+			return productRepository.NewMySQLProductRepository(db)
+		}, container.DependsOn("database"))
+	}
+
+	// Product Service
+	c.RegisterSingleton("productService", func() interface{} {
+		productRepo := c.MustGet("productRepository").(contracts.ProductRepository)
+		eventPublisher := c.MustGet("eventPublisher").(contracts.EventPublisher)
+		txManager := c.MustGet("transactionManager").(contracts.TransactionManager)
+		auditor := c.MustGet("auditor").(contracts.Auditor)
+
+		return productService.NewProductService(
+			productRepo,
+			eventPublisher,
+			txManager,
+			auditor,
+		)
+	}, container.DependsOn("productRepository", "eventPublisher", "transactionManager", "auditor"))
+
+	// Product Handler
+	c.RegisterSingleton("productHandler", func() interface{} {
+		productSvc := c.MustGet("productService").(contracts.ProductService)
+		return productHandler.NewProductHandler(productSvc)
+	}, container.DependsOn("productService"))
+
+	return nil
+}
+
+// RegisterRoutes registra as rotas do módulo de produto
+func (m *Module) RegisterRoutes(router *gin.RouterGroup, c *container.Container) {
+	productHandler := c.MustGet("productHandler").(contracts.ProductHandler)
+
+	productGroup := router.Group("/products")
+	{
+		productGroup.POST("/", productHandler.CreateProduct)
+		productGroup.GET("/", productHandler.GetProducts)
+		productGroup.GET("/:id", productHandler.GetProduct)
+		productGroup.PUT("/:id", productHandler.UpdateProduct)
+		productGroup.DELETE("/:id", productHandler.DeleteProduct)
+		productGroup.PUT("/:id/stock", productHandler.UpdateStock)
+	}
+
+	// Administração dos produtos excluídos
+	adminGroup := router.Group("/admin/products")
+	{
+		adminGroup.GET("/deleted", productHandler.ListDeletedProducts)
+		adminGroup.POST("/:id/restore", productHandler.RestoreProduct)
+		adminGroup.DELETE("/:id", productHandler.PurgeProduct)
+	}
+}
+
+// Health verifica a conexão e a tabela products
+func (m *Module) Health(ctx context.Context, c *container.Container) error {
+	if m.cfg.Storage == config.StorageMemory {
+		return nil // Sem banco a verificar
+	}
+	db := c.MustGet("database").(*gorm.DB)
+	return database.CheckTables(ctx, db, &database.ProductModel{})
+}
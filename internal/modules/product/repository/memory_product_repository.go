@@ -0,0 +1,306 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go-modular-monolith/internal/shared/database"
+	"go-modular-monolith/pkg/contracts"
+)
+
+// memoryProductRepository implementa ProductRepository em memória.
+// Alterações feitas em uma transação em memória são desfeitas no rollback.
+type memoryProductRepository struct {
+	products map[string]*contracts.Product
+	mu       sync.RWMutex
+}
+
+// NewMemoryProductRepository cria um repositório de produtos em memória
+func NewMemoryProductRepository() contracts.ProductRepository {
+	return &memoryProductRepository{
+		products: make(map[string]*contracts.Product),
+	}
+}
+
+func (r *memoryProductRepository) Create(ctx context.Context, product *contracts.Product) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.products[product.ID]; exists {
+		return fmt.Errorf("failed to create product: product %s %w", product.ID, contracts.ErrAlreadyExists)
+	}
+	product.Version = 1
+	r.products[product.ID] = copyProduct(product)
+	database.OnRollback(ctx, r.undoCreate(product.ID))
+	return nil
+}
+
+func (r *memoryProductRepository) GetByID(ctx context.Context, id string) (*contracts.Product, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	product, exists := r.products[id]
+	if !exists || product.DeletedAt != nil {
+		return nil, fmt.Errorf("product %w", contracts.ErrNotFound)
+	}
+	return copyProduct(product), nil
+}
+
+func (r *memoryProductRepository) Update(ctx context.Context, product *contracts.Product) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, exists := r.products[product.ID]
+	if !exists || previous.DeletedAt != nil {
+		return fmt.Errorf("product %w", contracts.ErrNotFound)
+	}
+	if previous.Version != product.Version {
+		return fmt.Errorf("product %w", contracts.ErrVersionConflict)
+	}
+	updated := copyProduct(product)
+	updated.DeletedAt = nil
+	updated.Version++
+	r.products[product.ID] = updated
+	product.Version = updated.Version
+	database.OnRollback(ctx, r.undoUpdate(previous, updated))
+	return nil
+}
+
+func (r *memoryProductRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, exists := r.products[id]
+	if !exists || previous.DeletedAt != nil {
+		return fmt.Errorf("product %w", contracts.ErrNotFound)
+	}
+	deleted := copyProduct(previous)
+	now := time.Now()
+	deleted.DeletedAt = &now
+	r.products[id] = deleted
+	database.OnRollback(ctx, r.undoDelete(id))
+	return nil
+}
+
+// ListDeleted lista os produtos excluídos, do mais antigo para o mais recente
+func (r *memoryProductRepository) ListDeleted(ctx context.Context) ([]*contracts.Product, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	products := make([]*contracts.Product, 0)
+	for _, product := range r.products {
+		if product.DeletedAt != nil {
+			products = append(products, copyProduct(product))
+		}
+	}
+
+	sort.Slice(products, func(i, j int) bool {
+		if !products[i].DeletedAt.Equal(*products[j].DeletedAt) {
+			return products[i].DeletedAt.Before(*products[j].DeletedAt)
+		}
+		return products[i].ID < products[j].ID
+	})
+
+	return products, nil
+}
+
+func (r *memoryProductRepository) Restore(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, exists := r.products[id]
+	if !exists || previous.DeletedAt == nil {
+		return fmt.Errorf("deleted product %w", contracts.ErrNotFound)
+	}
+	restored := copyProduct(previous)
+	restored.DeletedAt = nil
+	restored.UpdatedAt = time.Now()
+	r.products[id] = restored
+	database.OnRollback(ctx, r.undoRestore(id, previous.DeletedAt))
+	return nil
+}
+
+func (r *memoryProductRepository) Purge(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, exists := r.products[id]
+	if !exists || previous.DeletedAt == nil {
+		return fmt.Errorf("deleted product %w", contracts.ErrNotFound)
+	}
+	delete(r.products, id)
+	database.OnRollback(ctx, r.undoPurge(previous))
+	return nil
+}
+
+// List aplica os filtros como o repositório MySQL: nome sem diferenciar
+// maiúsculas, ordem por ID e paginação por Limit/Offset
+func (r *memoryProductRepository) List(ctx context.Context, filters contracts.ProductFilters) ([]*contracts.Product, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var name string
+	if filters.Name != nil {
+		name = strings.ToLower(*filters.Name)
+	}
+
+	products := make([]*contracts.Product, 0, len(r.products))
+	for _, product := range r.products {
+		if product.DeletedAt != nil {
+			continue
+		}
+		if filters.CategoryID != nil && product.CategoryID != *filters.CategoryID {
+			continue
+		}
+		if filters.MinPrice != nil && product.Price < *filters.MinPrice {
+			continue
+		}
+		if filters.MaxPrice != nil && product.Price > *filters.MaxPrice {
+			continue
+		}
+		if filters.Name != nil && !strings.Contains(strings.ToLower(product.Name), name) {
+			continue
+		}
+		products = append(products, copyProduct(product))
+	}
+
+	sort.Slice(products, func(i, j int) bool {
+		return products[i].ID < products[j].ID
+	})
+
+	if filters.Offset > 0 {
+		if filters.Offset >= len(products) {
+			return []*contracts.Product{}, nil
+		}
+		products = products[filters.Offset:]
+	}
+	if filters.Limit > 0 && filters.Limit < len(products) {
+		products = products[:filters.Limit]
+	}
+
+	return products, nil
+}
+
+// AdjustStock soma delta ao estoque sob o lock, sem permitir estoque negativo.
+// Não depende da versão, mas a incrementa.
+func (r *memoryProductRepository) AdjustStock(ctx context.Context, id string, delta int) (*contracts.Product, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, exists := r.products[id]
+	if !exists || previous.DeletedAt != nil {
+		return nil, fmt.Errorf("product %w", contracts.ErrNotFound)
+	}
+	if previous.Stock+delta < 0 {
+		return nil, fmt.Errorf("%w for product: %s", contracts.ErrInsufficientStock, previous.Name)
+	}
+
+	updated := copyProduct(previous)
+	updated.Stock += delta
+	updated.Version++
+	updated.UpdatedAt = time.Now()
+	r.products[id] = updated
+	database.OnRollback(ctx, r.undoAdjustStock(id, delta))
+
+	return copyProduct(updated), nil
+}
+
+// As funções de undo desfazem apenas a própria alteração, sobre o estado atual
+// do produto: sem isolamento, outras transações podem tê-lo alterado depois.
+
+func (r *memoryProductRepository) undoCreate(id string) func() {
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.products, id)
+	}
+}
+
+// undoUpdate volta os campos ao valor anterior, preservando os ajustes de
+// estoque e a exclusão feitos depois da atualização
+func (r *memoryProductRepository) undoUpdate(previous, updated *contracts.Product) func() {
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		current, exists := r.products[previous.ID]
+		if !exists {
+			return
+		}
+		restored := copyProduct(previous)
+		restored.Stock += current.Stock - updated.Stock
+		restored.DeletedAt = current.DeletedAt
+		restored.Version = current.Version + 1
+		restored.UpdatedAt = time.Now()
+		r.products[previous.ID] = restored
+	}
+}
+
+func (r *memoryProductRepository) undoDelete(id string) func() {
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if current, exists := r.products[id]; exists {
+			restored := copyProduct(current)
+			restored.DeletedAt = nil
+			r.products[id] = restored
+		}
+	}
+}
+
+func (r *memoryProductRepository) undoRestore(id string, deletedAt *time.Time) func() {
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if current, exists := r.products[id]; exists {
+			deleted := copyProduct(current)
+			deleted.DeletedAt = deletedAt
+			r.products[id] = deleted
+		}
+	}
+}
+
+func (r *memoryProductRepository) undoPurge(previous *contracts.Product) func() {
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if _, exists := r.products[previous.ID]; !exists {
+			r.products[previous.ID] = previous
+		}
+	}
+}
+
+// undoAdjustStock aplica -delta ao estoque atual, sem descartar os ajustes
+// feitos por outras transações desde então
+func (r *memoryProductRepository) undoAdjustStock(id string, delta int) func() {
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		current, exists := r.products[id]
+		if !exists {
+			return
+		}
+		adjusted := copyProduct(current)
+		adjusted.Stock -= delta
+		adjusted.Version++
+		adjusted.UpdatedAt = time.Now()
+		r.products[id] = adjusted
+	}
+}
+
+func copyProduct(product *contracts.Product) *contracts.Product {
+	copied := *product
+	if product.DeletedAt != nil {
+		deletedAt := *product.DeletedAt
+		copied.DeletedAt = &deletedAt
+	}
+	return &copied
+}
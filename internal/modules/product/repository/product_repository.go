@@ -2,8 +2,13 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"strings"
+	"time"
 
+	"go-modular-monolith/internal/shared/database"
 	"go-modular-monolith/pkg/contracts"
 
 	"gorm.io/gorm"
@@ -21,47 +26,99 @@ func NewMySQLProductRepository(db *gorm.DB) contracts.ProductRepository {
 
 // Create cria um novo produto
 func (r *MySQLProductRepository) Create(ctx context.Context, product *contracts.Product) error {
-	if err := r.db.WithContext(ctx).Create(product).Error; err != nil {
+	productModel := &database.ProductModel{}
+	productModel.FromContract(product)
+	productModel.Version = 1
+
+	if err := database.Conn(ctx, r.db).Create(productModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return fmt.Errorf("failed to create product: %w", contracts.ErrAlreadyExists)
+		}
 		return fmt.Errorf("failed to create product: %w", err)
 	}
+	product.Version = productModel.Version
 	return nil
 }
 
 // GetByID busca um produto por ID
 func (r *MySQLProductRepository) GetByID(ctx context.Context, id string) (*contracts.Product, error) {
-	var product contracts.Product
-	if err := r.db.WithContext(ctx).First(&product, "id = ?", id).Error; err != nil {
+	var productModel database.ProductModel
+	if err := database.Conn(ctx, r.db).First(&productModel, "id = ?", id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("product not found")
+			return nil, fmt.Errorf("product %w", contracts.ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get product: %w", err)
 	}
-	return &product, nil
+	return productModel.ToContract(), nil
 }
 
-// Update atualiza um produto existente
+// Update substitui todos os campos de um produto existente, se ainda estiver
+// na versão informada. Ao contrário de Save, não cria o produto quando o ID
+// não existe nem altera a exclusão.
 func (r *MySQLProductRepository) Update(ctx context.Context, product *contracts.Product) error {
-	if err := r.db.WithContext(ctx).Save(product).Error; err != nil {
-		return fmt.Errorf("failed to update product: %w", err)
+	productModel := &database.ProductModel{}
+	productModel.FromContract(product)
+	productModel.Version = product.Version + 1
+
+	result := database.Conn(ctx, r.db).Model(&database.ProductModel{}).
+		Where("id = ? AND version = ?", product.ID, product.Version).
+		Select("*").
+		Omit("deleted_at").
+		Updates(productModel)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update product: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("product %w", database.VersionMismatch(ctx, r.db, &database.ProductModel{}, product.ID))
 	}
+	product.Version = productModel.Version
 	return nil
 }
 
-// Delete remove um produto
+// Delete marca o produto como excluído (soft delete)
 func (r *MySQLProductRepository) Delete(ctx context.Context, id string) error {
-	result := r.db.WithContext(ctx).Delete(&contracts.Product{}, "id = ?", id)
+	result := database.Conn(ctx, r.db).Delete(&database.ProductModel{}, "id = ?", id)
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete product: %w", result.Error)
 	}
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("product not found")
+		return fmt.Errorf("product %w", contracts.ErrNotFound)
 	}
 	return nil
 }
 
+// AdjustStock soma delta ao estoque de forma atômica, sem permitir estoque
+// negativo. Não depende da versão, mas a incrementa.
+func (r *MySQLProductRepository) AdjustStock(ctx context.Context, id string, delta int) (*contracts.Product, error) {
+	result := database.Conn(ctx, r.db).Model(&database.ProductModel{}).
+		Where("id = ? AND stock + ? >= 0", id, delta).
+		Updates(map[string]interface{}{
+			"stock":      gorm.Expr("stock + ?", delta),
+			"version":    gorm.Expr("version + 1"),
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to adjust stock: %w", result.Error)
+	}
+
+	// Lido no primário: a réplica pode ainda não ter o estoque ajustado
+	product, err := r.GetByID(contracts.WithPrimaryReads(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+	if result.RowsAffected == 0 {
+		return nil, fmt.Errorf("%w for product: %s", contracts.ErrInsufficientStock, product.Name)
+	}
+	return product, nil
+}
+
+// likeEscaper trata % e _ do filtro como texto. O escape é '!' e não a barra
+// invertida, que o MySQL também interpreta dentro de strings.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
 // List lista produtos com filtros
 func (r *MySQLProductRepository) List(ctx context.Context, filters contracts.ProductFilters) ([]*contracts.Product, error) {
-	query := r.db.WithContext(ctx).Model(&contracts.Product{})
+	query := database.Conn(ctx, r.db).Model(&database.ProductModel{})
 
 	// Aplicar filtros
 	if filters.CategoryID != nil {
@@ -77,22 +134,74 @@ func (r *MySQLProductRepository) List(ctx context.Context, filters contracts.Pro
 	}
 
 	if filters.Name != nil {
-		query = query.Where("name ILIKE ?", "%"+*filters.Name+"%")
+		// LOWER + LIKE: busca sem diferenciar maiúsculas em MySQL e SQLite
+		query = query.Where("LOWER(name) LIKE ? ESCAPE '!'", "%"+likeEscaper.Replace(strings.ToLower(*filters.Name))+"%")
 	}
 
+	// Ordem estável para a paginação
+	query = query.Order("id")
+
 	// Aplicar paginação
 	if filters.Limit > 0 {
 		query = query.Limit(filters.Limit)
 	}
 
 	if filters.Offset > 0 {
+		// MySQL não aceita OFFSET sem LIMIT
+		if filters.Limit <= 0 {
+			query = query.Limit(math.MaxInt)
+		}
 		query = query.Offset(filters.Offset)
 	}
 
-	var products []*contracts.Product
-	if err := query.Find(&products).Error; err != nil {
+	var productModels []database.ProductModel
+	if err := query.Find(&productModels).Error; err != nil {
 		return nil, fmt.Errorf("failed to list products: %w", err)
 	}
 
-	return products, nil
+	return toContracts(productModels), nil
+}
+
+// ListDeleted lista os produtos excluídos, do mais antigo para o mais recente
+func (r *MySQLProductRepository) ListDeleted(ctx context.Context) ([]*contracts.Product, error) {
+	var productModels []database.ProductModel
+	if err := database.Conn(ctx, r.db).Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at, id").Find(&productModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to list deleted products: %w", err)
+	}
+	return toContracts(productModels), nil
+}
+
+// Restore desfaz a exclusão de um produto
+func (r *MySQLProductRepository) Restore(ctx context.Context, id string) error {
+	result := database.Conn(ctx, r.db).Unscoped().Model(&database.ProductModel{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return fmt.Errorf("failed to restore product: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("deleted product %w", contracts.ErrNotFound)
+	}
+	return nil
+}
+
+// Purge remove definitivamente um produto excluído. Itens de pedidos mantêm o
+// product_id e o preço gravados na compra.
+func (r *MySQLProductRepository) Purge(ctx context.Context, id string) error {
+	result := database.Conn(ctx, r.db).Unscoped().Delete(&database.ProductModel{}, "id = ? AND deleted_at IS NOT NULL", id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to purge product: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("deleted product %w", contracts.ErrNotFound)
+	}
+	return nil
+}
+
+func toContracts(productModels []database.ProductModel) []*contracts.Product {
+	products := make([]*contracts.Product, len(productModels))
+	for i, model := range productModels {
+		products[i] = model.ToContract()
+	}
+	return products
 }
@@ -0,0 +1,76 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go-modular-monolith/internal/modules/product"
+	"go-modular-monolith/internal/modules/product/repository"
+	"go-modular-monolith/internal/shared/database"
+	"go-modular-monolith/internal/shared/database/databasetest"
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/contracts/contractstest"
+	"go-modular-monolith/pkg/migrate"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductRepository(t *testing.T) {
+	t.Run("memory", func(t *testing.T) {
+		contractstest.TestProductRepository(t, func(t *testing.T) contracts.ProductRepository {
+			return repository.NewMemoryProductRepository()
+		})
+	})
+
+	migrations := migrate.Source{Module: "product", FS: new(product.Module).Migrations()}
+	for name, url := range databasetest.URLs() {
+		t.Run(name, func(t *testing.T) {
+			contractstest.TestProductRepository(t, func(t *testing.T) contracts.ProductRepository {
+				return repository.NewMySQLProductRepository(databasetest.Open(t, url, migrations))
+			})
+		})
+	}
+}
+
+var errRollback = errors.New("rollback")
+
+// O rollback em memória desfaz só o ajuste da própria transação, sem perder os
+// feitos por outras requisições enquanto ela estava aberta
+func TestMemoryProductRollbackKeepsConcurrentChanges(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryProductRepository()
+	txManager := database.NewMemoryTransactionManager()
+	require.NoError(t, repo.Create(ctx, &contracts.Product{ID: "prod-1", Name: "Monitor", Price: 100, Stock: 10}))
+
+	err := txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		_, err := repo.AdjustStock(txCtx, "prod-1", -3)
+		require.NoError(t, err)
+		_, err = repo.AdjustStock(ctx, "prod-1", 5) // Outra requisição, fora da transação
+		require.NoError(t, err)
+		return errRollback
+	})
+	require.ErrorIs(t, err, errRollback)
+
+	product, err := repo.GetByID(ctx, "prod-1")
+	require.NoError(t, err)
+	assert.Equal(t, 15, product.Stock)
+
+	err = txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		updated := *product
+		updated.Name = "Monitor 4K"
+		updated.Stock = 20
+		require.NoError(t, repo.Update(txCtx, &updated))
+		_, err := repo.AdjustStock(ctx, "prod-1", -2)
+		require.NoError(t, err)
+		return errRollback
+	})
+	require.ErrorIs(t, err, errRollback)
+
+	restored, err := repo.GetByID(ctx, "prod-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Monitor", restored.Name)
+	assert.Equal(t, 13, restored.Stock)
+	assert.Greater(t, restored.Version, product.Version, "ETags taken during the transaction are stale")
+}
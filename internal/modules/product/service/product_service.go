@@ -7,6 +7,7 @@ import (
 
 	"go-modular-monolith/internal/modules/product/domain"
 	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/events"
 
 	"github.com/google/uuid"
 )
@@ -15,16 +16,22 @@ import (
 type ProductService struct {
 	repo           contracts.ProductRepository
 	eventPublisher contracts.EventPublisher
+	txManager      contracts.TransactionManager
+	auditor        contracts.Auditor
 }
 
 // NewProductService cria uma nova instância do ProductService
 func NewProductService(
 	repo contracts.ProductRepository,
 	eventPublisher contracts.EventPublisher,
+	txManager contracts.TransactionManager,
+	auditor contracts.Auditor,
 ) contracts.ProductService {
 	return &ProductService{
 		repo:           repo,
 		eventPublisher: eventPublisher,
+		txManager:      txManager,
+		auditor:        auditor,
 	}
 }
 
@@ -41,26 +48,33 @@ func (s *ProductService) CreateProduct(ctx context.Context, req contracts.Create
 	product := aggregate.GetProduct()
 	product.ID = productID
 
-	// Salvar no banco de dados
+	// Salvar no banco de dados junto com o registro de auditoria
 	contractProduct := &product.Product
-	if err := s.repo.Create(ctx, contractProduct); err != nil {
-		return nil, fmt.Errorf("failed to create product: %w", err)
-	}
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, contractProduct); err != nil {
+			return fmt.Errorf("failed to create product: %w", err)
+		}
 
-	// Publicar evento de produto criado
-	event := contracts.Event{
-		Type:      "ProductCreatedEventType",
-		Timestamp: time.Now(),
-		Payload: contracts.ProductCreatedEvent{
-			ProductID:  productID,
-			Name:       product.Name,
-			CategoryID: product.CategoryID,
-			Price:      product.Price,
-		},
-	}
+		// Publicar evento de produto criado
+		event := contracts.Event{
+			Type:      events.ProductCreatedEventType,
+			Timestamp: time.Now(),
+			Payload: contracts.ProductCreatedEvent{
+				ProductID:  productID,
+				Name:       product.Name,
+				CategoryID: product.CategoryID,
+				Price:      product.Price,
+			},
+		}
 
-	// Ignorar erros de evento para não falhar a operação
-	_ = s.eventPublisher.Publish(ctx, event)
+		// Ignorar erros de evento para não falhar a operação
+		_ = s.eventPublisher.Publish(ctx, event)
+
+		return s.auditor.Record(ctx, contracts.AuditEntityProduct, productID, contracts.AuditActionCreate, nil, contractProduct)
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	return contractProduct, nil
 }
@@ -77,12 +91,17 @@ func (s *ProductService) GetProductByID(ctx context.Context, id string) (*contra
 
 // UpdateProduct atualiza um produto existente
 func (s *ProductService) UpdateProduct(ctx context.Context, id string, req contracts.UpdateProductRequest) (*contracts.Product, error) {
-	// Buscar produto existente
-	existingProduct, err := s.repo.GetByID(ctx, id)
+	// Buscar produto existente no primário: uma réplica atrasada perderia alterações
+	existingProduct, err := s.repo.GetByID(contracts.WithPrimaryReads(ctx), id)
 	if err != nil {
 		return nil, fmt.Errorf("product not found: %w", err)
 	}
 
+	// Versão pedida pelo cliente (If-Match)
+	if err := contracts.CheckIfMatch(ctx, existingProduct.Version); err != nil {
+		return nil, err
+	}
+
 	// Criar aggregate para validações e atualizações
 	domainProduct := &domain.Product{Product: *existingProduct}
 	aggregate := domain.NewProductAggregate(domainProduct)
@@ -112,19 +131,126 @@ func (s *ProductService) UpdateProduct(ctx context.Context, id string, req contr
 		}
 	}
 
-	// Salvar alterações
+	// Salvar alterações; o repositório recusa se o produto mudou desde a leitura
 	updatedProduct := &aggregate.GetProduct().Product
-	if err := s.repo.Update(ctx, updatedProduct); err != nil {
-		return nil, fmt.Errorf("failed to update product: %w", err)
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, updatedProduct); err != nil {
+			return fmt.Errorf("failed to update product: %w", err)
+		}
+
+		return s.auditor.Record(ctx, contracts.AuditEntityProduct, id, contracts.AuditActionUpdate, existingProduct, updatedProduct)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return updatedProduct, nil
 }
 
-// DeleteProduct remove um produto
+// DeleteProduct exclui um produto (soft delete); ele pode ser restaurado até o purge
 func (s *ProductService) DeleteProduct(ctx context.Context, id string) error {
-	if err := s.repo.Delete(ctx, id); err != nil {
-		return fmt.Errorf("failed to delete product: %w", err)
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		existingProduct, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete product: %w", err)
+		}
+
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete product: %w", err)
+		}
+
+		if err := s.auditor.Record(ctx, contracts.AuditEntityProduct, id, contracts.AuditActionDelete, existingProduct, nil); err != nil {
+			return err
+		}
+
+		return s.publish(ctx, events.ProductDeletedEventType, contracts.ProductDeletedEvent{ProductID: id})
+	})
+}
+
+// ListDeletedProducts lista os produtos excluídos
+func (s *ProductService) ListDeletedProducts(ctx context.Context) ([]*contracts.Product, error) {
+	products, err := s.repo.ListDeleted(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted products: %w", err)
+	}
+
+	return products, nil
+}
+
+// RestoreProduct desfaz a exclusão de um produto
+func (s *ProductService) RestoreProduct(ctx context.Context, id string) (*contracts.Product, error) {
+	var product *contracts.Product
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Restore(ctx, id); err != nil {
+			return fmt.Errorf("failed to restore product: %w", err)
+		}
+
+		var err error
+		product, err = s.repo.GetByID(contracts.WithPrimaryReads(ctx), id)
+		if err != nil {
+			return fmt.Errorf("failed to get restored product: %w", err)
+		}
+
+		if err := s.auditor.Record(ctx, contracts.AuditEntityProduct, id, contracts.AuditActionRestore, nil, product); err != nil {
+			return err
+		}
+
+		return s.publish(ctx, events.ProductRestoredEventType, contracts.ProductRestoredEvent{ProductID: id})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// PurgeProduct remove definitivamente um produto excluído
+func (s *ProductService) PurgeProduct(ctx context.Context, id string) error {
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		product, err := s.deletedProduct(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.Purge(ctx, id); err != nil {
+			return fmt.Errorf("failed to purge product: %w", err)
+		}
+
+		// O snapshot removido fica só na trilha de auditoria
+		if err := s.auditor.Record(ctx, contracts.AuditEntityProduct, id, contracts.AuditActionPurge, product, nil); err != nil {
+			return err
+		}
+
+		return s.publish(ctx, events.ProductPurgedEventType, contracts.ProductPurgedEvent{ProductID: id})
+	})
+}
+
+// deletedProduct busca o produto entre os excluídos
+func (s *ProductService) deletedProduct(ctx context.Context, id string) (*contracts.Product, error) {
+	products, err := s.repo.ListDeleted(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted products: %w", err)
+	}
+
+	for _, product := range products {
+		if product.ID == id {
+			return product, nil
+		}
+	}
+
+	return nil, fmt.Errorf("deleted product %w", contracts.ErrNotFound)
+}
+
+// publish publica o evento na transação do contexto (outbox)
+func (s *ProductService) publish(ctx context.Context, eventType string, payload interface{}) error {
+	event := contracts.Event{
+		Type:      eventType,
+		Timestamp: time.Now(),
+		Payload:   payload,
+	}
+
+	if err := s.eventPublisher.Publish(ctx, event); err != nil {
+		return fmt.Errorf("failed to publish %s event: %w", eventType, err)
 	}
 
 	return nil
@@ -142,12 +268,16 @@ func (s *ProductService) GetProducts(ctx context.Context, filters contracts.Prod
 
 // UpdateStock atualiza o estoque de um produto
 func (s *ProductService) UpdateStock(ctx context.Context, id string, quantity int) error {
-	// Buscar produto existente
-	existingProduct, err := s.repo.GetByID(ctx, id)
+	// Buscar produto existente no primário: uma réplica atrasada perderia alterações
+	existingProduct, err := s.repo.GetByID(contracts.WithPrimaryReads(ctx), id)
 	if err != nil {
 		return fmt.Errorf("product not found: %w", err)
 	}
 
+	if err := contracts.CheckIfMatch(ctx, existingProduct.Version); err != nil {
+		return err
+	}
+
 	// Criar aggregate e atualizar estoque
 	domainProduct := &domain.Product{Product: *existingProduct}
 	aggregate := domain.NewProductAggregate(domainProduct)
@@ -156,24 +286,71 @@ func (s *ProductService) UpdateStock(ctx context.Context, id string, quantity in
 		return fmt.Errorf("failed to update stock: %w", err)
 	}
 
-	// Salvar alterações
+	// Salvar alterações e evento de estoque atualizado na mesma transação (outbox)
 	updatedProduct := &aggregate.GetProduct().Product
-	if err := s.repo.Update(ctx, updatedProduct); err != nil {
-		return fmt.Errorf("failed to update product stock: %w", err)
-	}
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, updatedProduct); err != nil {
+			return fmt.Errorf("failed to update product stock: %w", err)
+		}
 
-	// Publicar evento de estoque atualizado
-	event := contracts.Event{
-		Type:      "ProductStockUpdatedEventType",
-		Timestamp: time.Now(),
-		Payload: contracts.ProductStockUpdatedEvent{
-			ProductID: id,
-			NewStock:  quantity,
-		},
-	}
+		if err := s.auditor.Record(ctx, contracts.AuditEntityProduct, id, contracts.AuditActionUpdate, existingProduct, updatedProduct); err != nil {
+			return err
+		}
 
-	// Ignorar erros de evento para não falhar a operação
-	_ = s.eventPublisher.Publish(ctx, event)
+		event := contracts.Event{
+			Type:      events.ProductStockUpdatedEventType,
+			Timestamp: time.Now(),
+			Payload: contracts.ProductStockUpdatedEvent{
+				ProductID: id,
+				NewStock:  quantity,
+			},
+		}
 
-	return nil
+		if err := s.eventPublisher.Publish(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish stock updated event: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// AdjustStock soma delta ao estoque do produto (negativo para reservar, positivo
+// para devolver). A operação é atômica no banco e falha se o estoque ficar negativo.
+func (s *ProductService) AdjustStock(ctx context.Context, id string, delta int) (*contracts.Product, error) {
+	var product *contracts.Product
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		product, err = s.repo.AdjustStock(ctx, id, delta)
+		if err != nil {
+			return err
+		}
+
+		// O ajuste é atômico no repositório: o estado anterior difere só no estoque e na versão
+		previous := *product
+		previous.Stock -= delta
+		previous.Version--
+		if err := s.auditor.Record(ctx, contracts.AuditEntityProduct, id, contracts.AuditActionUpdate, &previous, product); err != nil {
+			return err
+		}
+
+		event := contracts.Event{
+			Type:      events.ProductStockUpdatedEventType,
+			Timestamp: time.Now(),
+			Payload: contracts.ProductStockUpdatedEvent{
+				ProductID: id,
+				NewStock:  product.Stock,
+			},
+		}
+
+		if err := s.eventPublisher.Publish(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish stock updated event: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return product, nil
 }
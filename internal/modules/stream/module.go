@@ -0,0 +1,72 @@
+package stream
+
+import (
+	"fmt"
+
+	"go-modular-monolith/internal/shared/config"
+	"go-modular-monolith/internal/shared/sse"
+	"go-modular-monolith/pkg/container"
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/events"
+	"go-modular-monolith/pkg/module"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Module expõe os streams SSE alimentados pelo EventBus
+type Module struct {
+	module.Base
+	cfg *config.Config
+}
+
+// NewModule cria o módulo de streams
+func NewModule(cfg *config.Config) *Module {
+	return &Module{cfg: cfg}
+}
+
+// Name retorna o nome do módulo
+func (m *Module) Name() string {
+	return "stream"
+}
+
+// Register registra o broker e o handler SSE
+func (m *Module) Register(c *container.Container) error {
+	cfg := m.cfg
+
+	c.RegisterSingleton("sseBroker", func() interface{} {
+		return sse.NewBroker(sse.BrokerConfig{BufferSize: cfg.SSEBufferSize})
+	})
+
+	c.RegisterSingleton("sseHandler", func() interface{} {
+		broker := c.MustGet("sseBroker").(*sse.Broker)
+		tokenGenerator := c.MustGet("tokenGenerator").(contracts.TokenGenerator)
+		return sse.NewHandler(broker, tokenGenerator, cfg.SSEHeartbeat)
+	}, container.DependsOn("sseBroker", "tokenGenerator"))
+
+	return nil
+}
+
+// RegisterRoutes registra os streams SSE
+func (m *Module) RegisterRoutes(router *gin.RouterGroup, c *container.Container) {
+	sseHandler := c.MustGet("sseHandler").(*sse.Handler)
+
+	streamGroup := router.Group("/stream")
+	{
+		streamGroup.GET("/orders", sseHandler.StreamOrders)
+		streamGroup.GET("/products/:id/stock", sseHandler.StreamProductStock)
+	}
+}
+
+// Subscribe inscreve o broker nos eventos transmitidos
+func (m *Module) Subscribe(c *container.Container, bus *events.EventBus) error {
+	broker := c.MustGet("sseBroker").(*sse.Broker)
+
+	for _, pattern := range sse.StreamedEventTypes {
+		if _, err := bus.SubscribeWithOptions(pattern, broker.HandleEvent,
+			events.WithSubscriptionName("sse."+pattern),
+		); err != nil {
+			return fmt.Errorf("failed to subscribe event streams to %s: %w", pattern, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package adapters
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidToken indica token malformado, com assinatura inválida, expirado
+// ou que não é de acesso
+var ErrInvalidToken = errors.New("invalid token")
+
+const (
+	tokenTypeAccess  = "access"
+	tokenTypeRefresh = "refresh"
+)
+
+// jwtHeader é fixo: só HS256 é emitido e aceito
+var jwtHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+type tokenClaims struct {
+	Subject   string `json:"sub"`
+	Type      string `json:"typ"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// HMACTokenGenerator emite e valida JWTs assinados com HS256
+type HMACTokenGenerator struct {
+	secret     []byte
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+	now        func() time.Time
+}
+
+// NewHMACTokenGenerator cria o gerador com a chave e as validades dos tokens
+func NewHMACTokenGenerator(secret string, accessTTL, refreshTTL time.Duration) *HMACTokenGenerator {
+	return &HMACTokenGenerator{
+		secret:     []byte(secret),
+		accessTTL:  accessTTL,
+		refreshTTL: refreshTTL,
+		now:        time.Now,
+	}
+}
+
+// GenerateAccessToken emite um token de acesso para o usuário
+func (g *HMACTokenGenerator) GenerateAccessToken(userID string) (string, error) {
+	return g.generate(userID, tokenTypeAccess, g.accessTTL)
+}
+
+// GenerateRefreshToken emite um refresh token, que ValidateToken não aceita
+func (g *HMACTokenGenerator) GenerateRefreshToken(userID string) (string, error) {
+	return g.generate(userID, tokenTypeRefresh, g.refreshTTL)
+}
+
+// ValidateToken confere assinatura, tipo e validade e retorna o userID
+func (g *HMACTokenGenerator) ValidateToken(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != jwtHeader {
+		return "", ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, g.sign(parts[0]+"."+parts[1])) {
+		return "", ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+	var claims tokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", ErrInvalidToken
+	}
+
+	if claims.Type != tokenTypeAccess || claims.Subject == "" {
+		return "", ErrInvalidToken
+	}
+	if g.now().Unix() >= claims.ExpiresAt {
+		return "", fmt.Errorf("%w: expired", ErrInvalidToken)
+	}
+	return claims.Subject, nil
+}
+
+func (g *HMACTokenGenerator) generate(userID, tokenType string, ttl time.Duration) (string, error) {
+	if userID == "" {
+		return "", errors.New("user ID is required")
+	}
+
+	now := g.now()
+	payload, err := json.Marshal(tokenClaims{
+		Subject:   userID,
+		Type:      tokenType,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode token claims: %w", err)
+	}
+
+	unsigned := jwtHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(g.sign(unsigned)), nil
+}
+
+func (g *HMACTokenGenerator) sign(unsigned string) []byte {
+	mac := hmac.New(sha256.New, g.secret)
+	mac.Write([]byte(unsigned))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,58 @@
+package adapters_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"go-modular-monolith/internal/modules/user/adapters"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHMACTokenGeneratorAcceptsOwnAccessTokens(t *testing.T) {
+	tokens := adapters.NewHMACTokenGenerator("secret", time.Minute, time.Hour)
+
+	token, err := tokens.GenerateAccessToken("user-1")
+	require.NoError(t, err)
+
+	userID, err := tokens.ValidateToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", userID)
+}
+
+func TestHMACTokenGeneratorRejectsForgedTokens(t *testing.T) {
+	tokens := adapters.NewHMACTokenGenerator("secret", time.Minute, time.Hour)
+
+	token, err := tokens.GenerateAccessToken("user-1")
+	require.NoError(t, err)
+	parts := strings.Split(token, ".")
+
+	refresh, err := tokens.GenerateRefreshToken("user-1")
+	require.NoError(t, err)
+	otherKey, err := adapters.NewHMACTokenGenerator("other-secret", time.Minute, time.Hour).GenerateAccessToken("user-1")
+	require.NoError(t, err)
+	expired, err := adapters.NewHMACTokenGenerator("secret", -time.Minute, time.Hour).GenerateAccessToken("user-1")
+	require.NoError(t, err)
+
+	// Outro usuário no payload, mantendo a assinatura original
+	claims := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"admin","typ":"access","iat":0,"exp":9999999999}`))
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+
+	for name, forged := range map[string]string{
+		"legacy mock token":   "access_token_for_user-1",
+		"empty":               "",
+		"tampered payload":    parts[0] + "." + claims + "." + parts[2],
+		"alg none":            unsigned + "." + claims + ".",
+		"signed by other key": otherKey,
+		"refresh token":       refresh,
+		"expired":             expired,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := tokens.ValidateToken(forged)
+			assert.ErrorIs(t, err, adapters.ErrInvalidToken)
+		})
+	}
+}
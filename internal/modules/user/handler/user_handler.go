@@ -1,8 +1,10 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
+	"go-modular-monolith/internal/shared/middleware"
 	"go-modular-monolith/pkg/contracts"
 
 	"github.com/gin-gonic/gin"
@@ -27,10 +29,11 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 
 	createdUser, err := h.userService.CreateUser(c.Request.Context(), user)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
+	middleware.SetETag(c, createdUser.Version)
 	c.JSON(http.StatusCreated, createdUser)
 }
 
@@ -42,6 +45,19 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 		return
 	}
 
+	middleware.SetETag(c, user.Version)
+	c.JSON(http.StatusOK, user)
+}
+
+// GetCurrentUser retorna o usuário do token Bearer, resolvido no escopo da requisição
+func (h *UserHandler) GetCurrentUser(c *gin.Context) {
+	user := middleware.CurrentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or missing access token"})
+		return
+	}
+
+	middleware.SetETag(c, user.Version)
 	c.JSON(http.StatusOK, user)
 }
 
@@ -55,10 +71,11 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 
 	updatedUser, err := h.userService.UpdateUser(c.Request.Context(), id, user)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
+	middleware.SetETag(c, updatedUser.Version)
 	c.JSON(http.StatusOK, updatedUser)
 }
 
@@ -92,3 +109,49 @@ func (h *UserHandler) ValidateUser(c *gin.Context) {
 
 	c.JSON(http.StatusOK, user)
 }
+
+func (h *UserHandler) ListDeletedUsers(c *gin.Context) {
+	users, err := h.userService.ListDeletedUsers(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, users)
+}
+
+func (h *UserHandler) RestoreUser(c *gin.Context) {
+	user, err := h.userService.RestoreUser(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	middleware.SetETag(c, user.Version)
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *UserHandler) PurgeUser(c *gin.Context) {
+	if err := h.userService.PurgeUser(c.Request.Context(), c.Param("id")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, contracts.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, contracts.ErrPreconditionFailed):
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+	case errors.Is(err, contracts.ErrVersionConflict), errors.Is(err, contracts.ErrAlreadyExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		if logger := middleware.RequestLogger(c); logger != nil {
+			logger.Error("User request failed", contracts.Field{Key: "error", Value: err.Error()})
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
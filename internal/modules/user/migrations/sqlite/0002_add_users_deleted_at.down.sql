@@ -0,0 +1,3 @@
+DROP INDEX idx_users_deleted_at;
+
+ALTER TABLE users DROP COLUMN deleted_at;
@@ -0,0 +1,4 @@
+-- Soft delete: linhas com deleted_at preenchido ficam fora das consultas
+ALTER TABLE users ADD COLUMN deleted_at DATETIME;
+
+CREATE INDEX idx_users_deleted_at ON users (deleted_at);
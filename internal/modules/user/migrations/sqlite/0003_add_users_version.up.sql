@@ -0,0 +1,2 @@
+-- Controle de concorrência otimista: incrementada a cada Update
+ALTER TABLE users ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,141 @@
+package user
+
+import (
+	"context"
+	"strings"
+
+	userHandler "go-modular-monolith/internal/modules/user/handler"
+	userRepository "go-modular-monolith/internal/modules/user/repository"
+	userService "go-modular-monolith/internal/modules/user/service"
+	"go-modular-monolith/internal/shared/config"
+	"go-modular-monolith/internal/shared/database"
+	"go-modular-monolith/internal/shared/middleware"
+	"go-modular-monolith/pkg/container"
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/module"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Module é o módulo de usuários
+type Module struct {
+	module.Base
+	cfg *config.Config
+}
+
+// NewModule cria o módulo de usuários
+func NewModule(cfg *config.Config) *Module {
+	return &Module{cfg: cfg}
+}
+
+// Name retorna o nome do módulo
+func (m *Module) Name() string {
+	return "user"
+}
+
+// Register registra repositório, serviço, handler e o usuário atual por requisição
+func (m *Module) Register(c *container.Container) error {
+	// User Repository (em memória com STORAGE=memory, senão MySQL/SQLite)
+	if m.cfg.Storage == config.StorageMemory {
+		c.RegisterSingleton("userRepository", func() interface{} {
+			return userRepository.NewMemoryUserRepository()
+		})
+	} else {
+		c.RegisterSingleton("userRepository", func() interface{} {
+			db := c.MustGet("database").(*gorm.DB)
+			return userRepository.NewMySQLUserRepository(db)
+		}, container.DependsOn("database"))
+	}
+
+	// User Service
+	c.RegisterSingleton("userService", func() interface{} {
+		userRepo := c.MustGet("userRepository").(contracts.UserRepository)
+		passwordHasher := c.MustGet("passwordHasher").(contracts.PasswordHasher)
+		emailService := c.MustGet("emailService").(contracts.EmailService)
+		tokenGenerator := c.MustGet("tokenGenerator").(contracts.TokenGenerator)
+		eventPublisher := c.MustGet("eventPublisher").(contracts.EventPublisher)
+		txManager := c.MustGet("transactionManager").(contracts.TransactionManager)
+		auditor := c.MustGet("auditor").(contracts.Auditor)
+		logger := c.MustGet("logger").(contracts.Logger)
+
+		svc := userService.NewUserService(
+			userRepo,
+			passwordHasher,
+			emailService,
+			tokenGenerator,
+			eventPublisher,
+			txManager,
+			auditor,
+			logger,
+		)
+		// Aguarda os emails de boas-vindas em andamento
+		c.Append(container.Hook{Name: "userService", OnStop: svc.(*userService.UserService).Close})
+		return svc
+	}, container.DependsOn(
+		"userRepository", "passwordHasher", "emailService", "tokenGenerator", "eventPublisher", "transactionManager", "auditor", "logger",
+	))
+
+	// User Handler
+	c.RegisterSingleton("userHandler", func() interface{} {
+		userService := c.MustGet("userService").(contracts.UserService)
+		return userHandler.NewUserHandler(userService)
+	}, container.DependsOn("userService"))
+
+	// Usuário autenticado pelo token Bearer; nil quando ausente ou inválido
+	c.RegisterScoped(middleware.CurrentUserKey, func(s *container.Scope) interface{} {
+		ctx := s.MustGet("ginContext").(*gin.Context)
+		tokenGenerator := s.MustGet("tokenGenerator").(contracts.TokenGenerator)
+		userSvc := s.MustGet("userService").(contracts.UserService)
+
+		header := ctx.GetHeader("Authorization")
+		if !strings.HasPrefix(header, "Bearer ") {
+			return (*contracts.User)(nil)
+		}
+
+		userID, err := tokenGenerator.ValidateToken(strings.TrimPrefix(header, "Bearer "))
+		if err != nil {
+			return (*contracts.User)(nil)
+		}
+
+		user, err := userSvc.GetUserByID(ctx.Request.Context(), userID)
+		if err != nil {
+			return (*contracts.User)(nil)
+		}
+		return user
+	}, container.DependsOn("tokenGenerator", "userService"))
+
+	return nil
+}
+
+// RegisterRoutes registra as rotas do módulo de usuário
+func (m *Module) RegisterRoutes(router *gin.RouterGroup, c *container.Container) {
+	userHandler := c.MustGet("userHandler").(contracts.UserHandler)
+
+	userGroup := router.Group("/users")
+	{
+		userGroup.POST("/", userHandler.CreateUser)
+		userGroup.GET("/me", userHandler.GetCurrentUser)
+		userGroup.GET("/:id", userHandler.GetUser)
+		userGroup.PUT("/:id", userHandler.UpdateUser)
+		userGroup.DELETE("/:id", userHandler.DeleteUser)
+		userGroup.POST("/validate", userHandler.ValidateUser)
+	}
+
+	// Administração dos usuários excluídos
+	adminGroup := router.Group("/admin/users")
+	{
+		adminGroup.GET("/deleted", userHandler.ListDeletedUsers)
+		adminGroup.POST("/:id/restore", userHandler.RestoreUser)
+		adminGroup.DELETE("/:id", userHandler.PurgeUser)
+	}
+}
+
+// Health verifica a conexão e a tabela users
+func (m *Module) Health(ctx context.Context, c *container.Container) error {
+	if m.cfg.Storage == config.StorageMemory {
+		return nil // Sem banco a verificar
+	}
+	db := c.MustGet("database").(*gorm.DB)
+	return database.CheckTables(ctx, db, &database.UserModel{})
+}
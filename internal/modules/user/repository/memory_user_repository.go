@@ -0,0 +1,247 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go-modular-monolith/internal/modules/user/ports"
+	"go-modular-monolith/internal/shared/database"
+	"go-modular-monolith/pkg/contracts"
+)
+
+// memoryUserRepository implementa UserRepository em memória, com email e
+// username únicos como no MySQL, inclusive entre usuários excluídos. Alterações feitas em uma transação em
+// memória são desfeitas no rollback.
+type memoryUserRepository struct {
+	users map[string]*contracts.User
+	mu    sync.RWMutex
+}
+
+// NewMemoryUserRepository cria um repositório de usuários em memória
+func NewMemoryUserRepository() ports.UserRepository {
+	return &memoryUserRepository{
+		users: make(map[string]*contracts.User),
+	}
+}
+
+func (r *memoryUserRepository) Create(ctx context.Context, user *contracts.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.users[user.ID]; exists {
+		return fmt.Errorf("failed to create user: user %s %w", user.ID, contracts.ErrAlreadyExists)
+	}
+	if err := r.checkUnique(user); err != nil {
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+	user.Version = 1
+	r.users[user.ID] = copyUser(user)
+	database.OnRollback(ctx, r.undoCreate(user.ID))
+	return nil
+}
+
+func (r *memoryUserRepository) GetByID(ctx context.Context, id string) (*contracts.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, exists := r.users[id]
+	if !exists || user.DeletedAt != nil {
+		return nil, fmt.Errorf("user %w", contracts.ErrNotFound)
+	}
+	return copyUser(user), nil
+}
+
+func (r *memoryUserRepository) GetByEmail(ctx context.Context, email string) (*contracts.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, user := range r.users {
+		if user.Email == email && user.DeletedAt == nil {
+			return copyUser(user), nil
+		}
+	}
+	return nil, fmt.Errorf("user %w", contracts.ErrNotFound)
+}
+
+func (r *memoryUserRepository) Update(ctx context.Context, user *contracts.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, exists := r.users[user.ID]
+	if !exists || previous.DeletedAt != nil {
+		return fmt.Errorf("user %w", contracts.ErrNotFound)
+	}
+	if previous.Version != user.Version {
+		return fmt.Errorf("user %w", contracts.ErrVersionConflict)
+	}
+	if err := r.checkUnique(user); err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+	updated := copyUser(user)
+	updated.Version++
+	r.users[user.ID] = updated
+	user.Version = updated.Version
+	database.OnRollback(ctx, r.undoUpdate(previous))
+	return nil
+}
+
+func (r *memoryUserRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, exists := r.users[id]
+	if !exists || previous.DeletedAt != nil {
+		return fmt.Errorf("user %w", contracts.ErrNotFound)
+	}
+	deleted := copyUser(previous)
+	now := time.Now()
+	deleted.DeletedAt = &now
+	r.users[id] = deleted
+	database.OnRollback(ctx, r.undoDelete(id))
+	return nil
+}
+
+// ListDeleted lista os usuários excluídos, do mais antigo para o mais recente
+func (r *memoryUserRepository) ListDeleted(ctx context.Context) ([]*contracts.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := make([]*contracts.User, 0)
+	for _, user := range r.users {
+		if user.DeletedAt != nil {
+			users = append(users, copyUser(user))
+		}
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		if !users[i].DeletedAt.Equal(*users[j].DeletedAt) {
+			return users[i].DeletedAt.Before(*users[j].DeletedAt)
+		}
+		return users[i].ID < users[j].ID
+	})
+
+	return users, nil
+}
+
+func (r *memoryUserRepository) Restore(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, exists := r.users[id]
+	if !exists || previous.DeletedAt == nil {
+		return fmt.Errorf("deleted user %w", contracts.ErrNotFound)
+	}
+	restored := copyUser(previous)
+	restored.DeletedAt = nil
+	restored.UpdatedAt = time.Now()
+	r.users[id] = restored
+	database.OnRollback(ctx, r.undoRestore(id, previous.DeletedAt))
+	return nil
+}
+
+func (r *memoryUserRepository) Purge(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, exists := r.users[id]
+	if !exists || previous.DeletedAt == nil {
+		return fmt.Errorf("deleted user %w", contracts.ErrNotFound)
+	}
+	delete(r.users, id)
+	database.OnRollback(ctx, r.undoPurge(previous))
+	return nil
+}
+
+// checkUnique verifica email e username contra os demais usuários; requer o lock
+func (r *memoryUserRepository) checkUnique(user *contracts.User) error {
+	for id, existing := range r.users {
+		if id == user.ID {
+			continue
+		}
+		if existing.Email == user.Email {
+			return fmt.Errorf("email %s %w", user.Email, contracts.ErrAlreadyExists)
+		}
+		if existing.Username == user.Username {
+			return fmt.Errorf("username %s %w", user.Username, contracts.ErrAlreadyExists)
+		}
+	}
+	return nil
+}
+
+// As funções de undo desfazem apenas a própria alteração, sobre o estado atual
+// do usuário: sem isolamento, outras transações podem tê-lo alterado depois.
+
+func (r *memoryUserRepository) undoCreate(id string) func() {
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.users, id)
+	}
+}
+
+// undoUpdate volta os campos ao valor anterior, preservando uma exclusão feita
+// depois da atualização. A versão avança para invalidar ETags já entregues.
+func (r *memoryUserRepository) undoUpdate(previous *contracts.User) func() {
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		current, exists := r.users[previous.ID]
+		if !exists {
+			return
+		}
+		restored := copyUser(previous)
+		restored.DeletedAt = current.DeletedAt
+		restored.Version = current.Version + 1
+		r.users[previous.ID] = restored
+	}
+}
+
+func (r *memoryUserRepository) undoDelete(id string) func() {
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if current, exists := r.users[id]; exists {
+			restored := copyUser(current)
+			restored.DeletedAt = nil
+			r.users[id] = restored
+		}
+	}
+}
+
+func (r *memoryUserRepository) undoRestore(id string, deletedAt *time.Time) func() {
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if current, exists := r.users[id]; exists {
+			deleted := copyUser(current)
+			deleted.DeletedAt = deletedAt
+			r.users[id] = deleted
+		}
+	}
+}
+
+func (r *memoryUserRepository) undoPurge(previous *contracts.User) func() {
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if _, exists := r.users[previous.ID]; !exists {
+			r.users[previous.ID] = previous
+		}
+	}
+}
+
+func copyUser(user *contracts.User) *contracts.User {
+	copied := *user
+	if user.DeletedAt != nil {
+		deletedAt := *user.DeletedAt
+		copied.DeletedAt = &deletedAt
+	}
+	return &copied
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"go-modular-monolith/internal/modules/user/ports"
@@ -27,11 +28,16 @@ func NewMySQLUserRepository(db *gorm.DB) ports.UserRepository {
 func (r *mysqlUserRepository) Create(ctx context.Context, user *contracts.User) error {
 	userModel := &database.UserModel{}
 	userModel.FromContract(user)
+	userModel.Version = 1
 
-	if err := r.db.WithContext(ctx).Create(userModel).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Create(userModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return fmt.Errorf("failed to create user: %w", contracts.ErrAlreadyExists)
+		}
 		return fmt.Errorf("failed to create user: %w", err)
 	}
 
+	user.Version = userModel.Version
 	return nil
 }
 
@@ -39,9 +45,9 @@ func (r *mysqlUserRepository) Create(ctx context.Context, user *contracts.User)
 func (r *mysqlUserRepository) GetByID(ctx context.Context, id string) (*contracts.User, error) {
 	var userModel database.UserModel
 
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&userModel).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Where("id = ?", id).First(&userModel).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("user not found")
+			return nil, fmt.Errorf("user %w", contracts.ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get user by ID: %w", err)
 	}
@@ -53,9 +59,9 @@ func (r *mysqlUserRepository) GetByID(ctx context.Context, id string) (*contract
 func (r *mysqlUserRepository) GetByEmail(ctx context.Context, email string) (*contracts.User, error) {
 	var userModel database.UserModel
 
-	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&userModel).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Where("email = ?", email).First(&userModel).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("user not found")
+			return nil, fmt.Errorf("user %w", contracts.ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get user by email: %w", err)
 	}
@@ -63,32 +69,83 @@ func (r *mysqlUserRepository) GetByEmail(ctx context.Context, email string) (*co
 	return userModel.ToContract(), nil
 }
 
-// Update atualiza um usuário existente
+// Update atualiza um usuário existente, se ainda estiver na versão informada
 func (r *mysqlUserRepository) Update(ctx context.Context, user *contracts.User) error {
 	userModel := &database.UserModel{}
 	userModel.FromContract(user)
+	userModel.Version = user.Version + 1
 
-	result := r.db.WithContext(ctx).Where("id = ?", user.ID).Updates(userModel)
+	result := database.Conn(ctx, r.db).Where("id = ? AND version = ?", user.ID, user.Version).Updates(userModel)
 	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return fmt.Errorf("failed to update user: %w", contracts.ErrAlreadyExists)
+		}
 		return fmt.Errorf("failed to update user: %w", result.Error)
 	}
 
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("user not found")
+		return fmt.Errorf("user %w", database.VersionMismatch(ctx, r.db, &database.UserModel{}, user.ID))
 	}
 
+	user.Version = userModel.Version
 	return nil
 }
 
-// Delete remove um usuário do banco de dados
+// Delete marca o usuário como excluído (soft delete)
 func (r *mysqlUserRepository) Delete(ctx context.Context, id string) error {
-	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&database.UserModel{})
+	result := database.Conn(ctx, r.db).Where("id = ?", id).Delete(&database.UserModel{})
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete user: %w", result.Error)
 	}
 
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("user not found")
+		return fmt.Errorf("user %w", contracts.ErrNotFound)
+	}
+
+	return nil
+}
+
+// ListDeleted lista os usuários excluídos, do mais antigo para o mais recente
+func (r *mysqlUserRepository) ListDeleted(ctx context.Context) ([]*contracts.User, error) {
+	var userModels []database.UserModel
+
+	if err := database.Conn(ctx, r.db).Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at, id").Find(&userModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to list deleted users: %w", err)
+	}
+
+	users := make([]*contracts.User, len(userModels))
+	for i, model := range userModels {
+		users[i] = model.ToContract()
+	}
+
+	return users, nil
+}
+
+// Restore desfaz a exclusão de um usuário
+func (r *mysqlUserRepository) Restore(ctx context.Context, id string) error {
+	result := database.Conn(ctx, r.db).Unscoped().Model(&database.UserModel{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return fmt.Errorf("failed to restore user: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("deleted user %w", contracts.ErrNotFound)
+	}
+
+	return nil
+}
+
+// Purge remove definitivamente um usuário excluído
+func (r *mysqlUserRepository) Purge(ctx context.Context, id string) error {
+	result := database.Conn(ctx, r.db).Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).Delete(&database.UserModel{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to purge user: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("deleted user %w", contracts.ErrNotFound)
 	}
 
 	return nil
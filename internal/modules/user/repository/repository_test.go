@@ -0,0 +1,61 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go-modular-monolith/internal/modules/user"
+	"go-modular-monolith/internal/modules/user/repository"
+	"go-modular-monolith/internal/shared/database"
+	"go-modular-monolith/internal/shared/database/databasetest"
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/contracts/contractstest"
+	"go-modular-monolith/pkg/migrate"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserRepository(t *testing.T) {
+	t.Run("memory", func(t *testing.T) {
+		contractstest.TestUserRepository(t, func(t *testing.T) contracts.UserRepository {
+			return repository.NewMemoryUserRepository()
+		})
+	})
+
+	migrations := migrate.Source{Module: "user", FS: new(user.Module).Migrations()}
+	for name, url := range databasetest.URLs() {
+		t.Run(name, func(t *testing.T) {
+			contractstest.TestUserRepository(t, func(t *testing.T) contracts.UserRepository {
+				return repository.NewMySQLUserRepository(databasetest.Open(t, url, migrations))
+			})
+		})
+	}
+}
+
+// Desfazer uma atualização não reverte a exclusão feita depois dela por outra requisição
+func TestMemoryUserRollbackKeepsConcurrentDelete(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryUserRepository()
+	user := &contracts.User{ID: "user-1", Username: "john", Email: "john@example.com"}
+	require.NoError(t, repo.Create(ctx, user))
+
+	rollback := errors.New("rollback")
+	err := database.NewMemoryTransactionManager().WithinTransaction(ctx, func(txCtx context.Context) error {
+		updated := *user
+		updated.Username = "johnny"
+		require.NoError(t, repo.Update(txCtx, &updated))
+		require.NoError(t, repo.Delete(ctx, "user-1"))
+		return rollback
+	})
+	require.ErrorIs(t, err, rollback)
+
+	_, err = repo.GetByID(ctx, "user-1")
+	assert.ErrorIs(t, err, contracts.ErrNotFound)
+
+	deleted, err := repo.ListDeleted(ctx)
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	assert.Equal(t, "john", deleted[0].Username)
+}
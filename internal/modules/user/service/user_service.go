@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"time"
 
 	"go-modular-monolith/internal/modules/user/domain"
@@ -20,7 +22,10 @@ type UserService struct {
 	emailService   ports.EmailService
 	tokenGenerator ports.TokenGenerator
 	eventPublisher contracts.EventPublisher
+	txManager      contracts.TransactionManager
+	auditor        contracts.Auditor
 	logger         contracts.Logger
+	background     sync.WaitGroup // Envios de email em andamento
 }
 
 // NewUserService cria uma nova instância do serviço de usuário
@@ -30,6 +35,8 @@ func NewUserService(
 	emailService ports.EmailService,
 	tokenGenerator ports.TokenGenerator,
 	eventPublisher contracts.EventPublisher,
+	txManager contracts.TransactionManager,
+	auditor contracts.Auditor,
 	logger contracts.Logger,
 ) ports.UserService {
 	return &UserService{
@@ -38,6 +45,8 @@ func NewUserService(
 		emailService:   emailService,
 		tokenGenerator: tokenGenerator,
 		eventPublisher: eventPublisher,
+		txManager:      txManager,
+		auditor:        auditor,
 		logger:         logger,
 	}
 }
@@ -46,10 +55,11 @@ func NewUserService(
 func (s *UserService) CreateUser(ctx context.Context, req contracts.CreateUserRequest) (*contracts.User, error) {
 	s.logger.Info("Creating new user", contracts.Field{Key: "email", Value: req.Email})
 
-	// Verificar se o email já existe
+	// Verificar se o email já existe. Usuários excluídos não aparecem aqui, mas
+	// mantêm email e username até o purge: o Create os recusa com ErrAlreadyExists.
 	existingUser, err := s.userRepo.GetByEmail(ctx, req.Email)
 	if err == nil && existingUser != nil {
-		return nil, errors.New("user with this email already exists")
+		return nil, fmt.Errorf("user with this email %w", contracts.ErrAlreadyExists)
 	}
 
 	// Gerar ID único
@@ -78,33 +88,40 @@ func (s *UserService) CreateUser(ctx context.Context, req contracts.CreateUserRe
 		return nil, err
 	}
 
-	// Persistir usuário
-	if err := s.userRepo.Create(ctx, &contracts.User{
-		ID:       userAggregate.GetUser().ID,
-		Username: userAggregate.GetUser().Username,
-		Email:    userAggregate.GetUser().Email,
-		Password: userAggregate.GetUser().Password,
-	}); err != nil {
-		s.logger.Error("Failed to create user in repository", contracts.Field{Key: "error", Value: err})
-		return nil, errors.New("failed to create user")
-	}
+	// Persistir usuário e evento na mesma transação (outbox)
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.Create(ctx, &userAggregate.GetUser().User); err != nil {
+			if errors.Is(err, contracts.ErrAlreadyExists) {
+				return err
+			}
+			s.logger.Error("Failed to create user in repository", contracts.Field{Key: "error", Value: err})
+			return errors.New("failed to create user")
+		}
 
-	// Publicar evento
-	event := contracts.Event{
-		Type:      events.UserCreatedEventType,
-		Timestamp: time.Now(),
-		Payload: contracts.UserCreatedEvent{
-			UserID: userID,
-			Email:  req.Email,
-		},
-	}
+		event := contracts.Event{
+			Type:      events.UserCreatedEventType,
+			Timestamp: time.Now(),
+			Payload: contracts.UserCreatedEvent{
+				UserID: userID,
+				Email:  req.Email,
+			},
+		}
+
+		if err := s.eventPublisher.Publish(ctx, event); err != nil {
+			s.logger.Error("Failed to publish user created event", contracts.Field{Key: "error", Value: err})
+			return errors.New("failed to create user")
+		}
 
-	if err := s.eventPublisher.Publish(ctx, event); err != nil {
-		s.logger.Warn("Failed to publish user created event", contracts.Field{Key: "error", Value: err})
+		return s.auditor.Record(ctx, contracts.AuditEntityUser, userID, contracts.AuditActionCreate, nil, &userAggregate.GetUser().User)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Enviar email de boas-vindas (assíncrono)
+	// Enviar email de boas-vindas (assíncrono, aguardado no Close)
+	s.background.Add(1)
 	go func() {
+		defer s.background.Done()
 		if err := s.emailService.SendWelcomeEmail(context.Background(), userID, req.Email); err != nil {
 			s.logger.Warn("Failed to send welcome email", contracts.Field{Key: "error", Value: err})
 		}
@@ -114,6 +131,22 @@ func (s *UserService) CreateUser(ctx context.Context, req contracts.CreateUserRe
 	return &userAggregate.GetUser().User, nil
 }
 
+// Close aguarda os envios de email em andamento até o prazo do contexto
+func (s *UserService) Close(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.background.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("pending emails were not sent before deadline: %w", ctx.Err())
+	}
+}
+
 // GetUserByID obtém um usuário por ID
 func (s *UserService) GetUserByID(ctx context.Context, id string) (*contracts.User, error) {
 	if id == "" {
@@ -137,8 +170,8 @@ func (s *UserService) GetUserByID(ctx context.Context, id string) (*contracts.Us
 
 // UpdateUser atualiza um usuário
 func (s *UserService) UpdateUser(ctx context.Context, id string, req contracts.UpdateUserRequest) (*contracts.User, error) {
-	// Buscar usuário existente
-	existingUser, err := s.userRepo.GetByID(ctx, id)
+	// Buscar usuário existente no primário: uma réplica atrasada perderia alterações
+	existingUser, err := s.userRepo.GetByID(contracts.WithPrimaryReads(ctx), id)
 	if err != nil {
 		return nil, err
 	}
@@ -147,6 +180,11 @@ func (s *UserService) UpdateUser(ctx context.Context, id string, req contracts.U
 		return nil, errors.New("user not found")
 	}
 
+	// Versão pedida pelo cliente (If-Match)
+	if err := contracts.CheckIfMatch(ctx, existingUser.Version); err != nil {
+		return nil, err
+	}
+
 	userDomain := &domain.User{
 		User: *existingUser,
 	}
@@ -172,17 +210,25 @@ func (s *UserService) UpdateUser(ctx context.Context, id string, req contracts.U
 		return nil, err
 	}
 
-	// Persistir alterações
-	if err := s.userRepo.Update(ctx, existingUser); err != nil {
-		s.logger.Error("Failed to update user in repository", contracts.Field{Key: "error", Value: err})
-		return nil, errors.New("failed to update user")
+	// Persistir alterações; o repositório recusa se o usuário mudou desde a leitura
+	updatedUser := &userAggregate.GetUser().User
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.Update(ctx, updatedUser); err != nil {
+			s.logger.Error("Failed to update user in repository", contracts.Field{Key: "error", Value: err})
+			return fmt.Errorf("failed to update user: %w", err)
+		}
+
+		return s.auditor.Record(ctx, contracts.AuditEntityUser, id, contracts.AuditActionUpdate, existingUser, updatedUser)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	s.logger.Info("User updated successfully", contracts.Field{Key: "user_id", Value: id})
-	return &userAggregate.GetUser().User, nil
+	return updatedUser, nil
 }
 
-// DeleteUser remove um usuário
+// DeleteUser exclui um usuário (soft delete); ele pode ser restaurado até o purge
 func (s *UserService) DeleteUser(ctx context.Context, id string) error {
 	if id == "" {
 		return errors.New("user ID cannot be empty")
@@ -198,27 +244,130 @@ func (s *UserService) DeleteUser(ctx context.Context, id string) error {
 		return errors.New("user not found")
 	}
 
-	// Deletar usuário
-	if err := s.userRepo.Delete(ctx, id); err != nil {
-		s.logger.Error("Failed to delete user", contracts.Field{Key: "error", Value: err})
-		return errors.New("failed to delete user")
+	// Deletar usuário junto com o registro de auditoria
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.Delete(ctx, id); err != nil {
+			s.logger.Error("Failed to delete user", contracts.Field{Key: "error", Value: err})
+			return errors.New("failed to delete user")
+		}
+
+		// Publicar evento
+		event := contracts.Event{
+			Type:      events.UserDeletedEventType,
+			Timestamp: time.Now(),
+			Payload:   contracts.UserDeletedEvent{UserID: id},
+		}
+
+		if err := s.eventPublisher.Publish(ctx, event); err != nil {
+			s.logger.Warn("Failed to publish user deleted event", contracts.Field{Key: "error", Value: err})
+		}
+
+		return s.auditor.Record(ctx, contracts.AuditEntityUser, id, contracts.AuditActionDelete, existingUser, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("User deleted successfully", contracts.Field{Key: "user_id", Value: id})
+	return nil
+}
+
+// ListDeletedUsers lista os usuários excluídos
+func (s *UserService) ListDeletedUsers(ctx context.Context) ([]*contracts.User, error) {
+	users, err := s.userRepo.ListDeleted(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list deleted users", contracts.Field{Key: "error", Value: err})
+		return nil, err
 	}
 
-	// Publicar evento
-	event := contracts.Event{
-		Type:      events.UserDeletedEventType,
-		Timestamp: time.Now(),
-		Payload:   map[string]string{"user_id": id},
+	return users, nil
+}
+
+// RestoreUser desfaz a exclusão de um usuário
+func (s *UserService) RestoreUser(ctx context.Context, id string) (*contracts.User, error) {
+	var user *contracts.User
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.Restore(ctx, id); err != nil {
+			return err
+		}
+
+		var err error
+		user, err = s.userRepo.GetByID(contracts.WithPrimaryReads(ctx), id)
+		if err != nil {
+			return err
+		}
+
+		event := contracts.Event{
+			Type:      events.UserRestoredEventType,
+			Timestamp: time.Now(),
+			Payload:   contracts.UserRestoredEvent{UserID: id},
+		}
+
+		if err := s.eventPublisher.Publish(ctx, event); err != nil {
+			s.logger.Error("Failed to publish user restored event", contracts.Field{Key: "error", Value: err})
+			return errors.New("failed to restore user")
+		}
+
+		return s.auditor.Record(ctx, contracts.AuditEntityUser, id, contracts.AuditActionRestore, nil, user)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.eventPublisher.Publish(ctx, event); err != nil {
-		s.logger.Warn("Failed to publish user deleted event", contracts.Field{Key: "error", Value: err})
+	s.logger.Info("User restored successfully", contracts.Field{Key: "user_id", Value: id})
+	return user, nil
+}
+
+// PurgeUser remove definitivamente um usuário excluído
+func (s *UserService) PurgeUser(ctx context.Context, id string) error {
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		user, err := s.deletedUser(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.userRepo.Purge(ctx, id); err != nil {
+			return err
+		}
+
+		event := contracts.Event{
+			Type:      events.UserPurgedEventType,
+			Timestamp: time.Now(),
+			Payload:   contracts.UserPurgedEvent{UserID: id},
+		}
+
+		if err := s.eventPublisher.Publish(ctx, event); err != nil {
+			s.logger.Error("Failed to publish user purged event", contracts.Field{Key: "error", Value: err})
+			return errors.New("failed to purge user")
+		}
+
+		// O snapshot removido fica só na trilha de auditoria
+		return s.auditor.Record(ctx, contracts.AuditEntityUser, id, contracts.AuditActionPurge, user, nil)
+	})
+	if err != nil {
+		return err
 	}
 
-	s.logger.Info("User deleted successfully", contracts.Field{Key: "user_id", Value: id})
+	s.logger.Info("User purged successfully", contracts.Field{Key: "user_id", Value: id})
 	return nil
 }
 
+// deletedUser busca o usuário entre os excluídos
+func (s *UserService) deletedUser(ctx context.Context, id string) (*contracts.User, error) {
+	users, err := s.userRepo.ListDeleted(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range users {
+		if user.ID == id {
+			return user, nil
+		}
+	}
+
+	return nil, fmt.Errorf("deleted user %w", contracts.ErrNotFound)
+}
+
 // ValidateUser valida credenciais de usuário
 func (s *UserService) ValidateUser(ctx context.Context, email, password string) (*contracts.User, error) {
 	if email == "" || password == "" {
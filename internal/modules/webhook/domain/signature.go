@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cabeçalhos enviados em cada entrega
+const (
+	SignatureHeader  = "X-Webhook-Signature"
+	TimestampHeader  = "X-Webhook-Timestamp"
+	EventTypeHeader  = "X-Webhook-Event"
+	EventIDHeader    = "X-Webhook-Event-ID"
+	DeliveryIDHeader = "X-Webhook-Delivery"
+
+	signaturePrefix = "sha256="
+)
+
+// Sign calcula a assinatura HMAC-SHA256 de "timestamp.corpo" com o secret do endpoint.
+// Incluir o timestamp permite ao receptor rejeitar reenvios antigos.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature confere a assinatura recebida pelo receptor do webhook
+func VerifySignature(secret, signature, timestamp string, body []byte) bool {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+	expected := Sign(secret, time.Unix(unix, 0), body)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/events"
+)
+
+// ErrInvalidEndpoint indica dados de endpoint rejeitados pelas regras de domínio
+var ErrInvalidEndpoint = errors.New("invalid webhook endpoint")
+
+// SupportedEventTypes são os eventos que podem ser enviados a parceiros externos
+var SupportedEventTypes = []string{
+	events.OrderCreatedEventType,
+	events.OrderStatusUpdatedEventType,
+	events.ProductStockUpdatedEventType,
+}
+
+// Endpoint representa a entidade de domínio do endpoint de webhook
+type Endpoint struct {
+	contracts.WebhookEndpoint
+}
+
+// NewEndpoint cria um novo endpoint com validações de domínio.
+// Sem secret informado, um secret aleatório é gerado.
+func NewEndpoint(id, rawURL, secret string, eventTypes []string, active bool) (*Endpoint, error) {
+	if err := ValidateURL(rawURL); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateEventTypes(eventTypes); err != nil {
+		return nil, err
+	}
+
+	if secret == "" {
+		generated, err := GenerateSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	return &Endpoint{
+		WebhookEndpoint: contracts.WebhookEndpoint{
+			ID:         id,
+			URL:        rawURL,
+			Secret:     secret,
+			EventTypes: eventTypes,
+			Active:     active,
+			CreatedAt:  time.Now(),
+			UpdatedAt:  time.Now(),
+		},
+	}, nil
+}
+
+// Subscribes verifica se o endpoint está ativo e inscrito no tipo de evento
+func Subscribes(endpoint *contracts.WebhookEndpoint, eventType string) bool {
+	if !endpoint.Active {
+		return false
+	}
+	for _, subscribed := range endpoint.EventTypes {
+		if subscribed == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateSecret gera um secret aleatório para assinatura das entregas
+func GenerateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
+
+// ValidateURL exige uma URL absoluta http ou https
+func ValidateURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return fmt.Errorf("%w: URL must be absolute", ErrInvalidEndpoint)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return fmt.Errorf("%w: URL must use http or https", ErrInvalidEndpoint)
+	}
+	return nil
+}
+
+// ValidateEventTypes exige ao menos um tipo, todos suportados
+func ValidateEventTypes(eventTypes []string) error {
+	if len(eventTypes) == 0 {
+		return fmt.Errorf("%w: at least one event type is required", ErrInvalidEndpoint)
+	}
+	for _, eventType := range eventTypes {
+		if !isSupported(eventType) {
+			return fmt.Errorf("%w: unsupported event type %s", ErrInvalidEndpoint, eventType)
+		}
+	}
+	return nil
+}
+
+func isSupported(eventType string) bool {
+	for _, supported := range SupportedEventTypes {
+		if supported == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go-modular-monolith/internal/modules/webhook/domain"
+	"go-modular-monolith/pkg/contracts"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+	webhookService contracts.WebhookService
+}
+
+func NewWebhookHandler(webhookService contracts.WebhookService) contracts.WebhookHandler {
+	return &WebhookHandler{webhookService: webhookService}
+}
+
+func (h *WebhookHandler) CreateEndpoint(c *gin.Context) {
+	var req contracts.CreateWebhookEndpointRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	endpoint, err := h.webhookService.CreateEndpoint(c.Request.Context(), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, endpoint)
+}
+
+func (h *WebhookHandler) GetEndpoint(c *gin.Context) {
+	id := c.Param("id")
+	endpoint, err := h.webhookService.GetEndpoint(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, endpoint)
+}
+
+func (h *WebhookHandler) UpdateEndpoint(c *gin.Context) {
+	id := c.Param("id")
+	var req contracts.UpdateWebhookEndpointRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	endpoint, err := h.webhookService.UpdateEndpoint(c.Request.Context(), id, req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, endpoint)
+}
+
+func (h *WebhookHandler) DeleteEndpoint(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.webhookService.DeleteEndpoint(c.Request.Context(), id); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (h *WebhookHandler) ListEndpoints(c *gin.Context) {
+	endpoints, err := h.webhookService.ListEndpoints(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"endpoints": endpoints})
+}
+
+func (h *WebhookHandler) GetDelivery(c *gin.Context) {
+	id := c.Param("id")
+	delivery, err := h.webhookService.GetDelivery(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, delivery)
+}
+
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	filter := contracts.WebhookDeliveryFilter{Limit: 50}
+
+	if endpointID := c.Query("endpoint_id"); endpointID != "" {
+		filter.EndpointID = &endpointID
+	}
+
+	if status := c.Query("status"); status != "" {
+		deliveryStatus := contracts.WebhookDeliveryStatus(status)
+		filter.Status = &deliveryStatus
+	}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil {
+			filter.Limit = limit
+		}
+	}
+
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		if offset, err := strconv.Atoi(offsetStr); err == nil {
+			filter.Offset = offset
+		}
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deliveries": deliveries})
+}
+
+// ResendDelivery reenvia a entrega e retorna o resultado da nova tentativa
+func (h *WebhookHandler) ResendDelivery(c *gin.Context) {
+	id := c.Param("id")
+	delivery, err := h.webhookService.ResendDelivery(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, delivery)
+}
+
+func respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidEndpoint):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, contracts.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, contracts.ErrAlreadyExists), errors.Is(err, contracts.ErrInvalidState):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,33 @@
+-- IF NOT EXISTS: bancos criados pelo antigo AutoMigrate já têm as tabelas
+CREATE TABLE IF NOT EXISTS webhook_endpoints (
+    id VARCHAR(36) NOT NULL,
+    url VARCHAR(500) NOT NULL,
+    secret VARCHAR(100) NOT NULL,
+    event_types VARCHAR(500) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at DATETIME(3),
+    updated_at DATETIME(3),
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id VARCHAR(36) NOT NULL,
+    endpoint_id VARCHAR(36) NOT NULL,
+    event_id VARCHAR(36) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    attempts BIGINT NOT NULL DEFAULT 0,
+    response_code BIGINT NOT NULL DEFAULT 0,
+    response_body TEXT,
+    last_error TEXT,
+    next_attempt_at DATETIME(3),
+    delivered_at DATETIME(3),
+    created_at DATETIME(3),
+    updated_at DATETIME(3),
+    PRIMARY KEY (id),
+    INDEX idx_webhook_deliveries_endpoint_id (endpoint_id),
+    INDEX idx_webhook_deliveries_event_id (event_id),
+    INDEX idx_webhook_deliveries_due (status, next_attempt_at),
+    INDEX idx_webhook_deliveries_created_at (created_at)
+);
@@ -0,0 +1,4 @@
+-- Reserva das entregas pelo dispatcher que as envia
+ALTER TABLE webhook_deliveries ADD COLUMN locked_by VARCHAR(36);
+ALTER TABLE webhook_deliveries ADD COLUMN locked_until DATETIME(3);
+CREATE INDEX idx_webhook_deliveries_locked_by ON webhook_deliveries (locked_by);
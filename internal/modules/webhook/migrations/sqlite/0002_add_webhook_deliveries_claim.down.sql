@@ -0,0 +1,3 @@
+DROP INDEX idx_webhook_deliveries_locked_by;
+ALTER TABLE webhook_deliveries DROP COLUMN locked_until;
+ALTER TABLE webhook_deliveries DROP COLUMN locked_by;
@@ -0,0 +1,141 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	webhookDomain "go-modular-monolith/internal/modules/webhook/domain"
+	webhookHandler "go-modular-monolith/internal/modules/webhook/handler"
+	webhookRepository "go-modular-monolith/internal/modules/webhook/repository"
+	webhookService "go-modular-monolith/internal/modules/webhook/service"
+	"go-modular-monolith/internal/shared/config"
+	"go-modular-monolith/internal/shared/database"
+	"go-modular-monolith/pkg/container"
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/events"
+	"go-modular-monolith/pkg/module"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Module é o módulo de webhooks de saída
+type Module struct {
+	module.Base
+	cfg *config.Config
+}
+
+// NewModule cria o módulo de webhooks
+func NewModule(cfg *config.Config) *Module {
+	return &Module{cfg: cfg}
+}
+
+// Name retorna o nome do módulo
+func (m *Module) Name() string {
+	return "webhook"
+}
+
+// Register registra repositório, serviço, dispatcher e handler de webhooks
+func (m *Module) Register(c *container.Container) error {
+	cfg := m.cfg
+
+	// Webhook Repository (em memória com STORAGE=memory, senão MySQL/SQLite)
+	if m.cfg.Storage == config.StorageMemory {
+		c.RegisterSingleton("webhookRepository", func() interface{} {
+			return webhookRepository.NewMemoryWebhookRepository()
+		})
+	} else {
+		c.RegisterSingleton("webhookRepository", func() interface{} {
+			db := c.MustGet("database").(*gorm.DB)
+			return webhookRepository.NewMySQLWebhookRepository(db)
+		}, container.DependsOn("database"))
+	}
+
+	// Webhook Service
+	c.RegisterSingleton("webhookService", func() interface{} {
+		repo := c.MustGet("webhookRepository").(contracts.WebhookRepository)
+		logger := c.MustGet("logger").(contracts.Logger)
+
+		retryPolicy := events.DefaultRetryPolicy()
+		retryPolicy.MaxAttempts = cfg.WebhookMaxAttempts
+		retryPolicy.InitialBackoff = cfg.WebhookRetryBackoff
+		retryPolicy.MaxBackoff = cfg.WebhookRetryMaxWait
+
+		return webhookService.NewWebhookService(
+			repo,
+			webhookService.NewSender(cfg.WebhookTimeout),
+			retryPolicy,
+			logger,
+		)
+	}, container.DependsOn("webhookRepository", "logger"))
+
+	// Webhook Dispatcher (envio em background das entregas pendentes)
+	c.RegisterSingleton("webhookDispatcher", func() interface{} {
+		svc := c.MustGet("webhookService").(*webhookService.WebhookService)
+
+		dispatcher := webhookService.NewDispatcher(svc, webhookService.DispatcherConfig{
+			PollInterval: cfg.WebhookPollInterval,
+			LockTimeout:  cfg.WebhookLockTimeout,
+		})
+		c.Append(container.Hook{
+			Name: "webhookDispatcher",
+			OnStart: func(context.Context) error {
+				dispatcher.Start()
+				return nil
+			},
+			OnStop: dispatcher.Stop,
+		})
+		return dispatcher
+	}, container.DependsOn("webhookService"))
+
+	// Webhook Handler
+	c.RegisterSingleton("webhookHandler", func() interface{} {
+		webhookSvc := c.MustGet("webhookService").(contracts.WebhookService)
+		return webhookHandler.NewWebhookHandler(webhookSvc)
+	}, container.DependsOn("webhookService"))
+
+	return nil
+}
+
+// RegisterRoutes registra as rotas do módulo de webhooks
+func (m *Module) RegisterRoutes(router *gin.RouterGroup, c *container.Container) {
+	webhookHandler := c.MustGet("webhookHandler").(contracts.WebhookHandler)
+
+	webhookGroup := router.Group("/webhooks")
+	{
+		webhookGroup.POST("/endpoints", webhookHandler.CreateEndpoint)
+		webhookGroup.GET("/endpoints", webhookHandler.ListEndpoints)
+		webhookGroup.GET("/endpoints/:id", webhookHandler.GetEndpoint)
+		webhookGroup.PUT("/endpoints/:id", webhookHandler.UpdateEndpoint)
+		webhookGroup.DELETE("/endpoints/:id", webhookHandler.DeleteEndpoint)
+		webhookGroup.GET("/deliveries", webhookHandler.ListDeliveries)
+		webhookGroup.GET("/deliveries/:id", webhookHandler.GetDelivery)
+		webhookGroup.POST("/deliveries/:id/resend", webhookHandler.ResendDelivery)
+	}
+}
+
+// Subscribe enfileira entregas para os eventos suportados e constrói o
+// dispatcher, que registra o hook de início e parada do envio em background
+func (m *Module) Subscribe(c *container.Container, bus *events.EventBus) error {
+	webhookSvc := c.MustGet("webhookService").(contracts.WebhookService)
+
+	for _, eventType := range webhookDomain.SupportedEventTypes {
+		if _, err := bus.SubscribeWithOptions(eventType, webhookSvc.HandleEvent,
+			events.WithSubscriptionName("webhooks."+eventType),
+		); err != nil {
+			return fmt.Errorf("failed to subscribe webhooks to %s: %w", eventType, err)
+		}
+	}
+
+	c.MustGet("webhookDispatcher")
+	return nil
+}
+
+// Health verifica a conexão e as tabelas de webhooks
+func (m *Module) Health(ctx context.Context, c *container.Container) error {
+	if m.cfg.Storage == config.StorageMemory {
+		return nil // Sem banco a verificar
+	}
+	db := c.MustGet("database").(*gorm.DB)
+	return database.CheckTables(ctx, db, &database.WebhookEndpointModel{}, &database.WebhookDeliveryModel{})
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go-modular-monolith/pkg/contracts"
+)
+
+// memoryWebhookRepository implementa a interface WebhookRepository em memória
+type memoryWebhookRepository struct {
+	endpoints  map[string]*contracts.WebhookEndpoint
+	deliveries map[string]*contracts.WebhookDelivery
+	claims     map[string]time.Time // Fim da reserva de cada entrega em envio
+	mu         sync.RWMutex
+}
+
+// NewMemoryWebhookRepository cria um repositório de webhooks em memória
+func NewMemoryWebhookRepository() contracts.WebhookRepository {
+	return &memoryWebhookRepository{
+		endpoints:  make(map[string]*contracts.WebhookEndpoint),
+		deliveries: make(map[string]*contracts.WebhookDelivery),
+		claims:     make(map[string]time.Time),
+	}
+}
+
+func (r *memoryWebhookRepository) CreateEndpoint(ctx context.Context, endpoint *contracts.WebhookEndpoint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.endpoints[endpoint.ID]; exists {
+		return fmt.Errorf("webhook endpoint %w", contracts.ErrAlreadyExists)
+	}
+	r.endpoints[endpoint.ID] = copyEndpoint(endpoint)
+	return nil
+}
+
+func (r *memoryWebhookRepository) GetEndpoint(ctx context.Context, id string) (*contracts.WebhookEndpoint, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	endpoint, exists := r.endpoints[id]
+	if !exists {
+		return nil, fmt.Errorf("webhook endpoint %w", contracts.ErrNotFound)
+	}
+	return copyEndpoint(endpoint), nil
+}
+
+func (r *memoryWebhookRepository) UpdateEndpoint(ctx context.Context, endpoint *contracts.WebhookEndpoint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.endpoints[endpoint.ID]; !exists {
+		return fmt.Errorf("webhook endpoint %w", contracts.ErrNotFound)
+	}
+	r.endpoints[endpoint.ID] = copyEndpoint(endpoint)
+	return nil
+}
+
+func (r *memoryWebhookRepository) DeleteEndpoint(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.endpoints[id]; !exists {
+		return fmt.Errorf("webhook endpoint %w", contracts.ErrNotFound)
+	}
+	delete(r.endpoints, id)
+	return nil
+}
+
+func (r *memoryWebhookRepository) ListEndpoints(ctx context.Context) ([]*contracts.WebhookEndpoint, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	endpoints := make([]*contracts.WebhookEndpoint, 0, len(r.endpoints))
+	for _, endpoint := range r.endpoints {
+		endpoints = append(endpoints, copyEndpoint(endpoint))
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		return endpoints[i].CreatedAt.Before(endpoints[j].CreatedAt)
+	})
+	return endpoints, nil
+}
+
+func (r *memoryWebhookRepository) CreateDelivery(ctx context.Context, delivery *contracts.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.deliveries[delivery.ID]; exists {
+		return fmt.Errorf("webhook delivery %w", contracts.ErrAlreadyExists)
+	}
+	stored := *delivery
+	r.deliveries[delivery.ID] = &stored
+	return nil
+}
+
+func (r *memoryWebhookRepository) GetDelivery(ctx context.Context, id string) (*contracts.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	delivery, exists := r.deliveries[id]
+	if !exists {
+		return nil, fmt.Errorf("webhook delivery %w", contracts.ErrNotFound)
+	}
+	found := *delivery
+	return &found, nil
+}
+
+func (r *memoryWebhookRepository) UpdateDelivery(ctx context.Context, delivery *contracts.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.deliveries[delivery.ID]; !exists {
+		return fmt.Errorf("webhook delivery %w", contracts.ErrNotFound)
+	}
+	stored := *delivery
+	r.deliveries[delivery.ID] = &stored
+	delete(r.claims, delivery.ID)
+	return nil
+}
+
+func (r *memoryWebhookRepository) ListDeliveries(ctx context.Context, filter contracts.WebhookDeliveryFilter) ([]*contracts.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var deliveries []*contracts.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if filter.EndpointID != nil && delivery.EndpointID != *filter.EndpointID {
+			continue
+		}
+		if filter.Status != nil && delivery.Status != *filter.Status {
+			continue
+		}
+		found := *delivery
+		deliveries = append(deliveries, &found)
+	}
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].CreatedAt.After(deliveries[j].CreatedAt)
+	})
+
+	if filter.Offset > 0 {
+		if filter.Offset >= len(deliveries) {
+			return []*contracts.WebhookDelivery{}, nil
+		}
+		deliveries = deliveries[filter.Offset:]
+	}
+	if filter.Limit > 0 && filter.Limit < len(deliveries) {
+		deliveries = deliveries[:filter.Limit]
+	}
+
+	return deliveries, nil
+}
+
+func (r *memoryWebhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, limit int, lockFor time.Duration) ([]*contracts.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deliveries []*contracts.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if delivery.Status != contracts.WebhookDeliveryPending || delivery.NextAttemptAt == nil || delivery.NextAttemptAt.After(now) {
+			continue
+		}
+		if lockedUntil, claimed := r.claims[delivery.ID]; claimed && !lockedUntil.Before(now) {
+			continue
+		}
+		found := *delivery
+		deliveries = append(deliveries, &found)
+	}
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].NextAttemptAt.Before(*deliveries[j].NextAttemptAt)
+	})
+
+	if limit > 0 && limit < len(deliveries) {
+		deliveries = deliveries[:limit]
+	}
+	for _, delivery := range deliveries {
+		r.claims[delivery.ID] = now.Add(lockFor)
+	}
+	return deliveries, nil
+}
+
+func copyEndpoint(endpoint *contracts.WebhookEndpoint) *contracts.WebhookEndpoint {
+	copied := *endpoint
+	copied.EventTypes = append([]string(nil), endpoint.EventTypes...)
+	return &copied
+}
@@ -0,0 +1,248 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-modular-monolith/internal/shared/database"
+	"go-modular-monolith/pkg/contracts"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// mysqlWebhookRepository implementa a interface WebhookRepository usando MySQL/GORM
+type mysqlWebhookRepository struct {
+	db *gorm.DB
+}
+
+// NewMySQLWebhookRepository cria uma nova instância do repositório MySQL
+func NewMySQLWebhookRepository(db *gorm.DB) contracts.WebhookRepository {
+	return &mysqlWebhookRepository{
+		db: db,
+	}
+}
+
+// CreateEndpoint cria um novo endpoint no banco de dados
+func (r *mysqlWebhookRepository) CreateEndpoint(ctx context.Context, endpoint *contracts.WebhookEndpoint) error {
+	model := &database.WebhookEndpointModel{}
+	model.FromContract(endpoint)
+
+	if err := database.Conn(ctx, r.db).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to create webhook endpoint: %w", err)
+	}
+
+	return nil
+}
+
+// GetEndpoint busca um endpoint pelo ID
+func (r *mysqlWebhookRepository) GetEndpoint(ctx context.Context, id string) (*contracts.WebhookEndpoint, error) {
+	var model database.WebhookEndpointModel
+
+	if err := database.Conn(ctx, r.db).Where("id = ?", id).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("webhook endpoint %w", contracts.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get webhook endpoint: %w", err)
+	}
+
+	return model.ToContract(), nil
+}
+
+// UpdateEndpoint atualiza um endpoint existente
+func (r *mysqlWebhookRepository) UpdateEndpoint(ctx context.Context, endpoint *contracts.WebhookEndpoint) error {
+	model := &database.WebhookEndpointModel{}
+	model.FromContract(endpoint)
+
+	result := database.Conn(ctx, r.db).Model(&database.WebhookEndpointModel{}).Where("id = ?", endpoint.ID).Updates(map[string]interface{}{
+		"url":         model.URL,
+		"secret":      model.Secret,
+		"event_types": model.EventTypes,
+		"active":      model.Active,
+		"updated_at":  model.UpdatedAt,
+	})
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to update webhook endpoint: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("webhook endpoint %w", contracts.ErrNotFound)
+	}
+
+	return nil
+}
+
+// DeleteEndpoint remove um endpoint; o histórico de entregas é mantido
+func (r *mysqlWebhookRepository) DeleteEndpoint(ctx context.Context, id string) error {
+	result := database.Conn(ctx, r.db).Where("id = ?", id).Delete(&database.WebhookEndpointModel{})
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete webhook endpoint: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("webhook endpoint %w", contracts.ErrNotFound)
+	}
+
+	return nil
+}
+
+// ListEndpoints lista todos os endpoints
+func (r *mysqlWebhookRepository) ListEndpoints(ctx context.Context) ([]*contracts.WebhookEndpoint, error) {
+	var models []database.WebhookEndpointModel
+
+	if err := database.Conn(ctx, r.db).Order("created_at ASC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list webhook endpoints: %w", err)
+	}
+
+	endpoints := make([]*contracts.WebhookEndpoint, len(models))
+	for i := range models {
+		endpoints[i] = models[i].ToContract()
+	}
+
+	return endpoints, nil
+}
+
+// CreateDelivery registra uma nova entrega
+func (r *mysqlWebhookRepository) CreateDelivery(ctx context.Context, delivery *contracts.WebhookDelivery) error {
+	model := &database.WebhookDeliveryModel{}
+	model.FromContract(delivery)
+
+	if err := database.Conn(ctx, r.db).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+// GetDelivery busca uma entrega pelo ID
+func (r *mysqlWebhookRepository) GetDelivery(ctx context.Context, id string) (*contracts.WebhookDelivery, error) {
+	var model database.WebhookDeliveryModel
+
+	if err := database.Conn(ctx, r.db).Where("id = ?", id).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("webhook delivery %w", contracts.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	return model.ToContract(), nil
+}
+
+// UpdateDelivery grava o resultado de uma tentativa de entrega e libera a reserva
+func (r *mysqlWebhookRepository) UpdateDelivery(ctx context.Context, delivery *contracts.WebhookDelivery) error {
+	model := &database.WebhookDeliveryModel{}
+	model.FromContract(delivery)
+
+	result := database.Conn(ctx, r.db).Model(&database.WebhookDeliveryModel{}).Where("id = ?", delivery.ID).Updates(map[string]interface{}{
+		"status":          model.Status,
+		"attempts":        model.Attempts,
+		"response_code":   model.ResponseCode,
+		"response_body":   model.ResponseBody,
+		"last_error":      model.LastError,
+		"next_attempt_at": model.NextAttemptAt,
+		"delivered_at":    model.DeliveredAt,
+		"updated_at":      model.UpdatedAt,
+		"locked_by":       "",
+		"locked_until":    nil,
+	})
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("webhook delivery %w", contracts.ErrNotFound)
+	}
+
+	return nil
+}
+
+// ListDeliveries lista entregas com filtros e paginação, das mais recentes para as mais antigas
+func (r *mysqlWebhookRepository) ListDeliveries(ctx context.Context, filter contracts.WebhookDeliveryFilter) ([]*contracts.WebhookDelivery, error) {
+	query := database.Conn(ctx, r.db).Model(&database.WebhookDeliveryModel{})
+
+	if filter.EndpointID != nil {
+		query = query.Where("endpoint_id = ?", *filter.EndpointID)
+	}
+
+	if filter.Status != nil {
+		query = query.Where("status = ?", string(*filter.Status))
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	var models []database.WebhookDeliveryModel
+	if err := query.Order("created_at DESC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	deliveries := make([]*contracts.WebhookDelivery, len(models))
+	for i := range models {
+		deliveries[i] = models[i].ToContract()
+	}
+
+	return deliveries, nil
+}
+
+// dueForDelivery filtra as entregas pendentes vencidas e sem reserva válida
+func dueForDelivery(now time.Time) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("status = ? AND next_attempt_at <= ?", string(contracts.WebhookDeliveryPending), now).
+			Where("locked_until IS NULL OR locked_until < ?", now)
+	}
+}
+
+// ClaimDueDeliveries reserva as entregas vencidas com um UPDATE condicional:
+// com várias réplicas cada entrega é enviada por um só dispatcher
+func (r *mysqlWebhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, limit int, lockFor time.Duration) ([]*contracts.WebhookDelivery, error) {
+	var ids []string
+	err := database.Conn(ctx, r.db).Model(&database.WebhookDeliveryModel{}).
+		Scopes(dueForDelivery(now)).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due webhook deliveries: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	claim := uuid.New().String()
+	err = database.Conn(ctx, r.db).Model(&database.WebhookDeliveryModel{}).
+		Scopes(dueForDelivery(now)).
+		Where("id IN ?", ids).
+		UpdateColumns(map[string]interface{}{
+			"locked_by":    claim,
+			"locked_until": now.Add(lockFor),
+		}).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	// Só as linhas que este UPDATE reservou; as demais foram de outro dispatcher
+	var models []database.WebhookDeliveryModel
+	err = database.Conn(ctx, r.db).
+		Where("locked_by = ?", claim).
+		Order("next_attempt_at ASC").
+		Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch claimed webhook deliveries: %w", err)
+	}
+
+	deliveries := make([]*contracts.WebhookDelivery, len(models))
+	for i := range models {
+		deliveries[i] = models[i].ToContract()
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,87 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-modular-monolith/internal/modules/webhook"
+	"go-modular-monolith/internal/modules/webhook/repository"
+	"go-modular-monolith/internal/shared/database/databasetest"
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/migrate"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// forEachRepository roda o teste na implementação em memória e em cada banco
+func forEachRepository(t *testing.T, test func(t *testing.T, repo contracts.WebhookRepository)) {
+	t.Run("memory", func(t *testing.T) {
+		test(t, repository.NewMemoryWebhookRepository())
+	})
+
+	migrations := migrate.Source{Module: "webhook", FS: new(webhook.Module).Migrations()}
+	for name, url := range databasetest.URLs() {
+		t.Run(name, func(t *testing.T) {
+			test(t, repository.NewMySQLWebhookRepository(databasetest.Open(t, url, migrations)))
+		})
+	}
+}
+
+func TestMissingRecordsReturnNotFound(t *testing.T) {
+	forEachRepository(t, func(t *testing.T, repo contracts.WebhookRepository) {
+		ctx := context.Background()
+
+		_, err := repo.GetEndpoint(ctx, "missing")
+		assert.ErrorIs(t, err, contracts.ErrNotFound)
+		assert.ErrorIs(t, repo.UpdateEndpoint(ctx, &contracts.WebhookEndpoint{ID: "missing"}), contracts.ErrNotFound)
+		assert.ErrorIs(t, repo.DeleteEndpoint(ctx, "missing"), contracts.ErrNotFound)
+
+		_, err = repo.GetDelivery(ctx, "missing")
+		assert.ErrorIs(t, err, contracts.ErrNotFound)
+		assert.ErrorIs(t, repo.UpdateDelivery(ctx, &contracts.WebhookDelivery{ID: "missing"}), contracts.ErrNotFound)
+	})
+}
+
+func TestClaimDueDeliveriesSkipsClaimedDeliveries(t *testing.T) {
+	forEachRepository(t, func(t *testing.T, repo contracts.WebhookRepository) {
+		ctx := context.Background()
+		now := time.Now().Truncate(time.Millisecond)
+		later := now.Add(time.Hour)
+
+		for id, nextAttempt := range map[string]time.Time{"due": now.Add(-time.Second), "scheduled": later} {
+			nextAttempt := nextAttempt
+			require.NoError(t, repo.CreateDelivery(ctx, &contracts.WebhookDelivery{
+				ID:            id,
+				EndpointID:    "endpoint-1",
+				EventID:       "event-" + id,
+				EventType:     "order.created",
+				Payload:       []byte(`{}`),
+				Status:        contracts.WebhookDeliveryPending,
+				NextAttemptAt: &nextAttempt,
+				CreatedAt:     now,
+				UpdatedAt:     now,
+			}))
+		}
+
+		claimed, err := repo.ClaimDueDeliveries(ctx, now, 10, time.Minute)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+		assert.Equal(t, "due", claimed[0].ID)
+
+		again, err := repo.ClaimDueDeliveries(ctx, now, 10, time.Minute)
+		require.NoError(t, err)
+		assert.Empty(t, again, "a claimed delivery is not handed to another dispatcher")
+
+		expired, err := repo.ClaimDueDeliveries(ctx, now.Add(2*time.Minute), 10, time.Minute)
+		require.NoError(t, err)
+		require.Len(t, expired, 1, "an expired claim frees the delivery")
+
+		// A gravação do resultado libera a reserva
+		require.NoError(t, repo.UpdateDelivery(ctx, expired[0]))
+		released, err := repo.ClaimDueDeliveries(ctx, now.Add(2*time.Minute), 10, time.Minute)
+		require.NoError(t, err)
+		assert.Len(t, released, 1)
+	})
+}
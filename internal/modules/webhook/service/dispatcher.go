@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-modular-monolith/pkg/contracts"
+)
+
+// DispatcherConfig contém as configurações do dispatcher de webhooks
+type DispatcherConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	LockTimeout  time.Duration // Reserva de um lote ao dispatcher que o envia
+}
+
+// Dispatcher envia em background as entregas pendentes cuja tentativa venceu.
+// Cada lote é reservado antes do envio, então várias réplicas podem rodar o
+// dispatcher sem enviar a mesma entrega duas vezes.
+type Dispatcher struct {
+	service *WebhookService
+	config  DispatcherConfig
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewDispatcher cria uma nova instância do dispatcher
+func NewDispatcher(service *WebhookService, config DispatcherConfig) *Dispatcher {
+	if config.PollInterval <= 0 {
+		config.PollInterval = time.Second
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 50
+	}
+	if config.LockTimeout <= 0 {
+		config.LockTimeout = time.Minute
+	}
+
+	return &Dispatcher{
+		service: service,
+		config:  config,
+	}
+}
+
+// Start inicia o loop de envio em background
+func (d *Dispatcher) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	d.cancel = cancel
+	d.done = make(chan struct{})
+
+	go func() {
+		defer close(d.done)
+
+		ticker := time.NewTicker(d.config.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			if _, err := d.ProcessDue(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				d.service.logger.Error("Webhook dispatcher failed", contracts.Field{Key: "error", Value: err})
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop interrompe o loop e aguarda o lote em andamento terminar
+func (d *Dispatcher) Stop(ctx context.Context) error {
+	if d.cancel == nil {
+		return nil
+	}
+
+	d.cancel()
+	select {
+	case <-d.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ProcessDue reserva e envia um lote de entregas vencidas e retorna quantas
+// foram processadas
+func (d *Dispatcher) ProcessDue(ctx context.Context) (int, error) {
+	deliveries, err := d.service.repo.ClaimDueDeliveries(ctx, time.Now(), d.config.BatchSize, d.config.LockTimeout)
+	if err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			break
+		}
+
+		endpoint, err := d.service.repo.GetEndpoint(ctx, delivery.EndpointID)
+		if err != nil {
+			// Endpoint removido: a entrega não tem mais destino
+			delivery.Status = contracts.WebhookDeliveryFailed
+			delivery.LastError = err.Error()
+			delivery.NextAttemptAt = nil
+			delivery.UpdatedAt = time.Now()
+		} else {
+			d.service.attempt(ctx, endpoint, delivery, true)
+		}
+
+		if err := d.service.repo.UpdateDelivery(ctx, delivery); err != nil {
+			d.service.logger.Error("Failed to record webhook delivery", contracts.Field{Key: "error", Value: err})
+			continue
+		}
+		processed++
+	}
+
+	return processed, ctx.Err()
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-modular-monolith/internal/modules/webhook/domain"
+	"go-modular-monolith/pkg/contracts"
+)
+
+// maxResponseBody limita quanto da resposta do parceiro é guardado no log de entregas
+const maxResponseBody = 2048
+
+// SendResult é o resultado de uma requisição ao endpoint
+type SendResult struct {
+	StatusCode int
+	Body       string
+}
+
+// Sender envia entregas assinadas via HTTP POST
+type Sender struct {
+	client *http.Client
+}
+
+// NewSender cria um sender com o timeout informado por requisição
+func NewSender(timeout time.Duration) *Sender {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &Sender{
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Send faz o POST do payload da entrega. Respostas fora da faixa 2xx retornam erro
+// junto com o resultado, para que o código e o corpo sejam registrados.
+func (s *Sender) Send(ctx context.Context, endpoint *contracts.WebhookEndpoint, delivery *contracts.WebhookDelivery) (*SendResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+
+	timestamp := time.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "go-modular-monolith-webhooks/1.0")
+	req.Header.Set(domain.TimestampHeader, strconv.FormatInt(timestamp.Unix(), 10))
+	req.Header.Set(domain.SignatureHeader, domain.Sign(endpoint.Secret, timestamp, delivery.Payload))
+	req.Header.Set(domain.EventTypeHeader, delivery.EventType)
+	req.Header.Set(domain.EventIDHeader, delivery.EventID)
+	req.Header.Set(domain.DeliveryIDHeader, delivery.ID)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	result := &SendResult{StatusCode: resp.StatusCode, Body: string(body)}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return result, fmt.Errorf("webhook endpoint responded with status %d", resp.StatusCode)
+	}
+
+	return result, nil
+}
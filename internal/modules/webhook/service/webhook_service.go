@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go-modular-monolith/internal/modules/webhook/domain"
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/events"
+
+	"github.com/google/uuid"
+)
+
+// WebhookService implementa a lógica de negócio do módulo de webhooks
+type WebhookService struct {
+	repo   contracts.WebhookRepository
+	sender *Sender
+	retry  events.RetryPolicy
+	logger contracts.Logger
+}
+
+// NewWebhookService cria uma nova instância do serviço de webhooks.
+// A política de retry define as tentativas automáticas de cada entrega.
+func NewWebhookService(
+	repo contracts.WebhookRepository,
+	sender *Sender,
+	retry events.RetryPolicy,
+	logger contracts.Logger,
+) *WebhookService {
+	return &WebhookService{
+		repo:   repo,
+		sender: sender,
+		retry:  retry,
+		logger: logger,
+	}
+}
+
+// webhookPayload é o corpo enviado ao parceiro
+type webhookPayload struct {
+	ID            string      `json:"id"`
+	Type          string      `json:"type"`
+	Version       int         `json:"version"`
+	CorrelationID string      `json:"correlation_id,omitempty"`
+	Timestamp     time.Time   `json:"timestamp"`
+	Data          interface{} `json:"data"`
+}
+
+// CreateEndpoint cadastra um endpoint. O secret só é retornado nesta chamada.
+func (s *WebhookService) CreateEndpoint(ctx context.Context, req contracts.CreateWebhookEndpointRequest) (*contracts.WebhookEndpoint, error) {
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	endpoint, err := domain.NewEndpoint(uuid.New().String(), req.URL, req.Secret, req.EventTypes, active)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateEndpoint(ctx, &endpoint.WebhookEndpoint); err != nil {
+		return nil, err
+	}
+
+	return &endpoint.WebhookEndpoint, nil
+}
+
+// GetEndpoint busca um endpoint pelo ID
+func (s *WebhookService) GetEndpoint(ctx context.Context, id string) (*contracts.WebhookEndpoint, error) {
+	endpoint, err := s.repo.GetEndpoint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return withoutSecret(endpoint), nil
+}
+
+// UpdateEndpoint atualiza URL, secret, tipos inscritos ou o estado do endpoint
+func (s *WebhookService) UpdateEndpoint(ctx context.Context, id string, req contracts.UpdateWebhookEndpointRequest) (*contracts.WebhookEndpoint, error) {
+	endpoint, err := s.repo.GetEndpoint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		if err := domain.ValidateURL(*req.URL); err != nil {
+			return nil, err
+		}
+		endpoint.URL = *req.URL
+	}
+
+	if req.Secret != nil {
+		if *req.Secret == "" {
+			return nil, fmt.Errorf("%w: secret cannot be empty", domain.ErrInvalidEndpoint)
+		}
+		endpoint.Secret = *req.Secret
+	}
+
+	if req.EventTypes != nil {
+		if err := domain.ValidateEventTypes(req.EventTypes); err != nil {
+			return nil, err
+		}
+		endpoint.EventTypes = req.EventTypes
+	}
+
+	if req.Active != nil {
+		endpoint.Active = *req.Active
+	}
+
+	endpoint.UpdatedAt = time.Now()
+	if err := s.repo.UpdateEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+
+	return withoutSecret(endpoint), nil
+}
+
+// DeleteEndpoint remove um endpoint
+func (s *WebhookService) DeleteEndpoint(ctx context.Context, id string) error {
+	return s.repo.DeleteEndpoint(ctx, id)
+}
+
+// ListEndpoints lista os endpoints cadastrados
+func (s *WebhookService) ListEndpoints(ctx context.Context) ([]*contracts.WebhookEndpoint, error) {
+	endpoints, err := s.repo.ListEndpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, endpoint := range endpoints {
+		endpoints[i] = withoutSecret(endpoint)
+	}
+	return endpoints, nil
+}
+
+// GetDelivery busca uma entrega do log pelo ID
+func (s *WebhookService) GetDelivery(ctx context.Context, id string) (*contracts.WebhookDelivery, error) {
+	return s.repo.GetDelivery(ctx, id)
+}
+
+// ListDeliveries lista o log de entregas
+func (s *WebhookService) ListDeliveries(ctx context.Context, filter contracts.WebhookDeliveryFilter) ([]*contracts.WebhookDelivery, error) {
+	return s.repo.ListDeliveries(ctx, filter)
+}
+
+// HandleEvent cria uma entrega pendente para cada endpoint inscrito no tipo do evento.
+// O envio é feito pelo Dispatcher, fora da goroutine do publish.
+func (s *WebhookService) HandleEvent(ctx context.Context, event contracts.Event) error {
+	endpoints, err := s.repo.ListEndpoints(ctx)
+	if err != nil {
+		return err
+	}
+
+	var payload []byte
+	for _, endpoint := range endpoints {
+		if !domain.Subscribes(endpoint, event.Type) {
+			continue
+		}
+
+		if payload == nil {
+			payload, err = json.Marshal(webhookPayload{
+				ID:            event.ID,
+				Type:          event.Type,
+				Version:       event.Version,
+				CorrelationID: event.CorrelationID,
+				Timestamp:     event.Timestamp,
+				Data:          event.Payload,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to encode webhook payload: %w", err)
+			}
+		}
+
+		now := time.Now()
+		delivery := &contracts.WebhookDelivery{
+			ID:            uuid.New().String(),
+			EndpointID:    endpoint.ID,
+			EventID:       event.ID,
+			EventType:     event.Type,
+			Payload:       payload,
+			Status:        contracts.WebhookDeliveryPending,
+			NextAttemptAt: &now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+
+		if err := s.repo.CreateDelivery(ctx, delivery); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ResendDelivery reenvia manualmente uma entrega já concluída (enviada ou
+// falha) e retorna o resultado da tentativa. Falhas não agendam novas
+// tentativas. Uma entrega pendente é recusada: ela ainda está com o Dispatcher.
+func (s *WebhookService) ResendDelivery(ctx context.Context, id string) (*contracts.WebhookDelivery, error) {
+	delivery, err := s.repo.GetDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if delivery.Status == contracts.WebhookDeliveryPending {
+		return nil, fmt.Errorf("webhook delivery %s is pending and cannot be resent: %w", id, contracts.ErrInvalidState)
+	}
+
+	endpoint, err := s.repo.GetEndpoint(ctx, delivery.EndpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.attempt(ctx, endpoint, delivery, false)
+
+	if err := s.repo.UpdateDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// attempt envia a entrega e registra o resultado. Com scheduleRetry, uma falha
+// agenda a próxima tentativa até esgotar a política de retry.
+func (s *WebhookService) attempt(ctx context.Context, endpoint *contracts.WebhookEndpoint, delivery *contracts.WebhookDelivery, scheduleRetry bool) {
+	result, err := s.sender.Send(ctx, endpoint, delivery)
+
+	now := time.Now()
+	delivery.Attempts++
+	delivery.UpdatedAt = now
+	delivery.ResponseCode = 0
+	delivery.ResponseBody = ""
+	if result != nil {
+		delivery.ResponseCode = result.StatusCode
+		delivery.ResponseBody = result.Body
+	}
+
+	if err == nil {
+		delivery.Status = contracts.WebhookDeliverySucceeded
+		delivery.LastError = ""
+		delivery.NextAttemptAt = nil
+		delivery.DeliveredAt = &now
+		return
+	}
+
+	delivery.LastError = err.Error()
+	if scheduleRetry && delivery.Attempts < s.retry.MaxAttempts {
+		next := now.Add(s.retry.Backoff(delivery.Attempts))
+		delivery.Status = contracts.WebhookDeliveryPending
+		delivery.NextAttemptAt = &next
+		return
+	}
+
+	delivery.Status = contracts.WebhookDeliveryFailed
+	delivery.NextAttemptAt = nil
+	s.logger.Warn("Webhook delivery failed",
+		contracts.Field{Key: "delivery_id", Value: delivery.ID},
+		contracts.Field{Key: "endpoint_id", Value: endpoint.ID},
+		contracts.Field{Key: "attempts", Value: delivery.Attempts},
+		contracts.Field{Key: "error", Value: err.Error()},
+	)
+}
+
+// withoutSecret evita expor o secret fora da criação do endpoint
+func withoutSecret(endpoint *contracts.WebhookEndpoint) *contracts.WebhookEndpoint {
+	endpoint.Secret = ""
+	return endpoint
+}
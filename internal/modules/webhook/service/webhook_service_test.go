@@ -0,0 +1,212 @@
+package service_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"go-modular-monolith/internal/modules/webhook/domain"
+	"go-modular-monolith/internal/modules/webhook/repository"
+	"go-modular-monolith/internal/modules/webhook/service"
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/events"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// receiver é um parceiro de teste que responde com os status configurados
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+
+	status := http.StatusOK
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+	w.WriteHeader(status)
+	w.Write([]byte(http.StatusText(status)))
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...contracts.Field)           {}
+func (nopLogger) Info(string, ...contracts.Field)            {}
+func (nopLogger) Warn(string, ...contracts.Field)            {}
+func (nopLogger) Error(string, ...contracts.Field)           {}
+func (nopLogger) Fatal(string, ...contracts.Field)           {}
+func (l nopLogger) With(...contracts.Field) contracts.Logger { return l }
+
+func setup(t *testing.T, statuses ...int) (*service.WebhookService, *service.Dispatcher, *receiver, *contracts.WebhookEndpoint) {
+	t.Helper()
+
+	recv := &receiver{statuses: statuses}
+	server := httptest.NewServer(recv)
+	t.Cleanup(server.Close)
+
+	svc := service.NewWebhookService(
+		repository.NewMemoryWebhookRepository(),
+		service.NewSender(time.Second),
+		events.RetryPolicy{MaxAttempts: 3}, // Sem backoff: a próxima tentativa vence imediatamente
+		nopLogger{},
+	)
+	dispatcher := service.NewDispatcher(svc, service.DispatcherConfig{})
+
+	endpoint, err := svc.CreateEndpoint(context.Background(), contracts.CreateWebhookEndpointRequest{
+		URL:        server.URL,
+		EventTypes: []string{events.OrderCreatedEventType},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, endpoint.Secret)
+
+	return svc, dispatcher, recv, endpoint
+}
+
+func orderCreated() contracts.Event {
+	return contracts.Event{
+		ID:        "event-1",
+		Type:      events.OrderCreatedEventType,
+		Version:   1,
+		Payload:   contracts.OrderCreatedEvent{OrderID: "order-1", UserID: "user-1", Total: 10},
+		Timestamp: time.Now(),
+	}
+}
+
+func TestDeliverySignedWithHMAC(t *testing.T) {
+	ctx := context.Background()
+	svc, dispatcher, recv, endpoint := setup(t)
+
+	require.NoError(t, svc.HandleEvent(ctx, orderCreated()))
+
+	processed, err := dispatcher.ProcessDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+
+	require.Len(t, recv.requests, 1)
+	req := recv.requests[0]
+	assert.Equal(t, events.OrderCreatedEventType, req.Header.Get(domain.EventTypeHeader))
+	assert.Equal(t, "event-1", req.Header.Get(domain.EventIDHeader))
+	assert.True(t, domain.VerifySignature(endpoint.Secret, req.Header.Get(domain.SignatureHeader), req.Header.Get(domain.TimestampHeader), recv.bodies[0]))
+	assert.False(t, domain.VerifySignature("wrong-secret", req.Header.Get(domain.SignatureHeader), req.Header.Get(domain.TimestampHeader), recv.bodies[0]))
+
+	deliveries, err := svc.ListDeliveries(ctx, contracts.WebhookDeliveryFilter{})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, contracts.WebhookDeliverySucceeded, deliveries[0].Status)
+	assert.Equal(t, http.StatusOK, deliveries[0].ResponseCode)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+	assert.NotNil(t, deliveries[0].DeliveredAt)
+}
+
+func TestDeliveryRetriedUntilSuccess(t *testing.T) {
+	ctx := context.Background()
+	svc, dispatcher, recv, _ := setup(t, http.StatusInternalServerError, http.StatusBadGateway)
+
+	require.NoError(t, svc.HandleEvent(ctx, orderCreated()))
+
+	for i := 0; i < 3; i++ {
+		_, err := dispatcher.ProcessDue(ctx)
+		require.NoError(t, err)
+	}
+
+	assert.Len(t, recv.requests, 3)
+
+	deliveries, err := svc.ListDeliveries(ctx, contracts.WebhookDeliveryFilter{})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, contracts.WebhookDeliverySucceeded, deliveries[0].Status)
+	assert.Equal(t, 3, deliveries[0].Attempts)
+}
+
+func TestDeliveryFailsAfterMaxAttemptsAndCanBeResent(t *testing.T) {
+	ctx := context.Background()
+	svc, dispatcher, recv, _ := setup(t, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusServiceUnavailable)
+
+	require.NoError(t, svc.HandleEvent(ctx, orderCreated()))
+
+	for i := 0; i < 5; i++ {
+		_, err := dispatcher.ProcessDue(ctx)
+		require.NoError(t, err)
+	}
+
+	assert.Len(t, recv.requests, 3)
+
+	failed := contracts.WebhookDeliveryFailed
+	deliveries, err := svc.ListDeliveries(ctx, contracts.WebhookDeliveryFilter{Status: &failed})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, http.StatusServiceUnavailable, deliveries[0].ResponseCode)
+	assert.Equal(t, 3, deliveries[0].Attempts)
+
+	resent, err := svc.ResendDelivery(ctx, deliveries[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, contracts.WebhookDeliverySucceeded, resent.Status)
+	assert.Equal(t, 4, resent.Attempts)
+	assert.Len(t, recv.requests, 4)
+	assert.Equal(t, recv.bodies[0], recv.bodies[3])
+}
+
+func TestResendRefusesPendingDelivery(t *testing.T) {
+	ctx := context.Background()
+	svc, _, recv, _ := setup(t)
+
+	require.NoError(t, svc.HandleEvent(ctx, orderCreated()))
+	deliveries, err := svc.ListDeliveries(ctx, contracts.WebhookDeliveryFilter{})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+
+	_, err = svc.ResendDelivery(ctx, deliveries[0].ID)
+	assert.ErrorIs(t, err, contracts.ErrInvalidState)
+	assert.Empty(t, recv.requests)
+
+	delivery, err := svc.GetDelivery(ctx, deliveries[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, contracts.WebhookDeliveryPending, delivery.Status)
+	assert.NotNil(t, delivery.NextAttemptAt, "the dispatcher schedule is kept")
+	assert.Zero(t, delivery.Attempts)
+}
+
+func TestEventsWithoutSubscribedEndpointsAreIgnored(t *testing.T) {
+	ctx := context.Background()
+	svc, dispatcher, recv, endpoint := setup(t)
+
+	event := orderCreated()
+	event.Type = events.ProductStockUpdatedEventType
+	require.NoError(t, svc.HandleEvent(ctx, event))
+
+	inactive := false
+	_, err := svc.UpdateEndpoint(ctx, endpoint.ID, contracts.UpdateWebhookEndpointRequest{Active: &inactive})
+	require.NoError(t, err)
+	require.NoError(t, svc.HandleEvent(ctx, orderCreated()))
+
+	processed, err := dispatcher.ProcessDue(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, processed)
+	assert.Empty(t, recv.requests)
+}
+
+func TestCreateEndpointRejectsUnsupportedEventType(t *testing.T) {
+	svc, _, _, _ := setup(t)
+
+	_, err := svc.CreateEndpoint(context.Background(), contracts.CreateWebhookEndpointRequest{
+		URL:        "https://partner.example.com/hooks",
+		EventTypes: []string{events.UserCreatedEventType},
+	})
+	assert.ErrorIs(t, err, domain.ErrInvalidEndpoint)
+}
@@ -0,0 +1,98 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/events"
+)
+
+// auditor implementa contracts.Auditor gravando em um contracts.AuditLog
+type auditor struct {
+	log contracts.AuditLog
+}
+
+// NewAuditor cria um auditor. O autor vem de contracts.ActorFromContext e o ID
+// da requisição do correlation ID do contexto.
+func NewAuditor(log contracts.AuditLog) contracts.Auditor {
+	return &auditor{log: log}
+}
+
+// Record grava a alteração na trilha, na transação do contexto
+func (a *auditor) Record(ctx context.Context, entityType, entityID, action string, before, after interface{}) error {
+	changes, err := Diff(before, after)
+	if err != nil {
+		return fmt.Errorf("failed to diff %s %s: %w", entityType, entityID, err)
+	}
+
+	entry := &contracts.AuditEntry{
+		Actor:      contracts.ActorFromContext(ctx),
+		RequestID:  events.CorrelationIDFromContext(ctx),
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		Changes:    changes,
+		OccurredAt: time.Now(),
+	}
+
+	if err := a.log.Append(ctx, entry); err != nil {
+		return fmt.Errorf("failed to record audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// Diff compara a serialização JSON de before e after campo a campo e retorna
+// só os campos que mudaram. Campos fora do JSON (json:"-", como a senha)
+// nunca entram na trilha. nil equivale a um objeto sem campos, e campos null
+// a campos ausentes.
+func Diff(before, after interface{}) (map[string]contracts.AuditChange, error) {
+	beforeFields, err := fields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := fields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]contracts.AuditChange)
+	for name, value := range beforeFields {
+		if !bytes.Equal(value, afterFields[name]) {
+			changes[name] = contracts.AuditChange{Before: value, After: afterFields[name]}
+		}
+	}
+	for name, value := range afterFields {
+		if _, exists := beforeFields[name]; !exists {
+			changes[name] = contracts.AuditChange{After: value}
+		}
+	}
+
+	return changes, nil
+}
+
+func fields(entity interface{}) (map[string]json.RawMessage, error) {
+	if entity == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for name, value := range fields {
+		if string(value) == "null" {
+			delete(fields, name)
+		}
+	}
+	return fields, nil
+}
@@ -0,0 +1,90 @@
+package audit
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-modular-monolith/pkg/contracts"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Tamanho de página da consulta à trilha
+const (
+	defaultListLimit = 50
+	maxListLimit     = 500
+)
+
+// Handler expõe a consulta à trilha de auditoria
+type Handler struct {
+	log contracts.AuditLog
+}
+
+// NewHandler cria uma nova instância do handler
+func NewHandler(log contracts.AuditLog) *Handler {
+	return &Handler{log: log}
+}
+
+// ListEntries lista a trilha do registro mais recente para o mais antigo,
+// filtrando por entity_type, entity_id, actor, action, request_id, since e until
+func (h *Handler) ListEntries(c *gin.Context) {
+	query := contracts.AuditQuery{
+		EntityType: c.Query("entity_type"),
+		EntityID:   c.Query("entity_id"),
+		Actor:      c.Query("actor"),
+		Action:     c.Query("action"),
+		RequestID:  c.Query("request_id"),
+		Limit:      defaultListLimit,
+	}
+
+	if sinceStr := c.Query("since"); sinceStr != "" {
+		since, err := time.Parse(time.RFC3339, sinceStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "since must be an RFC3339 timestamp"})
+			return
+		}
+		query.Since = &since
+	}
+
+	if untilStr := c.Query("until"); untilStr != "" {
+		until, err := time.Parse(time.RFC3339, untilStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "until must be an RFC3339 timestamp"})
+			return
+		}
+		query.Until = &until
+	}
+
+	// Sem limite o AuditLog devolveria a tabela inteira; acima do máximo a
+	// página é reduzida a maxListLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+			return
+		}
+		query.Limit = min(limit, maxListLimit)
+	}
+
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "offset must be a non-negative integer"})
+			return
+		}
+		query.Offset = offset
+	}
+
+	entries, err := h.log.List(c.Request.Context(), query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"entries": entries,
+		"limit":   query.Limit,
+		"offset":  query.Offset,
+	})
+}
@@ -0,0 +1,97 @@
+package audit
+
+import (
+	"context"
+	"sync"
+
+	"go-modular-monolith/internal/shared/database"
+	"go-modular-monolith/pkg/contracts"
+)
+
+// MemoryAuditLog implementa contracts.AuditLog em memória
+type MemoryAuditLog struct {
+	entries []*contracts.AuditEntry
+	lastID  uint64
+	mu      sync.RWMutex
+}
+
+// NewMemoryAuditLog cria uma trilha de auditoria em memória
+func NewMemoryAuditLog() *MemoryAuditLog {
+	return &MemoryAuditLog{}
+}
+
+// Append grava o registro; um rollback da transação em memória o descarta
+func (l *MemoryAuditLog) Append(ctx context.Context, entry *contracts.AuditEntry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.lastID++
+	entry.ID = l.lastID
+
+	stored := *entry
+	l.entries = append(l.entries, &stored)
+	database.OnRollback(ctx, l.discard(stored.ID))
+
+	return nil
+}
+
+// discard remove um registro cuja transação foi desfeita
+func (l *MemoryAuditLog) discard(id uint64) func() {
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		for i, entry := range l.entries {
+			if entry.ID == id {
+				l.entries = append(l.entries[:i], l.entries[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+func (l *MemoryAuditLog) List(ctx context.Context, query contracts.AuditQuery) ([]*contracts.AuditEntry, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	var result []*contracts.AuditEntry
+	skipped := 0
+	for i := len(l.entries) - 1; i >= 0; i-- {
+		entry := l.entries[i]
+		if !matches(entry, query) {
+			continue
+		}
+		if skipped < query.Offset {
+			skipped++
+			continue
+		}
+
+		found := *entry
+		result = append(result, &found)
+		if query.Limit > 0 && len(result) == query.Limit {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+func matches(entry *contracts.AuditEntry, query contracts.AuditQuery) bool {
+	switch {
+	case query.EntityType != "" && entry.EntityType != query.EntityType:
+		return false
+	case query.EntityID != "" && entry.EntityID != query.EntityID:
+		return false
+	case query.Actor != "" && entry.Actor != query.Actor:
+		return false
+	case query.Action != "" && entry.Action != query.Action:
+		return false
+	case query.RequestID != "" && entry.RequestID != query.RequestID:
+		return false
+	case query.Since != nil && entry.OccurredAt.Before(*query.Since):
+		return false
+	case query.Until != nil && !entry.OccurredAt.Before(*query.Until):
+		return false
+	}
+	return true
+}
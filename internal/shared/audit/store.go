@@ -0,0 +1,114 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go-modular-monolith/internal/shared/database"
+	"go-modular-monolith/pkg/contracts"
+
+	"gorm.io/gorm"
+)
+
+// gormAuditLog implementa contracts.AuditLog usando GORM
+type gormAuditLog struct {
+	db *gorm.DB
+}
+
+// NewGormAuditLog cria uma trilha de auditoria persistida na tabela audit_log
+func NewGormAuditLog(db *gorm.DB) contracts.AuditLog {
+	return &gormAuditLog{db: db}
+}
+
+// Append grava o registro na transação do contexto, se houver
+func (l *gormAuditLog) Append(ctx context.Context, entry *contracts.AuditEntry) error {
+	changes, err := json.Marshal(entry.Changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit changes: %w", err)
+	}
+
+	model := &database.AuditLogModel{
+		Actor:      entry.Actor,
+		RequestID:  entry.RequestID,
+		EntityType: entry.EntityType,
+		EntityID:   entry.EntityID,
+		Action:     entry.Action,
+		Changes:    string(changes),
+		OccurredAt: entry.OccurredAt,
+	}
+
+	if err := database.Conn(ctx, l.db).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to append audit entry: %w", err)
+	}
+
+	entry.ID = model.ID
+	return nil
+}
+
+// List lê os registros do mais recente para o mais antigo aplicando os filtros
+func (l *gormAuditLog) List(ctx context.Context, query contracts.AuditQuery) ([]*contracts.AuditEntry, error) {
+	db := database.Conn(ctx, l.db)
+
+	if query.EntityType != "" {
+		db = db.Where("entity_type = ?", query.EntityType)
+	}
+	if query.EntityID != "" {
+		db = db.Where("entity_id = ?", query.EntityID)
+	}
+	if query.Actor != "" {
+		db = db.Where("actor = ?", query.Actor)
+	}
+	if query.Action != "" {
+		db = db.Where("action = ?", query.Action)
+	}
+	if query.RequestID != "" {
+		db = db.Where("request_id = ?", query.RequestID)
+	}
+	if query.Since != nil {
+		db = db.Where("occurred_at >= ?", *query.Since)
+	}
+	if query.Until != nil {
+		db = db.Where("occurred_at < ?", *query.Until)
+	}
+
+	if query.Limit > 0 {
+		db = db.Limit(query.Limit)
+	}
+	if query.Offset > 0 {
+		db = db.Offset(query.Offset)
+	}
+
+	var models []database.AuditLogModel
+	if err := db.Order("id DESC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list audit log: %w", err)
+	}
+
+	entries := make([]*contracts.AuditEntry, len(models))
+	for i := range models {
+		entry, err := toAuditEntry(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		entries[i] = entry
+	}
+	return entries, nil
+}
+
+func toAuditEntry(model *database.AuditLogModel) (*contracts.AuditEntry, error) {
+	var changes map[string]contracts.AuditChange
+	if err := json.Unmarshal([]byte(model.Changes), &changes); err != nil {
+		return nil, fmt.Errorf("failed to decode audit entry %d: %w", model.ID, err)
+	}
+
+	return &contracts.AuditEntry{
+		ID:         model.ID,
+		Actor:      model.Actor,
+		RequestID:  model.RequestID,
+		EntityType: model.EntityType,
+		EntityID:   model.EntityID,
+		Action:     model.Action,
+		Changes:    changes,
+		OccurredAt: model.OccurredAt,
+	}, nil
+}
@@ -1,12 +1,25 @@
 package config
 
 import (
+	"errors"
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
+// Valores de STORAGE
+const (
+	StorageDatabase = "database" // MySQL ou SQLite, conforme DATABASE_URL
+	StorageMemory   = "memory"   // Repositórios em memória, sem banco
+)
+
+// defaultJWTSecret só serve para desenvolvimento
+const defaultJWTSecret = "your-secret-key-change-in-production"
+
 type Config struct {
 	Port        string
 	DatabaseURL string
@@ -17,6 +30,55 @@ type Config struct {
 	DBDatabase  string
 	JWTSecret   string
 	Environment string
+
+	// Validade dos tokens emitidos pelo tokenGenerator
+	JWTAccessTokenTTL  time.Duration
+	JWTRefreshTokenTTL time.Duration
+
+	// Onde os módulos guardam os dados: database | memory
+	Storage string
+
+	// Módulos desligados (nomes separados por vírgula em MODULES_DISABLED)
+	DisabledModules []string
+
+	// Aplica migrações pendentes na inicialização; sem isso o servidor não sobe
+	MigrationsAutoApply bool
+
+	// Event bus
+	EventBusDriver       string // memory | disk
+	EventBusDiskDir      string
+	EventBusDiskFsync    bool
+	EventBusMode         string // sync | async
+	EventBusWorkers      int
+	EventBusQueueSize    int
+	EventBusBackpressure string // block | drop | error
+	EventBusMaxAttempts  int    // Tentativas por handler antes da dead letter
+	EventBusRetryBackoff time.Duration
+	EventBusRetryMaxWait time.Duration
+	EventBusTimeout      time.Duration // Prazo por execução de handler; zero desativa
+
+	// Outbox
+	OutboxPollInterval time.Duration
+	OutboxBatchSize    int
+	OutboxMaxAttempts  int // Tentativas por evento antes do status failed
+	OutboxRetryBackoff time.Duration
+	OutboxRetryMaxWait time.Duration
+	OutboxLockTimeout  time.Duration // Reserva de um lote ao relay que o entrega
+
+	// Sagas
+	SagaLease time.Duration // Reserva de uma instância ao processo que a executa
+
+	// Webhooks de saída
+	WebhookMaxAttempts  int
+	WebhookRetryBackoff time.Duration
+	WebhookRetryMaxWait time.Duration
+	WebhookTimeout      time.Duration // Timeout de cada requisição ao parceiro
+	WebhookPollInterval time.Duration
+	WebhookLockTimeout  time.Duration // Reserva de um lote ao dispatcher que o envia
+
+	// Server-Sent Events
+	SSEHeartbeat  time.Duration
+	SSEBufferSize int // Mensagens mantidas para retomada via Last-Event-ID
 }
 
 func LoadConfig() (*Config, error) {
@@ -25,7 +87,7 @@ func LoadConfig() (*Config, error) {
 		log.Println("No .env file found, using environment variables or defaults")
 	}
 
-	return &Config{
+	cfg := &Config{
 		Port:        getEnv("PORT", "8080"),
 		DatabaseURL: getEnv("DATABASE_URL", ""),
 		DBHost:      getEnv("DB_HOST", "localhost"),
@@ -33,9 +95,54 @@ func LoadConfig() (*Config, error) {
 		DBUsername:  getEnv("DB_USERNAME", "root"),
 		DBPassword:  getEnv("DB_PASSWORD", "123456"),
 		DBDatabase:  getEnv("DB_DATABASE", "app_db"),
-		JWTSecret:   getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
+		JWTSecret:   getEnv("JWT_SECRET", defaultJWTSecret),
 		Environment: getEnv("ENVIRONMENT", "development"),
-	}, nil
+		Storage:     getEnv("STORAGE", StorageDatabase),
+
+		JWTAccessTokenTTL:  getEnvAsDuration("JWT_ACCESS_TOKEN_TTL", 15*time.Minute),
+		JWTRefreshTokenTTL: getEnvAsDuration("JWT_REFRESH_TOKEN_TTL", 7*24*time.Hour),
+
+		DisabledModules:     getEnvAsList("MODULES_DISABLED"),
+		MigrationsAutoApply: getEnvAsBool("MIGRATIONS_AUTO_APPLY", false),
+
+		EventBusDriver:       getEnv("EVENTBUS_DRIVER", "memory"),
+		EventBusDiskDir:      getEnv("EVENTBUS_DISK_DIR", "./data/eventbus"),
+		EventBusDiskFsync:    getEnvAsBool("EVENTBUS_DISK_FSYNC", false),
+		EventBusMode:         getEnv("EVENTBUS_MODE", "sync"),
+		EventBusWorkers:      getEnvAsInt("EVENTBUS_WORKERS", 4),
+		EventBusQueueSize:    getEnvAsInt("EVENTBUS_QUEUE_SIZE", 100),
+		EventBusBackpressure: getEnv("EVENTBUS_BACKPRESSURE", "block"),
+		EventBusMaxAttempts:  getEnvAsInt("EVENTBUS_MAX_ATTEMPTS", 3),
+		EventBusRetryBackoff: getEnvAsDuration("EVENTBUS_RETRY_BACKOFF", 100*time.Millisecond),
+		EventBusRetryMaxWait: getEnvAsDuration("EVENTBUS_RETRY_MAX_BACKOFF", 5*time.Second),
+		EventBusTimeout:      getEnvAsDuration("EVENTBUS_HANDLER_TIMEOUT", 30*time.Second),
+
+		OutboxPollInterval: getEnvAsDuration("OUTBOX_POLL_INTERVAL", time.Second),
+		OutboxBatchSize:    getEnvAsInt("OUTBOX_BATCH_SIZE", 100),
+		OutboxMaxAttempts:  getEnvAsInt("OUTBOX_MAX_ATTEMPTS", 10),
+		OutboxRetryBackoff: getEnvAsDuration("OUTBOX_RETRY_BACKOFF", time.Second),
+		OutboxRetryMaxWait: getEnvAsDuration("OUTBOX_RETRY_MAX_BACKOFF", 5*time.Minute),
+		OutboxLockTimeout:  getEnvAsDuration("OUTBOX_LOCK_TIMEOUT", time.Minute),
+
+		SagaLease: getEnvAsDuration("SAGA_LEASE_DURATION", 30*time.Second),
+
+		WebhookMaxAttempts:  getEnvAsInt("WEBHOOK_MAX_ATTEMPTS", 5),
+		WebhookRetryBackoff: getEnvAsDuration("WEBHOOK_RETRY_BACKOFF", 10*time.Second),
+		WebhookRetryMaxWait: getEnvAsDuration("WEBHOOK_RETRY_MAX_BACKOFF", time.Hour),
+		WebhookTimeout:      getEnvAsDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+		WebhookPollInterval: getEnvAsDuration("WEBHOOK_POLL_INTERVAL", time.Second),
+		WebhookLockTimeout:  getEnvAsDuration("WEBHOOK_LOCK_TIMEOUT", time.Minute),
+
+		SSEHeartbeat:  getEnvAsDuration("SSE_HEARTBEAT_INTERVAL", 15*time.Second),
+		SSEBufferSize: getEnvAsInt("SSE_BUFFER_SIZE", 1000),
+	}
+
+	// Com a chave padrão qualquer um assina tokens válidos
+	if cfg.Environment == "production" && (cfg.JWTSecret == defaultJWTSecret || cfg.JWTSecret == "") {
+		return nil, errors.New("JWT_SECRET must be set in production")
+	}
+
+	return cfg, nil
 }
 
 func getEnv(key, defaultValue string) string {
@@ -44,3 +151,43 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvAsList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func getEnvAsInt(key string, defaultValue int) int {
+	if value, exists := os.LookupEnv(key); exists {
+		if parsed, err := strconv.Atoi(value); err == nil {
+			return parsed
+		}
+		log.Printf("Invalid integer for %s, using default %d", key, defaultValue)
+	}
+	return defaultValue
+}
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value, exists := os.LookupEnv(key); exists {
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			return parsed
+		}
+		log.Printf("Invalid boolean for %s, using default %t", key, defaultValue)
+	}
+	return defaultValue
+}
+
+func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, exists := os.LookupEnv(key); exists {
+		if parsed, err := time.ParseDuration(value); err == nil {
+			return parsed
+		}
+		log.Printf("Invalid duration for %s, using default %s", key, defaultValue)
+	}
+	return defaultValue
+}
@@ -0,0 +1,21 @@
+package database
+
+import "time"
+
+// AuditLogModel representa a estrutura da tabela audit_log no banco.
+// A tabela é append-only: registros nunca são atualizados ou removidos.
+type AuditLogModel struct {
+	ID         uint64    `gorm:"primaryKey;autoIncrement"`
+	Actor      string    `gorm:"size:100;not null;index"`
+	RequestID  string    `gorm:"size:64;index"`
+	EntityType string    `gorm:"size:50;not null"`
+	EntityID   string    `gorm:"size:36;not null"`
+	Action     string    `gorm:"size:20;not null"`
+	Changes    string    `gorm:"type:text;not null"`
+	OccurredAt time.Time `gorm:"not null;index"`
+}
+
+// TableName especifica o nome da tabela
+func (AuditLogModel) TableName() string {
+	return "audit_log"
+}
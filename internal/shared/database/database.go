@@ -4,32 +4,56 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"go-modular-monolith/pkg/contracts"
 
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
 // DatabaseConfig contém as configurações de conexão do banco
 type DatabaseConfig struct {
+	URL      string // DATABASE_URL; quando definida, tem precedência sobre os demais campos
 	Host     string
 	Port     string
 	Username string
 	Password string
 	Database string
+
+	// Réplicas de leitura, no formato de DATABASE_URL (DATABASE_REPLICA_URLS)
+	Replicas []string
+
+	// Pools do primário e de cada réplica; campos zerados usam o padrão do driver
+	Pool        PoolConfig
+	ReplicaPool PoolConfig
 }
 
 // GetDefaultConfig retorna a configuração do banco usando variáveis de ambiente ou padrões
 func GetDefaultConfig() *DatabaseConfig {
 	return &DatabaseConfig{
-		Host:     getEnvOrDefault("DB_HOST", "localhost"),
-		Port:     getEnvOrDefault("DB_PORT", "3306"),
-		Username: getEnvOrDefault("DB_USERNAME", "root"),
-		Password: getEnvOrDefault("DB_PASSWORD", "123456"),
-		Database: getEnvOrDefault("DB_DATABASE", "app_db"),
+		URL:         os.Getenv("DATABASE_URL"),
+		Host:        getEnvOrDefault("DB_HOST", "localhost"),
+		Port:        getEnvOrDefault("DB_PORT", "3306"),
+		Username:    getEnvOrDefault("DB_USERNAME", "root"),
+		Password:    getEnvOrDefault("DB_PASSWORD", "123456"),
+		Database:    getEnvOrDefault("DB_DATABASE", "app_db"),
+		Replicas:    getEnvAsList("DATABASE_REPLICA_URLS"),
+		Pool:        getPoolFromEnv("DB"),
+		ReplicaPool: getPoolFromEnv("DB_REPLICA"),
+	}
+}
+
+// getPoolFromEnv lê PREFIX_MAX_OPEN_CONNS, PREFIX_MAX_IDLE_CONNS,
+// PREFIX_CONN_MAX_LIFETIME e PREFIX_CONN_MAX_IDLE_TIME
+func getPoolFromEnv(prefix string) PoolConfig {
+	return PoolConfig{
+		MaxOpenConns:    getEnvAsInt(prefix + "_MAX_OPEN_CONNS"),
+		MaxIdleConns:    getEnvAsInt(prefix + "_MAX_IDLE_CONNS"),
+		ConnMaxLifetime: getEnvAsDuration(prefix + "_CONN_MAX_LIFETIME"),
+		ConnMaxIdleTime: getEnvAsDuration(prefix + "_CONN_MAX_IDLE_TIME"),
 	}
 }
 
@@ -41,18 +65,55 @@ func getEnvOrDefault(key, defaultValue string) string {
 	return defaultValue
 }
 
-// Connect estabelece conexão com o banco MySQL
+// getEnvAsList obtém uma lista separada por vírgulas
+func getEnvAsList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getEnvAsInt obtém um inteiro; ausente ou inválido retorna zero (padrão do driver)
+func getEnvAsInt(key string) int {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return 0
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Invalid integer for %s, using driver default", key)
+	}
+	return parsed
+}
+
+// getEnvAsDuration obtém uma duração; ausente ou inválida retorna zero (padrão do driver)
+func getEnvAsDuration(key string) time.Duration {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return 0
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Invalid duration for %s, using driver default", key)
+	}
+	return parsed
+}
+
+// Connect estabelece conexão com o banco escolhido pela URL (mysql:// ou
+// sqlite://) ou, sem URL, com o MySQL dos campos individuais
 func Connect(config *DatabaseConfig) (*gorm.DB, error) {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		config.Username,
-		config.Password,
-		config.Host,
-		config.Port,
-		config.Database,
-	)
-
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
+	target, err := parseConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := gorm.Open(target.dialector(), &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Info),
+		// Chave duplicada vira gorm.ErrDuplicatedKey em qualquer dialeto
+		TranslateError: true,
 		NowFunc: func() time.Time {
 			return time.Now().Local()
 		},
@@ -62,29 +123,42 @@ func Connect(config *DatabaseConfig) (*gorm.DB, error) {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	// Configurar connection pool
+	if len(config.Replicas) > 0 {
+		if err := registerReplicas(db, target, config); err != nil {
+			return nil, err
+		}
+	}
+
+	// Configurar connection pool do primário
 	sqlDB, err := db.DB()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
 	}
 
-	// Configurações do pool de conexões
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(100)
-	sqlDB.SetConnMaxLifetime(time.Hour)
+	pool := config.Pool.withDefaults(defaultPool(target))
+	if target.inMemory {
+		// O banco em memória existe em uma única conexão, qualquer que seja a configuração
+		pool = defaultPool(target)
+	}
+	sqlDB.SetMaxOpenConns(pool.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(pool.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
 
-	log.Println("Successfully connected to MySQL database")
+	log.Printf("Successfully connected to %s database with %d read replicas", target.driver, len(config.Replicas))
 	return db, nil
 }
 
 // UserModel representa a estrutura da tabela users no banco
 type UserModel struct {
-	ID        string    `gorm:"primaryKey;size:36"`
-	Username  string    `gorm:"uniqueIndex;size:50;not null"`
-	Email     string    `gorm:"uniqueIndex;size:100;not null"`
-	Password  string    `gorm:"size:255;not null"`
-	CreatedAt time.Time `gorm:"autoCreateTime"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+	ID        string         `gorm:"primaryKey;size:36"`
+	Username  string         `gorm:"uniqueIndex;size:50;not null"`
+	Email     string         `gorm:"uniqueIndex;size:100;not null"`
+	Password  string         `gorm:"size:255;not null"`
+	Version   int            `gorm:"not null;default:1"`
+	CreatedAt time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt gorm.DeletedAt `gorm:"index"` // Soft delete
 }
 
 // TableName especifica o nome da tabela
@@ -99,8 +173,10 @@ func (u *UserModel) ToContract() *contracts.User {
 		Username:  u.Username,
 		Email:     u.Email,
 		Password:  u.Password,
+		Version:   u.Version,
 		CreatedAt: u.CreatedAt,
 		UpdatedAt: u.UpdatedAt,
+		DeletedAt: deletedAtToContract(u.DeletedAt),
 	}
 }
 
@@ -110,20 +186,24 @@ func (u *UserModel) FromContract(user *contracts.User) {
 	u.Username = user.Username
 	u.Email = user.Email
 	u.Password = user.Password
+	u.Version = user.Version
 	u.CreatedAt = user.CreatedAt
 	u.UpdatedAt = user.UpdatedAt
+	u.DeletedAt = deletedAtFromContract(user.DeletedAt)
 }
 
 // ProductModel representa a estrutura da tabela products no banco
 type ProductModel struct {
-	ID          string    `gorm:"primaryKey;size:36"`
-	Name        string    `gorm:"size:100;not null"`
-	Description string    `gorm:"size:500"`
-	Price       float64   `gorm:"type:decimal(10,2);not null"`
-	Stock       int       `gorm:"default:0;not null"`
-	CategoryID  string    `gorm:"size:36;not null"`
-	CreatedAt   time.Time `gorm:"autoCreateTime"`
-	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+	ID          string         `gorm:"primaryKey;size:36"`
+	Name        string         `gorm:"size:100;not null"`
+	Description string         `gorm:"size:500"`
+	Price       float64        `gorm:"type:decimal(10,2);not null"`
+	Stock       int            `gorm:"default:0;not null"`
+	CategoryID  string         `gorm:"size:36;not null"`
+	Version     int            `gorm:"not null;default:1"`
+	CreatedAt   time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt   gorm.DeletedAt `gorm:"index"` // Soft delete
 }
 
 // TableName especifica o nome da tabela
@@ -140,8 +220,10 @@ func (p *ProductModel) ToContract() *contracts.Product {
 		Price:       p.Price,
 		Stock:       p.Stock,
 		CategoryID:  p.CategoryID,
+		Version:     p.Version,
 		CreatedAt:   p.CreatedAt,
 		UpdatedAt:   p.UpdatedAt,
+		DeletedAt:   deletedAtToContract(p.DeletedAt),
 	}
 }
 
@@ -153,8 +235,10 @@ func (p *ProductModel) FromContract(product *contracts.Product) {
 	p.Price = product.Price
 	p.Stock = product.Stock
 	p.CategoryID = product.CategoryID
+	p.Version = product.Version
 	p.CreatedAt = product.CreatedAt
 	p.UpdatedAt = product.UpdatedAt
+	p.DeletedAt = deletedAtFromContract(product.DeletedAt)
 }
 
 // OrderModel representa a estrutura da tabela orders no banco
@@ -164,8 +248,10 @@ type OrderModel struct {
 	Status    string           `gorm:"size:20;not null"`
 	Total     float64          `gorm:"type:decimal(10,2);not null"`
 	Items     []OrderItemModel `gorm:"foreignKey:OrderID"`
+	Version   int              `gorm:"not null;default:1"`
 	CreatedAt time.Time        `gorm:"autoCreateTime"`
 	UpdatedAt time.Time        `gorm:"autoUpdateTime"`
+	DeletedAt gorm.DeletedAt   `gorm:"index"` // Soft delete; os itens são mantidos
 }
 
 // TableName especifica o nome da tabela
@@ -204,8 +290,10 @@ func (o *OrderModel) ToContract() *contracts.Order {
 		Items:     items,
 		Status:    contracts.OrderStatus(o.Status),
 		Total:     o.Total,
+		Version:   o.Version,
 		CreatedAt: o.CreatedAt,
 		UpdatedAt: o.UpdatedAt,
+		DeletedAt: deletedAtToContract(o.DeletedAt),
 	}
 }
 
@@ -215,8 +303,10 @@ func (o *OrderModel) FromContract(order *contracts.Order) {
 	o.UserID = order.UserID
 	o.Status = string(order.Status)
 	o.Total = order.Total
+	o.Version = order.Version
 	o.CreatedAt = order.CreatedAt
 	o.UpdatedAt = order.UpdatedAt
+	o.DeletedAt = deletedAtFromContract(order.DeletedAt)
 
 	// Converter items
 	o.Items = make([]OrderItemModel, len(order.Items))
@@ -230,173 +320,16 @@ func (o *OrderModel) FromContract(order *contracts.Order) {
 	}
 }
 
-// AutoMigrate executa as migrações necessárias
-func AutoMigrate(db *gorm.DB) error {
-	err := db.AutoMigrate(
-		&UserModel{},
-		&ProductModel{},
-		&OrderModel{},
-		&OrderItemModel{},
-	)
-	if err != nil {
-		return fmt.Errorf("failed to run auto migration: %w", err)
-	}
-
-	log.Println("Database migration completed successfully")
-
-	// Executar seeds
-	if err := SeedDatabase(db); err != nil {
-		log.Printf("Warning: Failed to seed database: %v", err)
-		// Não falhar se o seed der erro, apenas avisar
-	}
-
-	return nil
-}
-
-// SeedDatabase popula o banco com dados iniciais
-func SeedDatabase(db *gorm.DB) error {
-	log.Println("Starting database seeding...")
-
-	// Verificar se já existem produtos para evitar duplicação
-	var count int64
-	if err := db.Model(&ProductModel{}).Count(&count).Error; err != nil {
-		return fmt.Errorf("failed to count products: %w", err)
-	}
-
-	if count > 0 {
-		log.Printf("Database already has %d products, skipping seed", count)
+func deletedAtToContract(deletedAt gorm.DeletedAt) *time.Time {
+	if !deletedAt.Valid {
 		return nil
 	}
+	return &deletedAt.Time
+}
 
-	// Seeds de produtos
-	products := []ProductModel{
-		{
-			ID:          "prod-001",
-			Name:        "iPhone 15 Pro Max",
-			Description: "Apple iPhone 15 Pro Max 256GB - Titânio Natural com câmera profissional de 48MP",
-			Price:       8999.99,
-			Stock:       15,
-			CategoryID:  "electronics",
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
-		},
-		{
-			ID:          "prod-002",
-			Name:        "MacBook Air M2",
-			Description: "MacBook Air 13\" com chip M2, 8GB RAM, 256GB SSD - Cor Meia-noite",
-			Price:       12999.99,
-			Stock:       8,
-			CategoryID:  "computers",
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
-		},
-		{
-			ID:          "prod-003",
-			Name:        "Samsung Galaxy S24 Ultra",
-			Description: "Samsung Galaxy S24 Ultra 512GB - Preto com S Pen incluída e câmera de 200MP",
-			Price:       7499.99,
-			Stock:       12,
-			CategoryID:  "electronics",
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
-		},
-		{
-			ID:          "prod-004",
-			Name:        "Dell XPS 13",
-			Description: "Notebook Dell XPS 13 Intel Core i7, 16GB RAM, 512GB SSD, Tela InfinityEdge",
-			Price:       9999.99,
-			Stock:       6,
-			CategoryID:  "computers",
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
-		},
-		{
-			ID:          "prod-005",
-			Name:        "AirPods Pro (3ª geração)",
-			Description: "Apple AirPods Pro com cancelamento ativo de ruído e case de carregamento MagSafe",
-			Price:       2499.99,
-			Stock:       25,
-			CategoryID:  "accessories",
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
-		},
-		{
-			ID:          "prod-006",
-			Name:        "Sony WH-1000XM5",
-			Description: "Fone de ouvido Sony WH-1000XM5 com cancelamento de ruído premium e 30h de bateria",
-			Price:       1899.99,
-			Stock:       18,
-			CategoryID:  "accessories",
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
-		},
-		{
-			ID:          "prod-007",
-			Name:        "iPad Air (5ª geração)",
-			Description: "iPad Air 10.9\" com chip M1, 256GB, Wi-Fi + Cellular - Azul-céu",
-			Price:       6499.99,
-			Stock:       10,
-			CategoryID:  "tablets",
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
-		},
-		{
-			ID:          "prod-008",
-			Name:        "Nintendo Switch OLED",
-			Description: "Console Nintendo Switch modelo OLED com tela de 7\" e 64GB de armazenamento",
-			Price:       2799.99,
-			Stock:       20,
-			CategoryID:  "gaming",
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
-		},
-		{
-			ID:          "prod-009",
-			Name:        "PlayStation 5",
-			Description: "Console Sony PlayStation 5 com SSD ultrarrápido e controle DualSense",
-			Price:       4999.99,
-			Stock:       5,
-			CategoryID:  "gaming",
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
-		},
-		{
-			ID:          "prod-010",
-			Name:        "Microsoft Surface Pro 9",
-			Description: "Surface Pro 9 Intel i7, 16GB RAM, 512GB SSD com teclado Type Cover incluso",
-			Price:       11999.99,
-			Stock:       7,
-			CategoryID:  "tablets",
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
-		},
-		{
-			ID:          "prod-011",
-			Name:        "LG OLED C3 55\"",
-			Description: "Smart TV LG OLED C3 55\" 4K com webOS, Dolby Vision IQ e Gaming Hub",
-			Price:       6999.99,
-			Stock:       4,
-			CategoryID:  "tv",
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
-		},
-		{
-			ID:          "prod-012",
-			Name:        "Apple Watch Series 9",
-			Description: "Apple Watch Series 9 GPS 45mm caixa de alumínio com pulseira esportiva",
-			Price:       3999.99,
-			Stock:       14,
-			CategoryID:  "wearables",
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
-		},
-	}
-
-	// Inserir produtos em lote
-	if err := db.CreateInBatches(products, 100).Error; err != nil {
-		return fmt.Errorf("failed to seed products: %w", err)
+func deletedAtFromContract(deletedAt *time.Time) gorm.DeletedAt {
+	if deletedAt == nil {
+		return gorm.DeletedAt{}
 	}
-
-	log.Printf("Successfully seeded %d products", len(products))
-	return nil
+	return gorm.DeletedAt{Time: *deletedAt, Valid: true}
 }
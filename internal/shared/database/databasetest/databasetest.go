@@ -0,0 +1,55 @@
+// Package databasetest abre bancos migrados para os testes dos repositórios GORM
+package databasetest
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"go-modular-monolith/internal/shared/database"
+	"go-modular-monolith/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+// URLs retorna os bancos em que os testes rodam, por nome: sempre um SQLite em
+// memória e, se TEST_MYSQL_URL estiver definida, o MySQL indicado por ela
+func URLs() map[string]string {
+	urls := map[string]string{"sqlite": "sqlite://:memory:"}
+	if url := os.Getenv("TEST_MYSQL_URL"); url != "" {
+		urls["mysql"] = url
+	}
+	return urls
+}
+
+// Open conecta em url e aplica as migrações dos módulos informados no dialeto
+// da conexão. A conexão é fechada ao fim do teste. Um SQLite em memória é novo
+// a cada chamada; no MySQL os dados de execuções anteriores permanecem.
+func Open(t *testing.T, url string, sources ...migrate.Source) *gorm.DB {
+	t.Helper()
+
+	db, err := database.Connect(&database.DatabaseConfig{URL: url})
+	if err != nil {
+		t.Fatalf("failed to connect to %s: %v", url, err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("failed to get underlying sql.DB: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	for i, source := range sources {
+		if sources[i], err = source.Dialect(db.Dialector.Name()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	migrations, err := migrate.Load(sources...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrate.New(sqlDB, migrations).Up(context.Background()); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+
+	return db
+}
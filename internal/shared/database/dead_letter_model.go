@@ -0,0 +1,23 @@
+package database
+
+import "time"
+
+// DeadLetterModel representa a estrutura da tabela dead_letters no banco
+type DeadLetterModel struct {
+	ID             string `gorm:"primaryKey;size:36"`
+	EventEnvelope  `gorm:"embedded"`
+	EventType      string    `gorm:"size:100;not null;index"`
+	Subscription   string    `gorm:"size:150;not null;index"`
+	Payload        string    `gorm:"type:text;not null"`
+	EventTimestamp time.Time `gorm:"not null"`
+	Attempts       int       `gorm:"not null"`
+	LastError      string    `gorm:"type:text"`
+	FailedAt       time.Time `gorm:"not null;index"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName especifica o nome da tabela
+func (DeadLetterModel) TableName() string {
+	return "dead_letters"
+}
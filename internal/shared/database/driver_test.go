@@ -0,0 +1,72 @@
+package database
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfigSelectsDriverFromURL(t *testing.T) {
+	tests := []struct {
+		url      string
+		driver   string
+		dsn      string
+		inMemory bool
+	}{
+		{
+			url:    "mysql://root:secret@db:3307/app_db",
+			driver: DriverMySQL,
+			dsn:    "root:secret@tcp(db:3307)/app_db?charset=utf8mb4&clientFoundRows=true&loc=Local&parseTime=True",
+		},
+		{
+			url:    "mysql://root@localhost/app_db?charset=latin1",
+			driver: DriverMySQL,
+			dsn:    "root:@tcp(localhost:3306)/app_db?charset=latin1&clientFoundRows=true&loc=Local&parseTime=True",
+		},
+		{
+			url:    "sqlite://./data/app.db",
+			driver: DriverSQLite,
+			dsn:    "./data/app.db?_busy_timeout=5000&_foreign_keys=on&_journal_mode=WAL",
+		},
+		{
+			url:    "sqlite:///var/lib/app.db?_busy_timeout=100",
+			driver: DriverSQLite,
+			dsn:    "/var/lib/app.db?_busy_timeout=100&_foreign_keys=on&_journal_mode=WAL",
+		},
+		{
+			url:      "sqlite://:memory:",
+			driver:   DriverSQLite,
+			dsn:      ":memory:?_busy_timeout=5000&_foreign_keys=on",
+			inMemory: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			target, err := parseConfig(&DatabaseConfig{URL: tt.url})
+			require.NoError(t, err)
+			assert.Equal(t, tt.driver, target.driver)
+			assert.Equal(t, tt.dsn, target.dsn)
+			assert.Equal(t, tt.inMemory, target.inMemory)
+		})
+	}
+}
+
+func TestParseConfigWithoutURLUsesMySQLFields(t *testing.T) {
+	target, err := parseConfig(&DatabaseConfig{
+		Host: "localhost", Port: "3306", Username: "root", Password: "123456", Database: "app_db",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, DriverMySQL, target.driver)
+	assert.Equal(t, "root:123456@tcp(localhost:3306)/app_db?charset=utf8mb4&clientFoundRows=true&loc=Local&parseTime=True", target.dsn)
+}
+
+func TestParseConfigRejectsUnknownDriver(t *testing.T) {
+	_, err := parseConfig(&DatabaseConfig{URL: "postgres://localhost/app_db"})
+	assert.True(t, errors.Is(err, ErrUnsupportedDriver))
+
+	_, err = parseConfig(&DatabaseConfig{URL: "localhost:3306"})
+	assert.Error(t, err)
+}
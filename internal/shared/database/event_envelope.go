@@ -0,0 +1,35 @@
+package database
+
+import "go-modular-monolith/pkg/events"
+
+// EventEnvelope agrupa os metadados do envelope persistidos junto ao payload
+// nas tabelas de eventos (outbox, dead letters e event log)
+type EventEnvelope struct {
+	EventID       string `gorm:"size:36;index"`
+	EventVersion  int    `gorm:"not null;default:1"`
+	EventSource   string `gorm:"size:50"`
+	CorrelationID string `gorm:"size:64;index"`
+	CausationID   string `gorm:"size:36"`
+}
+
+// NewEventEnvelope converte os metadados do evento para o formato persistido
+func NewEventEnvelope(metadata events.Metadata) EventEnvelope {
+	return EventEnvelope{
+		EventID:       metadata.EventID,
+		EventVersion:  metadata.Version,
+		EventSource:   metadata.Source,
+		CorrelationID: metadata.CorrelationID,
+		CausationID:   metadata.CausationID,
+	}
+}
+
+// Metadata converte o envelope persistido para os metadados do evento
+func (e EventEnvelope) Metadata() events.Metadata {
+	return events.Metadata{
+		EventID:       e.EventID,
+		Version:       e.EventVersion,
+		Source:        e.EventSource,
+		CorrelationID: e.CorrelationID,
+		CausationID:   e.CausationID,
+	}
+}
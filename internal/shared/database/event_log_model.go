@@ -0,0 +1,19 @@
+package database
+
+import "time"
+
+// EventLogModel representa a estrutura da tabela event_log no banco.
+// A tabela é append-only: registros nunca são atualizados ou removidos.
+type EventLogModel struct {
+	Sequence      uint64 `gorm:"primaryKey;autoIncrement"`
+	EventEnvelope `gorm:"embedded"`
+	EventType     string    `gorm:"size:100;not null;index"`
+	Payload       string    `gorm:"type:text;not null"`
+	OccurredAt    time.Time `gorm:"not null;index"`
+	RecordedAt    time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName especifica o nome da tabela
+func (EventLogModel) TableName() string {
+	return "event_log"
+}
@@ -0,0 +1,182 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"go-modular-monolith/pkg/contracts"
+)
+
+// memoryTx é a transação dos repositórios em memória: guarda como desfazer
+// cada alteração e o que executar após o commit. Não há isolamento: outras
+// requisições veem as alterações antes do commit.
+type memoryTx struct {
+	parent *memoryTx // Definido quando aninhada
+
+	mu       sync.Mutex
+	undo     []func()
+	onCommit []func()
+}
+
+type memoryTxContextKey struct{}
+
+func memoryTxFromContext(ctx context.Context) (*memoryTx, bool) {
+	tx, ok := ctx.Value(memoryTxContextKey{}).(*memoryTx)
+	return tx, ok
+}
+
+// OnRollback registra como desfazer uma alteração de um repositório em memória.
+// Sem transação no contexto a alteração é definitiva e fn é descartada.
+func OnRollback(ctx context.Context, fn func()) {
+	if tx, ok := memoryTxFromContext(ctx); ok {
+		tx.mu.Lock()
+		tx.undo = append(tx.undo, fn)
+		tx.mu.Unlock()
+	}
+}
+
+// AfterCommit executa fn após o commit da transação em memória do contexto,
+// ou imediatamente se não houver transação. Em um rollback fn é descartada.
+func AfterCommit(ctx context.Context, fn func()) {
+	tx, ok := memoryTxFromContext(ctx)
+	if !ok {
+		fn()
+		return
+	}
+	tx.mu.Lock()
+	tx.onCommit = append(tx.onCommit, fn)
+	tx.mu.Unlock()
+}
+
+// commit entrega o trabalho à transação externa ou, na raiz, executa os efeitos pós-commit
+func (t *memoryTx) commit() {
+	t.mu.Lock()
+	undo, onCommit := t.undo, t.onCommit
+	t.undo, t.onCommit = nil, nil
+	t.mu.Unlock()
+
+	if t.parent != nil {
+		t.parent.mu.Lock()
+		t.parent.undo = append(t.parent.undo, undo...)
+		t.parent.onCommit = append(t.parent.onCommit, onCommit...)
+		t.parent.mu.Unlock()
+		return
+	}
+
+	for _, fn := range onCommit {
+		fn()
+	}
+}
+
+// rollback desfaz as alterações em ordem inversa
+func (t *memoryTx) rollback() {
+	t.mu.Lock()
+	undo := t.undo
+	t.undo, t.onCommit = nil, nil
+	t.mu.Unlock()
+
+	for i := len(undo) - 1; i >= 0; i-- {
+		undo[i]()
+	}
+}
+
+// memoryTransactionManager implementa contracts.TransactionManager para os repositórios em memória
+type memoryTransactionManager struct{}
+
+// NewMemoryTransactionManager cria um gerenciador de transações sem banco:
+// um erro em fn desfaz as alterações registradas com OnRollback
+func NewMemoryTransactionManager() contracts.TransactionManager {
+	return memoryTransactionManager{}
+}
+
+// WithinTransaction executa fn em uma transação em memória, reaproveitando a do contexto se existir
+func (memoryTransactionManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := memoryTxFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	tx := &memoryTx{}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, memoryTxContextKey{}, tx)); err != nil {
+		tx.rollback()
+		return err
+	}
+	tx.commit()
+	return nil
+}
+
+// memoryDatabase implementa contracts.Database sobre os repositórios em memória
+type memoryDatabase struct {
+	users    contracts.UserRepository
+	products contracts.ProductRepository
+	orders   contracts.OrderRepository
+}
+
+// NewMemoryDatabase cria a unidade de trabalho dos repositórios em memória.
+// Repositórios nil (módulo desligado) são entregues como nil pela transação.
+func NewMemoryDatabase(users contracts.UserRepository, products contracts.ProductRepository, orders contracts.OrderRepository) contracts.Database {
+	return &memoryDatabase{users: users, products: products, orders: orders}
+}
+
+// BeginTx abre uma transação em memória, aninhada na do contexto se existir
+func (d *memoryDatabase) BeginTx(ctx context.Context) (contracts.Transaction, error) {
+	parent, _ := memoryTxFromContext(ctx)
+	return &memoryTransaction{db: d, tx: &memoryTx{parent: parent}}, nil
+}
+
+// Health sempre responde: não há conexão a verificar
+func (d *memoryDatabase) Health() error {
+	return nil
+}
+
+// memoryTransaction implementa contracts.Transaction. As alterações só são
+// desfeitas quando feitas com o contexto retornado por Context.
+type memoryTransaction struct {
+	db   *memoryDatabase
+	tx   *memoryTx
+	done bool
+}
+
+// Context retorna ctx carregando a transação
+func (t *memoryTransaction) Context(ctx context.Context) context.Context {
+	return context.WithValue(ctx, memoryTxContextKey{}, t.tx)
+}
+
+// Commit confirma a transação
+func (t *memoryTransaction) Commit() error {
+	if t.done {
+		return sql.ErrTxDone
+	}
+	t.done = true
+	t.tx.commit()
+	return nil
+}
+
+// Rollback desfaz as alterações feitas na transação
+func (t *memoryTransaction) Rollback() error {
+	if t.done {
+		return sql.ErrTxDone
+	}
+	t.done = true
+	t.tx.rollback()
+	return nil
+}
+
+func (t *memoryTransaction) UserRepository() contracts.UserRepository {
+	return t.db.users
+}
+
+func (t *memoryTransaction) ProductRepository() contracts.ProductRepository {
+	return t.db.products
+}
+
+func (t *memoryTransaction) OrderRepository() contracts.OrderRepository {
+	return t.db.orders
+}
@@ -0,0 +1,19 @@
+package database
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed migrations/*/*.sql
+var migrationFiles embed.FS
+
+// SharedMigrationsModule identifica as migrações das tabelas de infraestrutura
+// (outbox, dead letters, event log e sagas) em schema_migrations
+const SharedMigrationsModule = "shared"
+
+// Migrations retorna as migrações das tabelas de infraestrutura compartilhada
+func Migrations() fs.FS {
+	files, _ := fs.Sub(migrationFiles, "migrations")
+	return files
+}
@@ -0,0 +1,23 @@
+-- IF NOT EXISTS: bancos criados pelo antigo AutoMigrate já têm a tabela
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    event_id VARCHAR(36),
+    event_version BIGINT NOT NULL DEFAULT 1,
+    event_source VARCHAR(50),
+    correlation_id VARCHAR(64),
+    causation_id VARCHAR(36),
+    event_type VARCHAR(100) NOT NULL,
+    payload TEXT NOT NULL,
+    occurred_at DATETIME(3) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    attempts BIGINT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at DATETIME(3),
+    sent_at DATETIME(3),
+    PRIMARY KEY (id),
+    INDEX idx_outbox_events_event_id (event_id),
+    INDEX idx_outbox_events_correlation_id (correlation_id),
+    INDEX idx_outbox_events_event_type (event_type),
+    INDEX idx_outbox_events_status (status),
+    INDEX idx_outbox_events_created_at (created_at)
+);
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS dead_letters (
+    id VARCHAR(36) NOT NULL,
+    event_id VARCHAR(36),
+    event_version BIGINT NOT NULL DEFAULT 1,
+    event_source VARCHAR(50),
+    correlation_id VARCHAR(64),
+    causation_id VARCHAR(36),
+    event_type VARCHAR(100) NOT NULL,
+    subscription VARCHAR(150) NOT NULL,
+    payload TEXT NOT NULL,
+    event_timestamp DATETIME(3) NOT NULL,
+    attempts BIGINT NOT NULL,
+    last_error TEXT,
+    failed_at DATETIME(3) NOT NULL,
+    created_at DATETIME(3),
+    updated_at DATETIME(3),
+    PRIMARY KEY (id),
+    INDEX idx_dead_letters_event_id (event_id),
+    INDEX idx_dead_letters_correlation_id (correlation_id),
+    INDEX idx_dead_letters_event_type (event_type),
+    INDEX idx_dead_letters_subscription (subscription),
+    INDEX idx_dead_letters_failed_at (failed_at)
+);
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS event_log (
+    sequence BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    event_id VARCHAR(36),
+    event_version BIGINT NOT NULL DEFAULT 1,
+    event_source VARCHAR(50),
+    correlation_id VARCHAR(64),
+    causation_id VARCHAR(36),
+    event_type VARCHAR(100) NOT NULL,
+    payload TEXT NOT NULL,
+    occurred_at DATETIME(3) NOT NULL,
+    recorded_at DATETIME(3),
+    PRIMARY KEY (sequence),
+    INDEX idx_event_log_event_id (event_id),
+    INDEX idx_event_log_correlation_id (correlation_id),
+    INDEX idx_event_log_event_type (event_type),
+    INDEX idx_event_log_occurred_at (occurred_at)
+);
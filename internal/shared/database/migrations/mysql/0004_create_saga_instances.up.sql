@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS saga_instances (
+    id VARCHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    step BIGINT NOT NULL,
+    data TEXT NOT NULL,
+    last_error TEXT,
+    created_at DATETIME(3),
+    updated_at DATETIME(3),
+    PRIMARY KEY (id),
+    INDEX idx_saga_instances_name (name),
+    INDEX idx_saga_instances_status (status),
+    INDEX idx_saga_instances_created_at (created_at)
+);
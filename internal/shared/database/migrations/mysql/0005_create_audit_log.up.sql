@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    actor VARCHAR(100) NOT NULL,
+    request_id VARCHAR(64),
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id VARCHAR(36) NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    changes TEXT NOT NULL,
+    occurred_at DATETIME(3) NOT NULL,
+    PRIMARY KEY (id),
+    INDEX idx_audit_log_entity (entity_type, entity_id),
+    INDEX idx_audit_log_actor (actor),
+    INDEX idx_audit_log_request_id (request_id),
+    INDEX idx_audit_log_occurred_at (occurred_at)
+);
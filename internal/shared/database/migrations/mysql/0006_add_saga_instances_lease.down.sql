@@ -0,0 +1,2 @@
+ALTER TABLE saga_instances DROP COLUMN lease_expires_at;
+ALTER TABLE saga_instances DROP COLUMN owner;
@@ -0,0 +1,3 @@
+-- Lease do processo que executa a instância; a recuperação só assume leases expiradas
+ALTER TABLE saga_instances ADD COLUMN owner VARCHAR(64);
+ALTER TABLE saga_instances ADD COLUMN lease_expires_at DATETIME(3);
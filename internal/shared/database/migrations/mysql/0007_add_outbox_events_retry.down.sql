@@ -0,0 +1,4 @@
+DROP INDEX idx_outbox_events_locked_by ON outbox_events;
+ALTER TABLE outbox_events DROP COLUMN locked_until;
+ALTER TABLE outbox_events DROP COLUMN locked_by;
+ALTER TABLE outbox_events DROP COLUMN next_attempt_at;
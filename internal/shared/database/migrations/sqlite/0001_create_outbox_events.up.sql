@@ -0,0 +1,22 @@
+CREATE TABLE outbox_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_id VARCHAR(36),
+    event_version INTEGER NOT NULL DEFAULT 1,
+    event_source VARCHAR(50),
+    correlation_id VARCHAR(64),
+    causation_id VARCHAR(36),
+    event_type VARCHAR(100) NOT NULL,
+    payload TEXT NOT NULL,
+    occurred_at DATETIME NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at DATETIME,
+    sent_at DATETIME
+);
+
+CREATE INDEX idx_outbox_events_event_id ON outbox_events (event_id);
+CREATE INDEX idx_outbox_events_correlation_id ON outbox_events (correlation_id);
+CREATE INDEX idx_outbox_events_event_type ON outbox_events (event_type);
+CREATE INDEX idx_outbox_events_status ON outbox_events (status);
+CREATE INDEX idx_outbox_events_created_at ON outbox_events (created_at);
@@ -0,0 +1,23 @@
+CREATE TABLE dead_letters (
+    id VARCHAR(36) NOT NULL PRIMARY KEY,
+    event_id VARCHAR(36),
+    event_version INTEGER NOT NULL DEFAULT 1,
+    event_source VARCHAR(50),
+    correlation_id VARCHAR(64),
+    causation_id VARCHAR(36),
+    event_type VARCHAR(100) NOT NULL,
+    subscription VARCHAR(150) NOT NULL,
+    payload TEXT NOT NULL,
+    event_timestamp DATETIME NOT NULL,
+    attempts INTEGER NOT NULL,
+    last_error TEXT,
+    failed_at DATETIME NOT NULL,
+    created_at DATETIME,
+    updated_at DATETIME
+);
+
+CREATE INDEX idx_dead_letters_event_id ON dead_letters (event_id);
+CREATE INDEX idx_dead_letters_correlation_id ON dead_letters (correlation_id);
+CREATE INDEX idx_dead_letters_event_type ON dead_letters (event_type);
+CREATE INDEX idx_dead_letters_subscription ON dead_letters (subscription);
+CREATE INDEX idx_dead_letters_failed_at ON dead_letters (failed_at);
@@ -0,0 +1,17 @@
+CREATE TABLE event_log (
+    sequence INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_id VARCHAR(36),
+    event_version INTEGER NOT NULL DEFAULT 1,
+    event_source VARCHAR(50),
+    correlation_id VARCHAR(64),
+    causation_id VARCHAR(36),
+    event_type VARCHAR(100) NOT NULL,
+    payload TEXT NOT NULL,
+    occurred_at DATETIME NOT NULL,
+    recorded_at DATETIME
+);
+
+CREATE INDEX idx_event_log_event_id ON event_log (event_id);
+CREATE INDEX idx_event_log_correlation_id ON event_log (correlation_id);
+CREATE INDEX idx_event_log_event_type ON event_log (event_type);
+CREATE INDEX idx_event_log_occurred_at ON event_log (occurred_at);
@@ -0,0 +1,14 @@
+CREATE TABLE saga_instances (
+    id VARCHAR(36) NOT NULL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    step INTEGER NOT NULL,
+    data TEXT NOT NULL,
+    last_error TEXT,
+    created_at DATETIME,
+    updated_at DATETIME
+);
+
+CREATE INDEX idx_saga_instances_name ON saga_instances (name);
+CREATE INDEX idx_saga_instances_status ON saga_instances (status);
+CREATE INDEX idx_saga_instances_created_at ON saga_instances (created_at);
@@ -0,0 +1,5 @@
+-- Backoff entre tentativas, status terminal failed e reserva das linhas pelo relay
+ALTER TABLE outbox_events ADD COLUMN next_attempt_at DATETIME;
+ALTER TABLE outbox_events ADD COLUMN locked_by VARCHAR(36);
+ALTER TABLE outbox_events ADD COLUMN locked_until DATETIME;
+CREATE INDEX idx_outbox_events_locked_by ON outbox_events (locked_by);
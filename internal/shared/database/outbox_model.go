@@ -0,0 +1,34 @@
+package database
+
+import "time"
+
+// Status possíveis de um registro do outbox
+const (
+	OutboxStatusPending = "pending"
+	OutboxStatusSent    = "sent"
+	OutboxStatusFailed  = "failed" // Tentativas esgotadas; só volta a ser entregue com Requeue
+)
+
+// OutboxEventModel representa a estrutura da tabela outbox_events no banco.
+// Os eventos são gravados na mesma transação da alteração do agregado e
+// entregues ao EventBus posteriormente pelo relay.
+type OutboxEventModel struct {
+	ID            uint64 `gorm:"primaryKey;autoIncrement"`
+	EventEnvelope `gorm:"embedded"`
+	EventType     string    `gorm:"size:100;not null;index"`
+	Payload       string    `gorm:"type:text;not null"`
+	OccurredAt    time.Time `gorm:"not null"`
+	Status        string    `gorm:"size:20;not null;index"`
+	Attempts      int       `gorm:"default:0;not null"`
+	LastError     string    `gorm:"type:text"`
+	CreatedAt     time.Time `gorm:"autoCreateTime;index"`
+	SentAt        *time.Time
+	NextAttemptAt *time.Time // Backoff: o evento não é entregue antes disso
+	LockedBy      string     `gorm:"size:36;index"` // Reserva do lote que o relay está entregando
+	LockedUntil   *time.Time
+}
+
+// TableName especifica o nome da tabela
+func (OutboxEventModel) TableName() string {
+	return "outbox_events"
+}
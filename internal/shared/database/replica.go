@@ -0,0 +1,89 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// replicaTables são as tabelas cujas leituras vão para as réplicas: as dos
+// repositórios de usuários, produtos e pedidos. Outbox, sagas, dead letters,
+// event log e webhooks são lidos pelos workers logo após a escrita e ficam
+// sempre no primário.
+var replicaTables = []interface{}{"users", "products", "orders", "order_items"}
+
+// PoolConfig define o pool de conexões. Campos zerados usam o padrão do driver.
+type PoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+// defaultPool retorna o pool padrão para o destino da conexão
+func defaultPool(target connTarget) PoolConfig {
+	switch {
+	case target.inMemory:
+		// Cada conexão a :memory: é um banco diferente: uma única conexão, nunca reciclada
+		return PoolConfig{MaxOpenConns: 1, MaxIdleConns: 1}
+	case target.driver == DriverSQLite:
+		// SQLite aceita um escritor por vez; as demais conexões aguardam o busy timeout
+		return PoolConfig{MaxOpenConns: 4, MaxIdleConns: 2}
+	default:
+		return PoolConfig{MaxOpenConns: 100, MaxIdleConns: 10, ConnMaxLifetime: time.Hour}
+	}
+}
+
+// withDefaults preenche os campos zerados com os de defaults
+func (p PoolConfig) withDefaults(defaults PoolConfig) PoolConfig {
+	if p.MaxOpenConns == 0 {
+		p.MaxOpenConns = defaults.MaxOpenConns
+	}
+	if p.MaxIdleConns == 0 {
+		p.MaxIdleConns = defaults.MaxIdleConns
+	}
+	if p.ConnMaxLifetime == 0 {
+		p.ConnMaxLifetime = defaults.ConnMaxLifetime
+	}
+	if p.ConnMaxIdleTime == 0 {
+		p.ConnMaxIdleTime = defaults.ConnMaxIdleTime
+	}
+	return p
+}
+
+// registerReplicas encaminha as leituras de replicaTables para as réplicas.
+// Escritas, transações e leituras com contracts.WithPrimaryReads usam o primário.
+func registerReplicas(db *gorm.DB, primary connTarget, config *DatabaseConfig) error {
+	replicas := make([]gorm.Dialector, 0, len(config.Replicas))
+	for _, url := range config.Replicas {
+		target, err := parseConfig(&DatabaseConfig{URL: url})
+		if err != nil {
+			return fmt.Errorf("invalid replica: %w", err)
+		}
+		if target.driver != primary.driver {
+			return fmt.Errorf("invalid replica: %s replica for a %s database", target.driver, primary.driver)
+		}
+		if target.inMemory {
+			return fmt.Errorf("invalid replica: in-memory SQLite cannot be replicated")
+		}
+		replicas = append(replicas, target.dialector())
+	}
+
+	// O pool do resolver vale também para o primário; Connect o redefine depois
+	resolver := dbresolver.Register(dbresolver.Config{
+		Replicas: replicas,
+		Policy:   dbresolver.RandomPolicy{},
+	}, replicaTables...)
+	pool := config.ReplicaPool.withDefaults(defaultPool(primary))
+	resolver.SetMaxOpenConns(pool.MaxOpenConns)
+	resolver.SetMaxIdleConns(pool.MaxIdleConns)
+	resolver.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	resolver.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
+
+	if err := db.Use(resolver); err != nil {
+		return fmt.Errorf("failed to register replicas: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-modular-monolith/pkg/contracts"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+const productsTable = `CREATE TABLE products (
+	id VARCHAR(36) PRIMARY KEY, name VARCHAR(100), description VARCHAR(500), price DECIMAL(10,2),
+	stock INTEGER, category_id VARCHAR(36), version INTEGER, created_at DATETIME, updated_at DATETIME, deleted_at DATETIME
+)`
+
+// Primário e réplica são arquivos SQLite distintos, sem replicação entre eles:
+// o que cada leitura enxerga mostra para qual conexão ela foi encaminhada
+func TestReplicasServeReadsUnlessPrimaryIsForced(t *testing.T) {
+	dir := t.TempDir()
+	replicaURL := "sqlite://" + filepath.Join(dir, "replica.db")
+
+	replica, err := Connect(&DatabaseConfig{URL: replicaURL})
+	require.NoError(t, err)
+	require.NoError(t, replica.Exec(productsTable).Error)
+
+	db, err := Connect(&DatabaseConfig{
+		URL:         "sqlite://" + filepath.Join(dir, "primary.db"),
+		Replicas:    []string{replicaURL},
+		Pool:        PoolConfig{MaxOpenConns: 3, ConnMaxIdleTime: time.Minute},
+		ReplicaPool: PoolConfig{MaxOpenConns: 2},
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.Exec(productsTable).Error)
+
+	ctx := context.Background()
+	product := &contracts.Product{ID: "prod-1", Name: "Teclado", Price: 10, CategoryID: "cat"}
+	require.NoError(t, Conn(ctx, db).Create(product).Error)
+
+	var found contracts.Product
+	err = Conn(ctx, db).First(&found, "id = ?", product.ID).Error
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound, "read should go to the replica")
+
+	require.NoError(t, Conn(contracts.WithPrimaryReads(ctx), db).First(&found, "id = ?", product.ID).Error)
+	assert.Equal(t, product.Name, found.Name)
+
+	// Transações ficam no primário
+	err = NewTransactionManager(db).WithinTransaction(ctx, func(ctx context.Context) error {
+		return Conn(ctx, db).First(&found, "id = ?", product.ID).Error
+	})
+	require.NoError(t, err)
+
+	// O pool do primário não é sobrescrito pelo das réplicas
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	assert.Equal(t, 3, sqlDB.Stats().MaxOpenConnections)
+}
+
+func TestReplicaMustMatchPrimaryDriver(t *testing.T) {
+	_, err := Connect(&DatabaseConfig{
+		URL:      "sqlite://" + filepath.Join(t.TempDir(), "primary.db"),
+		Replicas: []string{"mysql://root@localhost/app_db"},
+	})
+	assert.ErrorContains(t, err, "mysql replica for a sqlite database")
+}
@@ -0,0 +1,23 @@
+package database
+
+import "time"
+
+// SagaInstanceModel representa a estrutura da tabela saga_instances no banco.
+// Owner e LeaseExpiresAt indicam qual processo executa a instância.
+type SagaInstanceModel struct {
+	ID             string     `gorm:"primaryKey;size:36"`
+	Name           string     `gorm:"size:100;not null;index"`
+	Status         string     `gorm:"size:20;not null;index"`
+	Step           int        `gorm:"not null"`
+	Data           string     `gorm:"type:text;not null"`
+	LastError      string     `gorm:"type:text"`
+	Owner          string     `gorm:"size:64"`
+	LeaseExpiresAt *time.Time `gorm:"column:lease_expires_at"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime;index"`
+	UpdatedAt      time.Time  `gorm:"autoUpdateTime"`
+}
+
+// TableName especifica o nome da tabela
+func (SagaInstanceModel) TableName() string {
+	return "saga_instances"
+}
@@ -0,0 +1,72 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"go-modular-monolith/pkg/contracts"
+
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+type txContextKey struct{}
+
+// ContextWithTx retorna um contexto que carrega a transação informada
+func ContextWithTx(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, txContextKey{}, tx)
+}
+
+// TxFromContext obtém a transação carregada pelo contexto, se houver
+func TxFromContext(ctx context.Context) (*gorm.DB, bool) {
+	tx, ok := ctx.Value(txContextKey{}).(*gorm.DB)
+	return tx, ok
+}
+
+// Conn retorna a transação do contexto ou, na ausência dela, a conexão padrão.
+// Repositórios devem usar Conn em vez de db.WithContext para participar de
+// transações e respeitar contracts.WithPrimaryReads quando há réplicas.
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	if contracts.PrimaryReads(ctx) {
+		return db.WithContext(ctx).Clauses(dbresolver.Write)
+	}
+	return db.WithContext(ctx)
+}
+
+// gormTransactionManager implementa contracts.TransactionManager usando GORM
+type gormTransactionManager struct {
+	db *gorm.DB
+}
+
+// NewTransactionManager cria um gerenciador de transações para a conexão informada
+func NewTransactionManager(db *gorm.DB) contracts.TransactionManager {
+	return &gormTransactionManager{db: db}
+}
+
+// WithinTransaction executa fn em uma transação, reaproveitando a do contexto se existir
+func (m *gormTransactionManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := TxFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(ContextWithTx(ctx, tx))
+	})
+}
+
+// VersionMismatch explica um Update com controle de versão que não alterou
+// nenhuma linha: contracts.ErrNotFound se o registro não existe (ou foi
+// excluído), senão contracts.ErrVersionConflict
+func VersionMismatch(ctx context.Context, db *gorm.DB, model interface{}, id string) error {
+	var count int64
+	if err := Conn(contracts.WithPrimaryReads(ctx), db).Model(model).Where("id = ?", id).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check version: %w", err)
+	}
+	if count == 0 {
+		return contracts.ErrNotFound
+	}
+	return contracts.ErrVersionConflict
+}
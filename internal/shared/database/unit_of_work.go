@@ -0,0 +1,137 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+
+	"go-modular-monolith/pkg/contracts"
+
+	"gorm.io/gorm"
+)
+
+// Repositories constrói os repositórios entregues pela unidade de trabalho.
+// Cada função recebe a conexão da transação; nil desabilita o repositório.
+type Repositories struct {
+	User    func(db *gorm.DB) contracts.UserRepository
+	Product func(db *gorm.DB) contracts.ProductRepository
+	Order   func(db *gorm.DB) contracts.OrderRepository
+}
+
+// gormDatabase implementa contracts.Database usando GORM
+type gormDatabase struct {
+	db         *gorm.DB
+	repos      Repositories
+	savepoints atomic.Uint64
+}
+
+// NewDatabase cria a unidade de trabalho para a conexão informada
+func NewDatabase(db *gorm.DB, repos Repositories) contracts.Database {
+	return &gormDatabase{db: db, repos: repos}
+}
+
+// BeginTx abre uma transação. Se o contexto já carrega uma, a nova é um
+// savepoint dentro dela: Rollback desfaz só o trabalho feito desde BeginTx.
+func (d *gormDatabase) BeginTx(ctx context.Context) (contracts.Transaction, error) {
+	if parent, ok := TxFromContext(ctx); ok {
+		name := fmt.Sprintf("uow_%d", d.savepoints.Add(1))
+		if err := parent.SavePoint(name).Error; err != nil {
+			return nil, fmt.Errorf("failed to create savepoint: %w", err)
+		}
+		return &gormTransaction{tx: parent, repos: d.repos, savepoint: name}, nil
+	}
+
+	tx := d.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+	return &gormTransaction{tx: tx, repos: d.repos}, nil
+}
+
+// Health verifica a conexão com o banco
+func (d *gormDatabase) Health() error {
+	sqlDB, err := d.db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+	return sqlDB.Ping()
+}
+
+// gormTransaction implementa contracts.Transaction sobre uma transação GORM
+type gormTransaction struct {
+	tx        *gorm.DB
+	repos     Repositories
+	savepoint string // Definido quando a transação é aninhada
+	done      bool
+
+	userRepo    contracts.UserRepository
+	productRepo contracts.ProductRepository
+	orderRepo   contracts.OrderRepository
+}
+
+// Context retorna ctx carregando a transação
+func (t *gormTransaction) Context(ctx context.Context) context.Context {
+	return ContextWithTx(ctx, t.tx)
+}
+
+// Commit confirma a transação. Aninhada, o savepoint é mantido e o trabalho
+// é confirmado junto com a transação externa.
+func (t *gormTransaction) Commit() error {
+	if t.done {
+		return sql.ErrTxDone
+	}
+	t.done = true
+
+	if t.savepoint != "" {
+		return nil
+	}
+	if err := t.tx.Commit().Error; err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// Rollback desfaz a transação; após Commit retorna sql.ErrTxDone,
+// o que permite adiar Rollback logo após BeginTx
+func (t *gormTransaction) Rollback() error {
+	if t.done {
+		return sql.ErrTxDone
+	}
+	t.done = true
+
+	if t.savepoint != "" {
+		if err := t.tx.RollbackTo(t.savepoint).Error; err != nil {
+			return fmt.Errorf("failed to rollback to savepoint: %w", err)
+		}
+		return nil
+	}
+	if err := t.tx.Rollback().Error; err != nil {
+		return fmt.Errorf("failed to rollback transaction: %w", err)
+	}
+	return nil
+}
+
+// UserRepository retorna o repositório de usuários ligado à transação
+func (t *gormTransaction) UserRepository() contracts.UserRepository {
+	if t.userRepo == nil && t.repos.User != nil {
+		t.userRepo = t.repos.User(t.tx)
+	}
+	return t.userRepo
+}
+
+// ProductRepository retorna o repositório de produtos ligado à transação
+func (t *gormTransaction) ProductRepository() contracts.ProductRepository {
+	if t.productRepo == nil && t.repos.Product != nil {
+		t.productRepo = t.repos.Product(t.tx)
+	}
+	return t.productRepo
+}
+
+// OrderRepository retorna o repositório de pedidos ligado à transação
+func (t *gormTransaction) OrderRepository() contracts.OrderRepository {
+	if t.orderRepo == nil && t.repos.Order != nil {
+		t.orderRepo = t.repos.Order(t.tx)
+	}
+	return t.orderRepo
+}
@@ -0,0 +1,111 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"go-modular-monolith/pkg/contracts"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// openUnitOfWork abre um SQLite em arquivo com a tabela items
+func openUnitOfWork(t *testing.T) (*gorm.DB, contracts.Database) {
+	t.Helper()
+	db, err := Connect(&DatabaseConfig{URL: "sqlite://" + filepath.Join(t.TempDir(), "uow.db")})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	require.NoError(t, db.Exec("CREATE TABLE items (name VARCHAR(50) PRIMARY KEY)").Error)
+	return db, NewDatabase(db, Repositories{})
+}
+
+func insertItem(t *testing.T, ctx context.Context, db *gorm.DB, name string) {
+	t.Helper()
+	require.NoError(t, Conn(ctx, db).Exec("INSERT INTO items (name) VALUES (?)", name).Error)
+}
+
+// items lista os itens visíveis no contexto (na transação dele, se houver)
+func items(t *testing.T, ctx context.Context, db *gorm.DB) []string {
+	t.Helper()
+	var names []string
+	require.NoError(t, Conn(ctx, db).Raw("SELECT name FROM items ORDER BY name").Scan(&names).Error)
+	return names
+}
+
+func TestUnitOfWorkCommitAndRollback(t *testing.T) {
+	ctx := context.Background()
+	db, uow := openUnitOfWork(t)
+
+	tx, err := uow.BeginTx(ctx)
+	require.NoError(t, err)
+	insertItem(t, tx.Context(ctx), db, "committed")
+	require.NoError(t, tx.Commit())
+	assert.ErrorIs(t, tx.Rollback(), sql.ErrTxDone, "deferred Rollback after Commit is harmless")
+	assert.ErrorIs(t, tx.Commit(), sql.ErrTxDone)
+
+	tx, err = uow.BeginTx(ctx)
+	require.NoError(t, err)
+	insertItem(t, tx.Context(ctx), db, "rolled-back")
+	require.NoError(t, tx.Rollback())
+	assert.ErrorIs(t, tx.Commit(), sql.ErrTxDone)
+
+	assert.Equal(t, []string{"committed"}, items(t, ctx, db))
+}
+
+// BeginTx dentro de uma transação abre um savepoint: Rollback desfaz só o
+// trabalho aninhado e Commit o deixa para a transação externa confirmar
+func TestUnitOfWorkNestedTransactionsUseSavepoints(t *testing.T) {
+	ctx := context.Background()
+	db, uow := openUnitOfWork(t)
+
+	outer, err := uow.BeginTx(ctx)
+	require.NoError(t, err)
+	outerCtx := outer.Context(ctx)
+	insertItem(t, outerCtx, db, "a-outer")
+
+	failed, err := uow.BeginTx(outerCtx)
+	require.NoError(t, err)
+	insertItem(t, failed.Context(outerCtx), db, "b-failed")
+	require.NoError(t, failed.Rollback())
+	assert.Equal(t, []string{"a-outer"}, items(t, outerCtx, db), "only the savepoint is undone")
+
+	kept, err := uow.BeginTx(outerCtx)
+	require.NoError(t, err)
+	keptCtx := kept.Context(outerCtx)
+	insertItem(t, keptCtx, db, "c-kept")
+
+	// Dois níveis: desfazer o savepoint mais interno preserva o do meio
+	innermost, err := uow.BeginTx(keptCtx)
+	require.NoError(t, err)
+	insertItem(t, innermost.Context(keptCtx), db, "d-innermost")
+	require.NoError(t, innermost.Rollback())
+	require.NoError(t, kept.Commit())
+
+	require.NoError(t, outer.Commit())
+	assert.Equal(t, []string{"a-outer", "c-kept"}, items(t, ctx, db))
+}
+
+func TestUnitOfWorkOuterRollbackDiscardsCommittedSavepoints(t *testing.T) {
+	ctx := context.Background()
+	db, uow := openUnitOfWork(t)
+
+	outer, err := uow.BeginTx(ctx)
+	require.NoError(t, err)
+	outerCtx := outer.Context(ctx)
+	insertItem(t, outerCtx, db, "outer")
+
+	inner, err := uow.BeginTx(outerCtx)
+	require.NoError(t, err)
+	insertItem(t, inner.Context(outerCtx), db, "inner")
+	require.NoError(t, inner.Commit())
+
+	require.NoError(t, outer.Rollback())
+	assert.Empty(t, items(t, ctx, db))
+}
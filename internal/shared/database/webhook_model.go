@@ -0,0 +1,112 @@
+package database
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"go-modular-monolith/pkg/contracts"
+)
+
+// WebhookEndpointModel representa a estrutura da tabela webhook_endpoints no banco
+type WebhookEndpointModel struct {
+	ID         string    `gorm:"primaryKey;size:36"`
+	URL        string    `gorm:"size:500;not null"`
+	Secret     string    `gorm:"size:100;not null"`
+	EventTypes string    `gorm:"size:500;not null"` // Tipos separados por vírgula
+	Active     bool      `gorm:"not null;default:true"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName especifica o nome da tabela
+func (WebhookEndpointModel) TableName() string {
+	return "webhook_endpoints"
+}
+
+// ToContract converte WebhookEndpointModel para contracts.WebhookEndpoint
+func (w *WebhookEndpointModel) ToContract() *contracts.WebhookEndpoint {
+	return &contracts.WebhookEndpoint{
+		ID:         w.ID,
+		URL:        w.URL,
+		Secret:     w.Secret,
+		EventTypes: strings.Split(w.EventTypes, ","),
+		Active:     w.Active,
+		CreatedAt:  w.CreatedAt,
+		UpdatedAt:  w.UpdatedAt,
+	}
+}
+
+// FromContract converte contracts.WebhookEndpoint para WebhookEndpointModel
+func (w *WebhookEndpointModel) FromContract(endpoint *contracts.WebhookEndpoint) {
+	w.ID = endpoint.ID
+	w.URL = endpoint.URL
+	w.Secret = endpoint.Secret
+	w.EventTypes = strings.Join(endpoint.EventTypes, ",")
+	w.Active = endpoint.Active
+	w.CreatedAt = endpoint.CreatedAt
+	w.UpdatedAt = endpoint.UpdatedAt
+}
+
+// WebhookDeliveryModel representa a estrutura da tabela webhook_deliveries no banco
+type WebhookDeliveryModel struct {
+	ID            string     `gorm:"primaryKey;size:36"`
+	EndpointID    string     `gorm:"size:36;not null;index"`
+	EventID       string     `gorm:"size:36;not null;index"`
+	EventType     string     `gorm:"size:100;not null"`
+	Payload       string     `gorm:"type:text;not null"`
+	Status        string     `gorm:"size:20;not null;index:idx_webhook_deliveries_due,priority:1"`
+	Attempts      int        `gorm:"not null;default:0"`
+	ResponseCode  int        `gorm:"not null;default:0"`
+	ResponseBody  string     `gorm:"type:text"`
+	LastError     string     `gorm:"type:text"`
+	NextAttemptAt *time.Time `gorm:"index:idx_webhook_deliveries_due,priority:2"`
+	DeliveredAt   *time.Time
+	LockedBy      string `gorm:"size:36;index"` // Dispatcher que reservou a entrega
+	LockedUntil   *time.Time
+	CreatedAt     time.Time `gorm:"autoCreateTime;index"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName especifica o nome da tabela
+func (WebhookDeliveryModel) TableName() string {
+	return "webhook_deliveries"
+}
+
+// ToContract converte WebhookDeliveryModel para contracts.WebhookDelivery
+func (w *WebhookDeliveryModel) ToContract() *contracts.WebhookDelivery {
+	return &contracts.WebhookDelivery{
+		ID:            w.ID,
+		EndpointID:    w.EndpointID,
+		EventID:       w.EventID,
+		EventType:     w.EventType,
+		Payload:       json.RawMessage(w.Payload),
+		Status:        contracts.WebhookDeliveryStatus(w.Status),
+		Attempts:      w.Attempts,
+		ResponseCode:  w.ResponseCode,
+		ResponseBody:  w.ResponseBody,
+		LastError:     w.LastError,
+		NextAttemptAt: w.NextAttemptAt,
+		DeliveredAt:   w.DeliveredAt,
+		CreatedAt:     w.CreatedAt,
+		UpdatedAt:     w.UpdatedAt,
+	}
+}
+
+// FromContract converte contracts.WebhookDelivery para WebhookDeliveryModel
+func (w *WebhookDeliveryModel) FromContract(delivery *contracts.WebhookDelivery) {
+	w.ID = delivery.ID
+	w.EndpointID = delivery.EndpointID
+	w.EventID = delivery.EventID
+	w.EventType = delivery.EventType
+	w.Payload = string(delivery.Payload)
+	w.Status = string(delivery.Status)
+	w.Attempts = delivery.Attempts
+	w.ResponseCode = delivery.ResponseCode
+	w.ResponseBody = delivery.ResponseBody
+	w.LastError = delivery.LastError
+	w.NextAttemptAt = delivery.NextAttemptAt
+	w.DeliveredAt = delivery.DeliveredAt
+	w.CreatedAt = delivery.CreatedAt
+	w.UpdatedAt = delivery.UpdatedAt
+}
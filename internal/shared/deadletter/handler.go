@@ -0,0 +1,128 @@
+package deadletter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go-modular-monolith/pkg/events"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Redriver reentrega uma dead letter à inscrição de origem
+type Redriver interface {
+	Redrive(ctx context.Context, id string) error
+}
+
+// Handler expõe a administração das dead letters
+type Handler struct {
+	store    events.DeadLetterStore
+	redriver Redriver
+}
+
+// NewHandler cria uma nova instância do handler
+func NewHandler(store events.DeadLetterStore, redriver Redriver) *Handler {
+	return &Handler{
+		store:    store,
+		redriver: redriver,
+	}
+}
+
+// ListDeadLetters lista dead letters filtrando por event_type e subscription
+func (h *Handler) ListDeadLetters(c *gin.Context) {
+	filter := parseFilter(c)
+
+	filter.Limit = 50
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil {
+			filter.Limit = limit
+		}
+	}
+
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		if offset, err := strconv.Atoi(offsetStr); err == nil {
+			filter.Offset = offset
+		}
+	}
+
+	deadLetters, err := h.store.List(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"dead_letters": deadLetters,
+		"limit":        filter.Limit,
+		"offset":       filter.Offset,
+	})
+}
+
+// GetDeadLetter retorna os detalhes de uma dead letter
+func (h *Handler) GetDeadLetter(c *gin.Context) {
+	deadLetter, err := h.store.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, deadLetter)
+}
+
+// RedriveDeadLetter reentrega a dead letter à inscrição que falhou
+func (h *Handler) RedriveDeadLetter(c *gin.Context) {
+	if err := h.redriver.Redrive(c.Request.Context(), c.Param("id")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Dead letter redriven successfully"})
+}
+
+// DeleteDeadLetter remove uma dead letter definitivamente
+func (h *Handler) DeleteDeadLetter(c *gin.Context) {
+	if err := h.store.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// PurgeDeadLetters remove todas as dead letters que atendem aos filtros
+func (h *Handler) PurgeDeadLetters(c *gin.Context) {
+	purged, err := h.store.Purge(c.Request.Context(), parseFilter(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"purged": purged})
+}
+
+func parseFilter(c *gin.Context) events.DeadLetterFilter {
+	filter := events.DeadLetterFilter{}
+
+	if eventType := c.Query("event_type"); eventType != "" {
+		filter.EventType = &eventType
+	}
+
+	if subscription := c.Query("subscription"); subscription != "" {
+		filter.Subscription = &subscription
+	}
+
+	return filter
+}
+
+func respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, events.ErrDeadLetterNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, events.ErrSubscriptionNotFound):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
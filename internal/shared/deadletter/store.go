@@ -0,0 +1,134 @@
+package deadletter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go-modular-monolith/internal/shared/database"
+	"go-modular-monolith/pkg/events"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// gormDeadLetterStore implementa events.DeadLetterStore usando GORM
+type gormDeadLetterStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore cria um store de dead letters persistido no banco
+func NewGormStore(db *gorm.DB) events.DeadLetterStore {
+	return &gormDeadLetterStore{db: db}
+}
+
+// Save grava ou atualiza a dead letter. Usa a conexão padrão para não ser
+// desfeita junto com uma eventual transação do handler que falhou.
+func (s *gormDeadLetterStore) Save(ctx context.Context, deadLetter *events.DeadLetter) error {
+	model := toModel(deadLetter)
+	err := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"attempts", "last_error", "failed_at", "updated_at"}),
+	}).Create(model).Error
+	if err != nil {
+		return fmt.Errorf("failed to save dead letter: %w", err)
+	}
+	return nil
+}
+
+// Get busca uma dead letter pelo ID
+func (s *gormDeadLetterStore) Get(ctx context.Context, id string) (*events.DeadLetter, error) {
+	var model database.DeadLetterModel
+	if err := s.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, events.ErrDeadLetterNotFound
+		}
+		return nil, fmt.Errorf("failed to get dead letter: %w", err)
+	}
+	return fromModel(&model), nil
+}
+
+// List lista dead letters com filtros e paginação
+func (s *gormDeadLetterStore) List(ctx context.Context, filter events.DeadLetterFilter) ([]*events.DeadLetter, error) {
+	query := applyFilter(s.db.WithContext(ctx).Model(&database.DeadLetterModel{}), filter).Order("failed_at ASC")
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	var models []database.DeadLetterModel
+	if err := query.Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+
+	deadLetters := make([]*events.DeadLetter, len(models))
+	for i := range models {
+		deadLetters[i] = fromModel(&models[i])
+	}
+	return deadLetters, nil
+}
+
+// Delete remove uma dead letter
+func (s *gormDeadLetterStore) Delete(ctx context.Context, id string) error {
+	result := s.db.WithContext(ctx).Where("id = ?", id).Delete(&database.DeadLetterModel{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete dead letter: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return events.ErrDeadLetterNotFound
+	}
+	return nil
+}
+
+// Purge remove todas as dead letters que atendem ao filtro
+func (s *gormDeadLetterStore) Purge(ctx context.Context, filter events.DeadLetterFilter) (int64, error) {
+	// Sem filtros o GORM exige confirmação explícita para deletar a tabela inteira
+	query := applyFilter(s.db.WithContext(ctx).Session(&gorm.Session{AllowGlobalUpdate: true}), filter)
+
+	result := query.Delete(&database.DeadLetterModel{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to purge dead letters: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+func applyFilter(query *gorm.DB, filter events.DeadLetterFilter) *gorm.DB {
+	if filter.EventType != nil {
+		query = query.Where("event_type = ?", *filter.EventType)
+	}
+	if filter.Subscription != nil {
+		query = query.Where("subscription = ?", *filter.Subscription)
+	}
+	return query
+}
+
+func toModel(deadLetter *events.DeadLetter) *database.DeadLetterModel {
+	return &database.DeadLetterModel{
+		EventEnvelope:  database.NewEventEnvelope(deadLetter.Metadata),
+		ID:             deadLetter.ID,
+		EventType:      deadLetter.EventType,
+		Subscription:   deadLetter.Subscription,
+		Payload:        string(deadLetter.Payload),
+		EventTimestamp: deadLetter.EventTimestamp,
+		Attempts:       deadLetter.Attempts,
+		LastError:      deadLetter.LastError,
+		FailedAt:       deadLetter.FailedAt,
+	}
+}
+
+func fromModel(model *database.DeadLetterModel) *events.DeadLetter {
+	return &events.DeadLetter{
+		Metadata:       model.Metadata(),
+		ID:             model.ID,
+		EventType:      model.EventType,
+		Subscription:   model.Subscription,
+		Payload:        json.RawMessage(model.Payload),
+		EventTimestamp: model.EventTimestamp,
+		Attempts:       model.Attempts,
+		LastError:      model.LastError,
+		FailedAt:       model.FailedAt,
+	}
+}
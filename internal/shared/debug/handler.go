@@ -0,0 +1,41 @@
+package debug
+
+import (
+	"net/http"
+	"strings"
+
+	"go-modular-monolith/pkg/container"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler expõe informações de diagnóstico da aplicação
+type Handler struct {
+	container *container.Container
+}
+
+// NewHandler cria uma nova instância do handler
+func NewHandler(c *container.Container) *Handler {
+	return &Handler{container: c}
+}
+
+// GetContainerGraph retorna o grafo de dependências do container em JSON ou,
+// com format=dot, no formato do Graphviz (dot -Tsvg graph.dot > graph.svg)
+func (h *Handler) GetContainerGraph(c *gin.Context) {
+	graph := h.container.Graph()
+
+	if c.Query("format") == "dot" {
+		c.Data(http.StatusOK, "text/vnd.graphviz; charset=utf-8", []byte(graph.DOT()))
+		return
+	}
+
+	problems := []string{}
+	if err := graph.Validate(); err != nil {
+		problems = strings.Split(err.Error(), "\n")
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"nodes":  graph.Nodes,
+		"errors": problems,
+	})
+}
@@ -0,0 +1,24 @@
+package eventmetrics
+
+import (
+	"net/http"
+
+	"go-modular-monolith/pkg/events"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler expõe as métricas coletadas pelo interceptor de latência do Event Bus
+type Handler struct {
+	metrics *events.LatencyMetrics
+}
+
+// NewHandler cria uma nova instância do handler
+func NewHandler(metrics *events.LatencyMetrics) *Handler {
+	return &Handler{metrics: metrics}
+}
+
+// GetMetrics retorna latência e erros por operação, tipo de evento e inscrição
+func (h *Handler) GetMetrics(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"metrics": h.metrics.Snapshot()})
+}
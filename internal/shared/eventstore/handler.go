@@ -0,0 +1,93 @@
+package eventstore
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-modular-monolith/pkg/events"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Replayer reentrega eventos do log para inscrições do bus
+type Replayer interface {
+	Replay(ctx context.Context, req events.ReplayRequest) (*events.ReplayResult, error)
+}
+
+// Handler expõe a consulta ao log de eventos e o replay
+type Handler struct {
+	log      events.EventLog
+	replayer Replayer
+}
+
+// NewHandler cria uma nova instância do handler
+func NewHandler(log events.EventLog, replayer Replayer) *Handler {
+	return &Handler{
+		log:      log,
+		replayer: replayer,
+	}
+}
+
+// ListEvents lista eventos do log filtrando por types, after_sequence e since
+func (h *Handler) ListEvents(c *gin.Context) {
+	query := events.EventLogQuery{Limit: 100}
+
+	if types := c.Query("types"); types != "" {
+		query.Types = strings.Split(types, ",")
+	}
+
+	if afterStr := c.Query("after_sequence"); afterStr != "" {
+		if after, err := strconv.ParseUint(afterStr, 10, 64); err == nil {
+			query.AfterSequence = after
+		}
+	}
+
+	if sinceStr := c.Query("since"); sinceStr != "" {
+		since, err := time.Parse(time.RFC3339, sinceStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "since must be an RFC3339 timestamp"})
+			return
+		}
+		query.Since = &since
+	}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil {
+			query.Limit = limit
+		}
+	}
+
+	stored, err := h.log.Read(c.Request.Context(), query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"events": stored})
+}
+
+// ReplayEvents reentrega eventos do log para as inscrições escolhidas
+func (h *Handler) ReplayEvents(c *gin.Context) {
+	var req events.ReplayRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.replayer.Replay(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, events.ErrSubscriptionNotFound) || errors.Is(err, events.ErrNoReplayTargets) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error(), "result": result})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,89 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go-modular-monolith/internal/shared/database"
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/events"
+
+	"gorm.io/gorm"
+)
+
+// gormEventLog implementa events.EventLog usando GORM
+type gormEventLog struct {
+	db *gorm.DB
+}
+
+// NewGormEventLog cria um log de eventos persistido na tabela event_log
+func NewGormEventLog(db *gorm.DB) events.EventLog {
+	return &gormEventLog{db: db}
+}
+
+// Append grava o evento no final do log
+func (l *gormEventLog) Append(ctx context.Context, event contracts.Event) (*events.StoredEvent, error) {
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event payload: %w", err)
+	}
+
+	occurredAt := event.Timestamp
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+
+	model := &database.EventLogModel{
+		EventEnvelope: database.NewEventEnvelope(events.MetadataOf(event)),
+		EventType:     event.Type,
+		Payload:       string(payload),
+		OccurredAt:    occurredAt,
+	}
+
+	if err := l.db.WithContext(ctx).Create(model).Error; err != nil {
+		return nil, fmt.Errorf("failed to append event: %w", err)
+	}
+
+	return toStoredEvent(model), nil
+}
+
+// Read lê eventos em ordem de sequência aplicando os filtros
+func (l *gormEventLog) Read(ctx context.Context, query events.EventLogQuery) ([]*events.StoredEvent, error) {
+	db := l.db.WithContext(ctx).Where("sequence > ?", query.AfterSequence)
+
+	if len(query.Types) > 0 {
+		db = db.Where("event_type IN ?", query.Types)
+	}
+
+	if query.Since != nil {
+		db = db.Where("occurred_at >= ?", *query.Since)
+	}
+
+	if query.Limit > 0 {
+		db = db.Limit(query.Limit)
+	}
+
+	var models []database.EventLogModel
+	if err := db.Order("sequence ASC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to read event log: %w", err)
+	}
+
+	stored := make([]*events.StoredEvent, len(models))
+	for i := range models {
+		stored[i] = toStoredEvent(&models[i])
+	}
+	return stored, nil
+}
+
+func toStoredEvent(model *database.EventLogModel) *events.StoredEvent {
+	return &events.StoredEvent{
+		Metadata:   model.Metadata(),
+		Sequence:   model.Sequence,
+		Type:       model.EventType,
+		Payload:    json.RawMessage(model.Payload),
+		Timestamp:  model.OccurredAt,
+		RecordedAt: model.RecordedAt,
+	}
+}
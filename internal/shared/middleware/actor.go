@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"go-modular-monolith/pkg/contracts"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AnonymousActor é o autor das alterações feitas sem token válido
+const AnonymousActor = "anonymous"
+
+// CurrentUserKey é o serviço com escopo que resolve o usuário do token Bearer
+// (registrado pelo módulo de usuário)
+const CurrentUserKey = "currentUser"
+
+// Actor identifica quem faz a requisição pelo usuário autenticado do escopo
+// (CurrentUser) e o leva ao contexto (contracts.WithActor), de onde a trilha
+// de auditoria o lê. Sem usuário autenticado o autor é AnonymousActor; a
+// requisição não é recusada. Requer o middleware Scope antes.
+func Actor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actor := AnonymousActor
+		if user := CurrentUser(c); user != nil {
+			actor = user.ID
+		}
+
+		c.Request = c.Request.WithContext(contracts.WithActor(c.Request.Context(), actor))
+		c.Next()
+	}
+}
+
+// CurrentUser retorna o usuário do token Bearer, resolvido uma vez por
+// requisição no escopo. É nil sem token válido, para usuários excluídos e
+// quando o módulo de usuário está desligado.
+func CurrentUser(c *gin.Context) *contracts.User {
+	scope := scopeOf(c)
+	if scope == nil {
+		return nil
+	}
+
+	user, err := scope.Get(CurrentUserKey)
+	if err != nil {
+		return nil
+	}
+	current, _ := user.(*contracts.User)
+	return current
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"strconv"
+	"strings"
+
+	"go-modular-monolith/pkg/contracts"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ETag retorna o ETag da versão de um registro
+func ETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// SetETag envia o ETag da versão do registro na resposta
+func SetETag(c *gin.Context, version int) {
+	c.Header("ETag", ETag(version))
+}
+
+// IfMatch leva o header If-Match ao contexto da requisição (contracts.WithIfMatch),
+// para que os serviços recusem alterar um registro que mudou desde a leitura.
+// "*" aceita qualquer versão. ETags fracos ou desconhecidos nunca correspondem,
+// como manda a comparação forte do If-Match.
+func IfMatch() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := strings.TrimSpace(c.GetHeader("If-Match"))
+		if header == "" || header == "*" {
+			c.Next()
+			return
+		}
+
+		versions := []int{}
+		for _, tag := range strings.Split(header, ",") {
+			tag = strings.TrimSpace(tag)
+			if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+				continue
+			}
+			if version, err := strconv.Atoi(tag[1 : len(tag)-1]); err == nil {
+				versions = append(versions, version)
+			}
+		}
+
+		c.Request = c.Request.WithContext(contracts.WithIfMatch(c.Request.Context(), versions))
+		c.Next()
+	}
+}
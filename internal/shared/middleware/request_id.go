@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"go-modular-monolith/pkg/events"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader é o header usado para propagar o ID da requisição
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDKey é a chave do ID da requisição no gin.Context
+const RequestIDKey = "request_id"
+
+// RequestID garante um ID por requisição (reaproveitando o X-Request-ID recebido)
+// e o usa como correlation ID dos eventos publicados durante a requisição
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" {
+			requestID = uuid.New().String()
+		}
+
+		c.Set(RequestIDKey, requestID)
+		c.Header(RequestIDHeader, requestID)
+		c.Request = c.Request.WithContext(events.WithCorrelationID(c.Request.Context(), requestID))
+
+		c.Next()
+	}
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"context"
+	"log"
+
+	"go-modular-monolith/pkg/container"
+	"go-modular-monolith/pkg/contracts"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ScopeKey é a chave do escopo da requisição no gin.Context
+const ScopeKey = "container_scope"
+
+// RequestLoggerKey é o serviço com escopo do logger com o ID da requisição
+const RequestLoggerKey = "requestLogger"
+
+// Scope cria um escopo filho do container por requisição, acessível pelo
+// gin.Context (GetScope) e pelo contexto da requisição (container.ScopeFromContext).
+// O escopo recebe o gin.Context e o ID da requisição (quando RequestID roda antes)
+// e é descartado ao fim da requisição.
+func Scope(c *container.Container) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		scope := c.NewScope()
+		scope.Set("ginContext", ctx)
+		scope.Set(RequestIDKey, ctx.GetString(RequestIDKey))
+
+		ctx.Set(ScopeKey, scope)
+		ctx.Request = ctx.Request.WithContext(container.ContextWithScope(ctx.Request.Context(), scope))
+
+		defer func() {
+			// A limpeza roda mesmo se o cliente desconectou
+			if err := scope.Dispose(context.WithoutCancel(ctx.Request.Context())); err != nil {
+				log.Printf("Failed to dispose request scope: %v", err)
+			}
+		}()
+
+		ctx.Next()
+	}
+}
+
+// GetScope obtém o escopo da requisição criado pelo middleware Scope
+func GetScope(ctx *gin.Context) *container.Scope {
+	scope, _ := ctx.MustGet(ScopeKey).(*container.Scope)
+	return scope
+}
+
+// RequestLogger retorna o logger da requisição, resolvido no escopo; nil fora
+// do middleware Scope
+func RequestLogger(ctx *gin.Context) contracts.Logger {
+	scope := scopeOf(ctx)
+	if scope == nil {
+		return nil
+	}
+
+	logger, err := scope.Get(RequestLoggerKey)
+	if err != nil {
+		return nil
+	}
+	return logger.(contracts.Logger)
+}
+
+// scopeOf é como GetScope, mas retorna nil fora do middleware Scope
+func scopeOf(ctx *gin.Context) *container.Scope {
+	value, _ := ctx.Get(ScopeKey)
+	scope, _ := value.(*container.Scope)
+	return scope
+}
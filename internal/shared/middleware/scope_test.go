@@ -0,0 +1,110 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-modular-monolith/internal/shared/middleware"
+	"go-modular-monolith/pkg/container"
+	"go-modular-monolith/pkg/contracts"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// unitOfWork é um serviço com escopo que registra o próprio descarte
+type unitOfWork struct {
+	requestID string
+	disposed  bool
+}
+
+func newRouter(c *container.Container, handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.RequestID(), middleware.Scope(c), middleware.Actor())
+	router.GET("/", handler)
+	return router
+}
+
+func serve(router *gin.Engine, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestScopeCreatesAndDisposesInstancesPerRequest(t *testing.T) {
+	c := container.NewContainer()
+	var created []*unitOfWork
+	c.RegisterScoped("unitOfWork", func(s *container.Scope) interface{} {
+		uow := &unitOfWork{requestID: s.MustGet(middleware.RequestIDKey).(string)}
+		s.OnDispose(func(ctx context.Context) error {
+			uow.disposed = true
+			return nil
+		})
+		created = append(created, uow)
+		return uow
+	})
+
+	router := newRouter(c, func(ctx *gin.Context) {
+		scope := middleware.GetScope(ctx)
+		uow := scope.MustGet("unitOfWork").(*unitOfWork)
+		assert.Same(t, uow, scope.MustGet("unitOfWork"), "one instance per request")
+		assert.False(t, uow.disposed, "alive while the request runs")
+
+		// O mesmo escopo chega aos serviços pelo contexto da requisição
+		fromContext, ok := container.ScopeFromContext(ctx.Request.Context())
+		require.True(t, ok)
+		assert.Same(t, scope, fromContext)
+		ctx.Status(http.StatusNoContent)
+	})
+
+	serve(router, map[string]string{middleware.RequestIDHeader: "req-1"})
+	serve(router, map[string]string{middleware.RequestIDHeader: "req-2"})
+
+	require.Len(t, created, 2)
+	assert.NotSame(t, created[0], created[1])
+	assert.Equal(t, "req-1", created[0].requestID)
+	assert.Equal(t, "req-2", created[1].requestID)
+	assert.True(t, created[0].disposed)
+	assert.True(t, created[1].disposed)
+}
+
+func TestActorUsesScopedCurrentUser(t *testing.T) {
+	c := container.NewContainer()
+	resolved := 0
+	c.RegisterScoped(middleware.CurrentUserKey, func(s *container.Scope) interface{} {
+		resolved++
+		if s.MustGet("ginContext").(*gin.Context).GetHeader("Authorization") != "Bearer valid" {
+			return (*contracts.User)(nil)
+		}
+		return &contracts.User{ID: "user-1"}
+	})
+
+	var actor string
+	router := newRouter(c, func(ctx *gin.Context) {
+		actor = contracts.ActorFromContext(ctx.Request.Context())
+		assert.Equal(t, actor != middleware.AnonymousActor, middleware.CurrentUser(ctx) != nil)
+	})
+
+	serve(router, map[string]string{"Authorization": "Bearer valid"})
+	assert.Equal(t, "user-1", actor)
+	assert.Equal(t, 1, resolved, "Actor and the handler share the request's instance")
+
+	serve(router, map[string]string{"Authorization": "Bearer forged"})
+	assert.Equal(t, middleware.AnonymousActor, actor)
+
+	// Sem o módulo de usuário não há currentUser: todos são anônimos
+	router = newRouter(container.NewContainer(), func(ctx *gin.Context) {
+		actor = contracts.ActorFromContext(ctx.Request.Context())
+		assert.Nil(t, middleware.RequestLogger(ctx))
+	})
+	serve(router, map[string]string{"Authorization": "Bearer valid"})
+	assert.Equal(t, middleware.AnonymousActor, actor)
+}
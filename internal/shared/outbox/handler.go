@@ -0,0 +1,53 @@
+package outbox
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go-modular-monolith/pkg/contracts"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler expõe as métricas do outbox para administração
+type Handler struct {
+	relay *Relay
+	store *Store
+}
+
+// NewHandler cria uma nova instância do handler
+func NewHandler(relay *Relay, store *Store) *Handler {
+	return &Handler{relay: relay, store: store}
+}
+
+// GetMetrics retorna lag e contadores do relay
+func (h *Handler) GetMetrics(c *gin.Context) {
+	metrics, err := h.relay.Metrics(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, metrics)
+}
+
+// RequeueEvent devolve à fila um evento com as tentativas esgotadas
+func (h *Handler) RequeueEvent(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid outbox event ID"})
+		return
+	}
+
+	if err := h.store.Requeue(c.Request.Context(), id); err != nil {
+		if errors.Is(err, contracts.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Outbox event requeued successfully"})
+}
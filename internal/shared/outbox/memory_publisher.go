@@ -0,0 +1,55 @@
+package outbox
+
+import (
+	"context"
+
+	"go-modular-monolith/internal/shared/database"
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/events"
+)
+
+// MemoryPublisher substitui o outbox quando não há banco (STORAGE=memory):
+// o evento vai ao bus após o commit da transação em memória do contexto e é
+// descartado no rollback. Sem o outbox, eventos de um processo que cai entre
+// o commit e a entrega são perdidos.
+type MemoryPublisher struct {
+	bus      contracts.EventPublisher
+	registry *events.Registry
+	logger   contracts.Logger
+}
+
+// NewMemoryPublisher cria um publisher que entrega ao bus após o commit
+func NewMemoryPublisher(bus contracts.EventPublisher, registry *events.Registry, logger contracts.Logger) contracts.EventPublisher {
+	return &MemoryPublisher{
+		bus:      bus,
+		registry: registry,
+		logger:   logger,
+	}
+}
+
+// Publish valida o evento e o entrega ao bus no commit. Fora de uma transação
+// a entrega é imediata e o erro do bus é retornado.
+func (p *MemoryPublisher) Publish(ctx context.Context, event contracts.Event) error {
+	event, err := p.registry.Decode(events.Enrich(ctx, event))
+	if err != nil {
+		return err
+	}
+
+	var publishErr error
+	database.AfterCommit(ctx, func() {
+		publishErr = p.bus.Publish(context.WithoutCancel(ctx), event)
+		if publishErr != nil {
+			p.logger.Error("Failed to publish event after commit",
+				contracts.Field{Key: "event_id", Value: event.ID},
+				contracts.Field{Key: "event_type", Value: event.Type},
+				contracts.Field{Key: "error", Value: publishErr.Error()},
+			)
+		}
+	})
+	return publishErr
+}
+
+// Subscribe registra o handler diretamente no bus
+func (p *MemoryPublisher) Subscribe(pattern string, handler contracts.EventHandler) (contracts.Subscription, error) {
+	return p.bus.Subscribe(pattern, handler)
+}
@@ -0,0 +1,41 @@
+package outbox
+
+import (
+	"context"
+
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/events"
+)
+
+// Publisher implementa contracts.EventPublisher gravando os eventos no outbox.
+// A entrega aos handlers acontece pelo Relay; as inscrições são delegadas ao bus.
+type Publisher struct {
+	store    *Store
+	bus      contracts.EventPublisher
+	registry *events.Registry
+}
+
+// NewPublisher cria um publisher transacional baseado no outbox
+func NewPublisher(store *Store, bus contracts.EventPublisher, registry *events.Registry) contracts.EventPublisher {
+	return &Publisher{
+		store:    store,
+		bus:      bus,
+		registry: registry,
+	}
+}
+
+// Publish valida o evento e o grava no outbox, na transação do contexto quando houver.
+// Eventos inválidos são rejeitados aqui para não ficarem presos no outbox.
+func (p *Publisher) Publish(ctx context.Context, event contracts.Event) error {
+	event, err := p.registry.Decode(events.Enrich(ctx, event))
+	if err != nil {
+		return err
+	}
+
+	return p.store.Add(ctx, event)
+}
+
+// Subscribe registra o handler diretamente no bus que recebe os eventos do relay
+func (p *Publisher) Subscribe(pattern string, handler contracts.EventHandler) (contracts.Subscription, error) {
+	return p.bus.Subscribe(pattern, handler)
+}
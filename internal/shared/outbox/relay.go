@@ -0,0 +1,232 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/events"
+)
+
+// RelayConfig contém as configurações do relay
+type RelayConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	// Retry define as tentativas por evento e o backoff entre elas; esgotadas,
+	// o evento fica com status failed até ser devolvido à fila com Requeue
+	Retry events.RetryPolicy
+	// LockTimeout é por quanto tempo um lote fica reservado ao relay que o
+	// reservou; deve ser maior que o tempo de entrega de um lote
+	LockTimeout time.Duration
+}
+
+// DefaultRetryPolicy é a política do relay quando RelayConfig.Retry não é informada
+func DefaultRetryPolicy() events.RetryPolicy {
+	return events.RetryPolicy{
+		MaxAttempts:    10,
+		InitialBackoff: time.Second,
+		MaxBackoff:     5 * time.Minute,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// Metrics representa o estado atual do relay
+type Metrics struct {
+	Delivered     uint64     `json:"delivered"`
+	Failed        uint64     `json:"failed"` // Tentativas com erro desde o início do processo
+	Pending       int64      `json:"pending"`
+	FailedEvents  int64      `json:"failed_events"` // Eventos com as tentativas esgotadas
+	OldestPending *time.Time `json:"oldest_pending,omitempty"`
+	LagSeconds    float64    `json:"lag_seconds"`
+	LastRunAt     *time.Time `json:"last_run_at,omitempty"`
+	LastError     string     `json:"last_error,omitempty"`
+}
+
+// Dispatcher entrega o evento aos handlers antes de retornar, mesmo com o
+// EventBus em modo assíncrono (events.EventBus.PublishSync)
+type Dispatcher interface {
+	PublishSync(ctx context.Context, event contracts.Event) error
+}
+
+// Relay lê eventos pendentes do outbox e os entrega ao EventBus.
+// A entrega é at-least-once: o evento só é marcado como enviado depois que os
+// handlers o processaram, e se o processo cair entre a entrega e o MarkSent
+// ele será entregue novamente. Cada lote é reservado antes da entrega, então
+// réplicas rodando o relay ao mesmo tempo não entregam os mesmos eventos.
+type Relay struct {
+	store  *Store
+	bus    Dispatcher
+	logger contracts.Logger
+	config RelayConfig
+
+	mu        sync.Mutex
+	delivered uint64
+	failed    uint64
+	lastRunAt *time.Time
+	lastError string
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewRelay cria uma nova instância do relay
+func NewRelay(store *Store, bus Dispatcher, logger contracts.Logger, config RelayConfig) *Relay {
+	if config.PollInterval <= 0 {
+		config.PollInterval = time.Second
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.Retry.MaxAttempts <= 0 {
+		config.Retry = DefaultRetryPolicy()
+	}
+	if config.LockTimeout <= 0 {
+		config.LockTimeout = time.Minute
+	}
+
+	return &Relay{
+		store:  store,
+		bus:    bus,
+		logger: logger,
+		config: config,
+	}
+}
+
+// Start inicia o loop de entrega em background
+func (r *Relay) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.done = make(chan struct{})
+
+	go func() {
+		defer close(r.done)
+
+		ticker := time.NewTicker(r.config.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			if _, err := r.ProcessBatch(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				r.logger.Error("Outbox relay failed", contracts.Field{Key: "error", Value: err})
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop interrompe o loop e aguarda o lote em andamento terminar
+func (r *Relay) Stop(ctx context.Context) error {
+	if r.cancel == nil {
+		return nil
+	}
+
+	r.cancel()
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ProcessBatch reserva e entrega um lote de eventos pendentes e retorna quantos foram entregues
+func (r *Relay) ProcessBatch(ctx context.Context) (int, error) {
+	records, err := r.store.ClaimPending(ctx, r.config.BatchSize, r.config.LockTimeout)
+	if err != nil {
+		r.recordRun(0, 0, err)
+		return 0, err
+	}
+
+	delivered, failed := 0, 0
+	for _, record := range records {
+		if ctx.Err() != nil {
+			break
+		}
+
+		event := record.Metadata().Event(record.EventType, json.RawMessage(record.Payload), record.OccurredAt)
+
+		if err := r.bus.PublishSync(ctx, event); err != nil {
+			failed++
+			if markErr := r.recordFailure(ctx, record.ID, record.Attempts+1, err); markErr != nil {
+				r.logger.Error("Failed to record outbox delivery error", contracts.Field{Key: "error", Value: markErr})
+			}
+			continue
+		}
+
+		if err := r.store.MarkSent(ctx, record.ID); err != nil {
+			// O evento será reenviado no próximo ciclo (at-least-once)
+			failed++
+			r.logger.Error("Failed to mark outbox event as sent", contracts.Field{Key: "error", Value: err})
+			continue
+		}
+		delivered++
+	}
+
+	r.recordRun(delivered, failed, nil)
+	return delivered, ctx.Err()
+}
+
+// recordFailure agenda a próxima tentativa ou, esgotadas as tentativas, tira o
+// evento da fila: um evento que nunca é entregue não bloqueia os seguintes
+func (r *Relay) recordFailure(ctx context.Context, id uint64, attempt int, cause error) error {
+	if attempt >= r.config.Retry.MaxAttempts {
+		r.logger.Error("Outbox event failed permanently",
+			contracts.Field{Key: "outbox_id", Value: id},
+			contracts.Field{Key: "attempts", Value: attempt},
+			contracts.Field{Key: "error", Value: cause.Error()},
+		)
+		return r.store.MarkFailed(ctx, id, cause)
+	}
+	return r.store.ScheduleRetry(ctx, id, cause, time.Now().Add(r.config.Retry.Backoff(attempt)))
+}
+
+func (r *Relay) recordRun(delivered, failed int, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	r.lastRunAt = &now
+	r.delivered += uint64(delivered)
+	r.failed += uint64(failed)
+	if err != nil {
+		r.lastError = err.Error()
+	}
+}
+
+// Metrics retorna as métricas do relay, incluindo o lag do evento pendente mais antigo
+func (r *Relay) Metrics(ctx context.Context) (*Metrics, error) {
+	pending, oldest, err := r.store.PendingStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	failedEvents, err := r.store.CountFailed(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	metrics := &Metrics{
+		Delivered:     r.delivered,
+		Failed:        r.failed,
+		Pending:       pending,
+		FailedEvents:  failedEvents,
+		OldestPending: oldest,
+		LastRunAt:     r.lastRunAt,
+		LastError:     r.lastError,
+	}
+	if oldest != nil {
+		metrics.LagSeconds = time.Since(*oldest).Seconds()
+	}
+
+	return metrics, nil
+}
@@ -0,0 +1,287 @@
+package outbox_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-modular-monolith/internal/shared/database"
+	"go-modular-monolith/internal/shared/database/databasetest"
+	"go-modular-monolith/internal/shared/outbox"
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/events"
+	"go-modular-monolith/pkg/migrate"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...contracts.Field)           {}
+func (nopLogger) Info(string, ...contracts.Field)            {}
+func (nopLogger) Warn(string, ...contracts.Field)            {}
+func (nopLogger) Error(string, ...contracts.Field)           {}
+func (nopLogger) Fatal(string, ...contracts.Field)           {}
+func (l nopLogger) With(...contracts.Field) contracts.Logger { return l }
+
+// failingDispatcher recusa todos os eventos
+type failingDispatcher struct{}
+
+func (failingDispatcher) PublishSync(context.Context, contracts.Event) error {
+	return errors.New("bus unavailable")
+}
+
+// forEachDatabase roda o teste em cada banco de databasetest com a tabela
+// outbox_events vazia
+func forEachDatabase(t *testing.T, test func(t *testing.T, db *gorm.DB, store *outbox.Store)) {
+	for name, url := range databasetest.URLs() {
+		t.Run(name, func(t *testing.T) {
+			db := databasetest.Open(t, url, migrate.Source{Module: database.SharedMigrationsModule, FS: database.Migrations()})
+			require.NoError(t, db.Exec("DELETE FROM outbox_events").Error)
+			test(t, db, outbox.NewStore(db))
+		})
+	}
+}
+
+func addEvents(t *testing.T, store *outbox.Store, count int) {
+	t.Helper()
+	for i := 0; i < count; i++ {
+		require.NoError(t, store.Add(context.Background(), contracts.Event{
+			Type:      events.OrderCreatedEventType,
+			Timestamp: time.Now(),
+			Payload:   contracts.OrderCreatedEvent{OrderID: "order-1", UserID: "user-1", Total: 10},
+		}))
+	}
+}
+
+func TestStorePendingLifecycle(t *testing.T) {
+	forEachDatabase(t, func(t *testing.T, db *gorm.DB, store *outbox.Store) {
+		ctx := context.Background()
+		addEvents(t, store, 3)
+
+		pending, err := store.ClaimPending(ctx, 2, time.Minute)
+		require.NoError(t, err)
+		require.Len(t, pending, 2)
+		assert.Less(t, pending[0].ID, pending[1].ID, "oldest first")
+
+		require.NoError(t, store.MarkSent(ctx, pending[0].ID))
+		require.NoError(t, store.ScheduleRetry(ctx, pending[1].ID, errors.New("boom"), time.Now().Add(time.Hour)))
+
+		count, oldest, err := store.PendingStats(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+		assert.NotNil(t, oldest)
+
+		var failed database.OutboxEventModel
+		require.NoError(t, db.First(&failed, pending[1].ID).Error)
+		assert.Equal(t, database.OutboxStatusPending, failed.Status)
+		assert.Equal(t, 1, failed.Attempts)
+		assert.Equal(t, "boom", failed.LastError)
+		assert.NotNil(t, failed.NextAttemptAt)
+		assert.Empty(t, failed.LockedBy, "the claim is released")
+
+		var sent database.OutboxEventModel
+		require.NoError(t, db.First(&sent, pending[0].ID).Error)
+		assert.Equal(t, database.OutboxStatusSent, sent.Status)
+		assert.NotNil(t, sent.SentAt)
+	})
+}
+
+// Com o bus assíncrono descartando eventos, o relay ainda só marca como
+// enviado o que os handlers já processaram
+func TestRelayMarksSentOnlyAfterHandlersRun(t *testing.T) {
+	forEachDatabase(t, func(t *testing.T, db *gorm.DB, store *outbox.Store) {
+		ctx := context.Background()
+		bus := events.NewEventBus(events.WithAsyncDispatch(events.AsyncConfig{
+			Workers:      1,
+			QueueSize:    1,
+			Backpressure: events.BackpressureDrop,
+		}))
+		t.Cleanup(func() { bus.Close(context.Background()) })
+
+		var handled atomic.Int32
+		_, err := bus.Subscribe(events.OrderCreatedEventType, func(ctx context.Context, event contracts.Event) error {
+			time.Sleep(time.Millisecond)
+			handled.Add(1)
+			return nil
+		})
+		require.NoError(t, err)
+
+		addEvents(t, store, 5)
+		relay := outbox.NewRelay(store, bus, nopLogger{}, outbox.RelayConfig{BatchSize: 10})
+
+		delivered, err := relay.ProcessBatch(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 5, delivered)
+		assert.Equal(t, int32(5), handled.Load(), "every event marked as sent was handled")
+		assert.Zero(t, bus.DroppedEvents())
+
+		count, _, err := store.PendingStats(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+}
+
+func TestRelayKeepsFailedEventsPending(t *testing.T) {
+	forEachDatabase(t, func(t *testing.T, db *gorm.DB, store *outbox.Store) {
+		ctx := context.Background()
+		addEvents(t, store, 2)
+		relay := outbox.NewRelay(store, failingDispatcher{}, nopLogger{}, outbox.RelayConfig{BatchSize: 10})
+
+		delivered, err := relay.ProcessBatch(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, delivered)
+
+		metrics, err := relay.Metrics(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), metrics.Failed)
+		assert.Equal(t, int64(2), metrics.Pending)
+	})
+}
+
+func TestClaimPendingSkipsClaimedAndBackedOffEvents(t *testing.T) {
+	forEachDatabase(t, func(t *testing.T, db *gorm.DB, store *outbox.Store) {
+		ctx := context.Background()
+		addEvents(t, store, 4)
+
+		first, err := store.ClaimPending(ctx, 2, time.Minute)
+		require.NoError(t, err)
+		require.Len(t, first, 2)
+
+		// Outro relay recebe só o que não foi reservado
+		second, err := store.ClaimPending(ctx, 10, time.Minute)
+		require.NoError(t, err)
+		require.Len(t, second, 2)
+		assert.Greater(t, second[0].ID, first[1].ID)
+
+		none, err := store.ClaimPending(ctx, 10, time.Minute)
+		require.NoError(t, err)
+		assert.Empty(t, none)
+
+		// Em backoff o evento fica de fora; vencido o prazo, volta
+		require.NoError(t, store.ScheduleRetry(ctx, first[0].ID, errors.New("boom"), time.Now().Add(time.Hour)))
+		require.NoError(t, store.ScheduleRetry(ctx, first[1].ID, errors.New("boom"), time.Now().Add(-time.Second)))
+		retried, err := store.ClaimPending(ctx, 10, time.Minute)
+		require.NoError(t, err)
+		require.Len(t, retried, 1)
+		assert.Equal(t, first[1].ID, retried[0].ID)
+
+		// Uma reserva expirada (relay que caiu) é assumida por outro
+		require.NoError(t, db.Exec("UPDATE outbox_events SET locked_until = ? WHERE id = ?", time.Now().Add(-time.Second), second[0].ID).Error)
+		expired, err := store.ClaimPending(ctx, 10, time.Minute)
+		require.NoError(t, err)
+		require.Len(t, expired, 1)
+		assert.Equal(t, second[0].ID, expired[0].ID)
+	})
+}
+
+// poisonDispatcher recusa os pedidos order-poison e conta as entregas por pedido
+type poisonDispatcher struct {
+	mu        sync.Mutex
+	delivered map[string]int
+}
+
+func (d *poisonDispatcher) PublishSync(ctx context.Context, event contracts.Event) error {
+	var payload contracts.OrderCreatedEvent
+	if err := json.Unmarshal(event.Payload.(json.RawMessage), &payload); err != nil {
+		return err
+	}
+	if payload.OrderID == "order-poison" {
+		return errors.New("cannot handle order-poison")
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.delivered[payload.OrderID]++
+	return nil
+}
+
+// Eventos que nunca são entregues esgotam as tentativas e saem da fila, em vez
+// de ocupar o lote para sempre
+func TestRelayMovesPoisonEventsToFailed(t *testing.T) {
+	forEachDatabase(t, func(t *testing.T, db *gorm.DB, store *outbox.Store) {
+		ctx := context.Background()
+		for _, orderID := range []string{"order-poison", "order-poison", "order-1"} {
+			require.NoError(t, store.Add(ctx, contracts.Event{
+				Type:    events.OrderCreatedEventType,
+				Payload: contracts.OrderCreatedEvent{OrderID: orderID, UserID: "user-1", Total: 10},
+			}))
+		}
+
+		dispatcher := &poisonDispatcher{delivered: make(map[string]int)}
+		relay := outbox.NewRelay(store, dispatcher, nopLogger{}, outbox.RelayConfig{
+			BatchSize: 2,
+			Retry:     events.RetryPolicy{MaxAttempts: 2},
+		})
+
+		for i := 0; i < 2; i++ {
+			delivered, err := relay.ProcessBatch(ctx)
+			require.NoError(t, err)
+			assert.Zero(t, delivered, "the batch only holds the poison events")
+		}
+
+		delivered, err := relay.ProcessBatch(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+
+		metrics, err := relay.Metrics(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, metrics.Pending)
+		assert.Equal(t, int64(2), metrics.FailedEvents)
+		assert.Equal(t, uint64(4), metrics.Failed)
+
+		var failed []database.OutboxEventModel
+		require.NoError(t, db.Where("status = ?", database.OutboxStatusFailed).Order("id").Find(&failed).Error)
+		require.Len(t, failed, 2)
+		assert.Equal(t, 2, failed[0].Attempts)
+		assert.Equal(t, "cannot handle order-poison", failed[0].LastError)
+
+		// Requeue devolve o evento à fila com as tentativas zeradas
+		require.NoError(t, store.Requeue(ctx, failed[0].ID))
+		count, _, err := store.PendingStats(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+		assert.ErrorIs(t, store.Requeue(ctx, failed[0].ID), contracts.ErrNotFound, "only failed events")
+	})
+}
+
+func TestConcurrentRelaysDeliverEachEventOnce(t *testing.T) {
+	forEachDatabase(t, func(t *testing.T, db *gorm.DB, store *outbox.Store) {
+		ctx := context.Background()
+		for i := 0; i < 40; i++ {
+			require.NoError(t, store.Add(ctx, contracts.Event{
+				Type:    events.OrderCreatedEventType,
+				Payload: contracts.OrderCreatedEvent{OrderID: fmt.Sprintf("order-%d", i), UserID: "user-1", Total: 10},
+			}))
+		}
+
+		dispatcher := &poisonDispatcher{delivered: make(map[string]int)}
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			relay := outbox.NewRelay(store, dispatcher, nopLogger{}, outbox.RelayConfig{BatchSize: 5})
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					delivered, err := relay.ProcessBatch(ctx)
+					if !assert.NoError(t, err) || delivered == 0 {
+						return
+					}
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Len(t, dispatcher.delivered, 40)
+		for orderID, count := range dispatcher.delivered {
+			assert.Equal(t, 1, count, orderID)
+		}
+	})
+}
@@ -0,0 +1,203 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go-modular-monolith/internal/shared/database"
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/events"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Store persiste eventos na tabela outbox_events
+type Store struct {
+	db *gorm.DB
+}
+
+// NewStore cria uma nova instância do store do outbox
+func NewStore(db *gorm.DB) *Store {
+	return &Store{db: db}
+}
+
+// Add grava o evento no outbox usando a transação do contexto, se houver
+func (s *Store) Add(ctx context.Context, event contracts.Event) error {
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode event payload: %w", err)
+	}
+
+	occurredAt := event.Timestamp
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+
+	record := &database.OutboxEventModel{
+		EventEnvelope: database.NewEventEnvelope(events.MetadataOf(event)),
+		EventType:     event.Type,
+		Payload:       string(payload),
+		OccurredAt:    occurredAt,
+		Status:        database.OutboxStatusPending,
+	}
+
+	if err := database.Conn(ctx, s.db).Create(record).Error; err != nil {
+		return fmt.Errorf("failed to store outbox event: %w", err)
+	}
+
+	return nil
+}
+
+// readyForDelivery filtra os eventos pendentes fora do backoff e sem reserva válida
+func readyForDelivery(now time.Time) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("status = ?", database.OutboxStatusPending).
+			Where("next_attempt_at IS NULL OR next_attempt_at <= ?", now).
+			Where("locked_until IS NULL OR locked_until < ?", now)
+	}
+}
+
+// ClaimPending reserva por lockFor os eventos pendentes mais antigos prontos
+// para entrega e os retorna. A reserva é um UPDATE condicional: com várias
+// réplicas cada evento é entregue por uma só. Uma reserva que expira (o
+// processo caiu no meio do lote) libera o evento para outro relay.
+func (s *Store) ClaimPending(ctx context.Context, limit int, lockFor time.Duration) ([]database.OutboxEventModel, error) {
+	now := time.Now()
+
+	var ids []uint64
+	err := s.db.WithContext(ctx).Model(&database.OutboxEventModel{}).
+		Scopes(readyForDelivery(now)).
+		Order("id ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pending outbox events: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	claim := uuid.New().String()
+	err = s.db.WithContext(ctx).Model(&database.OutboxEventModel{}).
+		Scopes(readyForDelivery(now)).
+		Where("id IN ?", ids).
+		UpdateColumns(map[string]interface{}{
+			"locked_by":    claim,
+			"locked_until": now.Add(lockFor),
+		}).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+
+	// Só as linhas que este UPDATE reservou; as demais foram de outro relay
+	var records []database.OutboxEventModel
+	if err := s.db.WithContext(ctx).Where("locked_by = ?", claim).Order("id ASC").Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch claimed outbox events: %w", err)
+	}
+
+	return records, nil
+}
+
+// release desfaz a reserva junto com a atualização do evento
+func release(updates map[string]interface{}) map[string]interface{} {
+	updates["locked_by"] = ""
+	updates["locked_until"] = nil
+	return updates
+}
+
+// MarkSent marca o evento como entregue
+func (s *Store) MarkSent(ctx context.Context, id uint64) error {
+	now := time.Now()
+	err := s.db.WithContext(ctx).Model(&database.OutboxEventModel{}).Where("id = ?", id).Updates(release(map[string]interface{}{
+		"status":   database.OutboxStatusSent,
+		"sent_at":  &now,
+		"attempts": gorm.Expr("attempts + 1"),
+	})).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox event as sent: %w", err)
+	}
+	return nil
+}
+
+// ScheduleRetry registra uma tentativa de entrega com erro; o evento continua
+// pendente e só volta a ser reservado a partir de retryAt
+func (s *Store) ScheduleRetry(ctx context.Context, id uint64, cause error, retryAt time.Time) error {
+	err := s.db.WithContext(ctx).Model(&database.OutboxEventModel{}).Where("id = ?", id).Updates(release(map[string]interface{}{
+		"attempts":        gorm.Expr("attempts + 1"),
+		"last_error":      cause.Error(),
+		"next_attempt_at": retryAt,
+	})).Error
+	if err != nil {
+		return fmt.Errorf("failed to schedule outbox event retry: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed registra a última tentativa com erro e tira o evento da fila
+func (s *Store) MarkFailed(ctx context.Context, id uint64, cause error) error {
+	err := s.db.WithContext(ctx).Model(&database.OutboxEventModel{}).Where("id = ?", id).Updates(release(map[string]interface{}{
+		"status":          database.OutboxStatusFailed,
+		"attempts":        gorm.Expr("attempts + 1"),
+		"last_error":      cause.Error(),
+		"next_attempt_at": nil,
+	})).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox event as failed: %w", err)
+	}
+	return nil
+}
+
+// Requeue devolve um evento failed à fila com as tentativas zeradas
+func (s *Store) Requeue(ctx context.Context, id uint64) error {
+	result := s.db.WithContext(ctx).Model(&database.OutboxEventModel{}).
+		Where("id = ? AND status = ?", id, database.OutboxStatusFailed).
+		Updates(map[string]interface{}{
+			"status":          database.OutboxStatusPending,
+			"attempts":        0,
+			"next_attempt_at": nil,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to requeue outbox event: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("failed outbox event %d %w", id, contracts.ErrNotFound)
+	}
+	return nil
+}
+
+// CountFailed retorna a quantidade de eventos com as tentativas esgotadas
+func (s *Store) CountFailed(ctx context.Context) (int64, error) {
+	var count int64
+	err := s.db.WithContext(ctx).Model(&database.OutboxEventModel{}).Where("status = ?", database.OutboxStatusFailed).Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count failed outbox events: %w", err)
+	}
+	return count, nil
+}
+
+// PendingStats retorna a quantidade de eventos pendentes e o horário do mais antigo
+func (s *Store) PendingStats(ctx context.Context) (int64, *time.Time, error) {
+	var count int64
+	query := s.db.WithContext(ctx).Model(&database.OutboxEventModel{}).Where("status = ?", database.OutboxStatusPending)
+	if err := query.Count(&count).Error; err != nil {
+		return 0, nil, fmt.Errorf("failed to count pending outbox events: %w", err)
+	}
+
+	if count == 0 {
+		return 0, nil, nil
+	}
+
+	var oldest database.OutboxEventModel
+	err := s.db.WithContext(ctx).
+		Where("status = ?", database.OutboxStatusPending).
+		Order("id ASC").
+		First(&oldest).Error
+	if err != nil {
+		return count, nil, fmt.Errorf("failed to get oldest pending outbox event: %w", err)
+	}
+
+	return count, &oldest.CreatedAt, nil
+}
@@ -0,0 +1,86 @@
+package saga
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go-modular-monolith/pkg/saga"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler expõe a consulta e a recuperação das sagas
+type Handler struct {
+	store       saga.Store
+	coordinator *saga.Coordinator
+}
+
+// NewHandler cria uma nova instância do handler
+func NewHandler(store saga.Store, coordinator *saga.Coordinator) *Handler {
+	return &Handler{
+		store:       store,
+		coordinator: coordinator,
+	}
+}
+
+// ListSagas lista as instâncias filtrando por name e status
+func (h *Handler) ListSagas(c *gin.Context) {
+	filter := saga.Filter{Limit: 50}
+
+	if name := c.Query("name"); name != "" {
+		filter.Name = &name
+	}
+	if status := c.Query("status"); status != "" {
+		s := saga.Status(status)
+		filter.Status = &s
+	}
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil {
+			filter.Limit = limit
+		}
+	}
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		if offset, err := strconv.Atoi(offsetStr); err == nil {
+			filter.Offset = offset
+		}
+	}
+
+	instances, err := h.store.List(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"sagas":  instances,
+		"limit":  filter.Limit,
+		"offset": filter.Offset,
+	})
+}
+
+// GetSaga retorna o estado de uma instância
+func (h *Handler) GetSaga(c *gin.Context) {
+	instance, err := h.store.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if errors.Is(err, saga.ErrSagaNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, instance)
+}
+
+// RecoverSagas retoma as instâncias não finalizadas (por exemplo, compensações que falharam)
+func (h *Handler) RecoverSagas(c *gin.Context) {
+	result, err := h.coordinator.Recover(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,157 @@
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go-modular-monolith/internal/shared/database"
+	"go-modular-monolith/pkg/saga"
+
+	"gorm.io/gorm"
+)
+
+// gormSagaStore implementa saga.Store usando GORM
+type gormSagaStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore cria um store de sagas persistido no banco
+func NewGormStore(db *gorm.DB) saga.Store {
+	return &gormSagaStore{db: db}
+}
+
+// Create grava uma nova instância
+func (s *gormSagaStore) Create(ctx context.Context, instance *saga.Instance) error {
+	if err := database.Conn(ctx, s.db).Create(toModel(instance)).Error; err != nil {
+		return fmt.Errorf("failed to create saga: %w", err)
+	}
+	return nil
+}
+
+// Update grava o progresso da instância, participando da transação do passo
+func (s *gormSagaStore) Update(ctx context.Context, instance *saga.Instance) error {
+	result := database.Conn(ctx, s.db).Model(&database.SagaInstanceModel{}).
+		Where("id = ?", instance.ID).
+		Updates(map[string]interface{}{
+			"status":     string(instance.Status),
+			"step":       instance.Step,
+			"data":       string(instance.Data),
+			"last_error": instance.LastError,
+			"updated_at": instance.UpdatedAt,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update saga: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return saga.ErrSagaNotFound
+	}
+	return nil
+}
+
+// Lease reserva a instância para owner com um UPDATE condicional: só um
+// processo vence quando vários tentam assumir a mesma lease expirada
+func (s *gormSagaStore) Lease(ctx context.Context, id, owner string, until time.Time) (bool, error) {
+	result := database.Conn(ctx, s.db).Model(&database.SagaInstanceModel{}).
+		Where("id = ?", id).
+		Where("owner = ? OR lease_expires_at IS NULL OR lease_expires_at < ?", owner, time.Now()).
+		UpdateColumns(map[string]interface{}{
+			"owner":            owner,
+			"lease_expires_at": until,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to lease saga: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		if _, err := s.Get(ctx, id); err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+// Get busca uma instância pelo ID
+func (s *gormSagaStore) Get(ctx context.Context, id string) (*saga.Instance, error) {
+	var model database.SagaInstanceModel
+	if err := database.Conn(ctx, s.db).Where("id = ?", id).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, saga.ErrSagaNotFound
+		}
+		return nil, fmt.Errorf("failed to get saga: %w", err)
+	}
+	return fromModel(&model), nil
+}
+
+// List lista instâncias com filtros e paginação
+func (s *gormSagaStore) List(ctx context.Context, filter saga.Filter) ([]*saga.Instance, error) {
+	query := database.Conn(ctx, s.db).Model(&database.SagaInstanceModel{}).Order("created_at ASC")
+
+	if filter.Name != nil {
+		query = query.Where("name = ?", *filter.Name)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", string(*filter.Status))
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	return find(query)
+}
+
+// ListUnfinished lista as instâncias em execução ou em compensação
+func (s *gormSagaStore) ListUnfinished(ctx context.Context) ([]*saga.Instance, error) {
+	query := database.Conn(ctx, s.db).Model(&database.SagaInstanceModel{}).
+		Where("status IN ?", []string{string(saga.StatusRunning), string(saga.StatusCompensating)}).
+		Order("created_at ASC")
+
+	return find(query)
+}
+
+func find(query *gorm.DB) ([]*saga.Instance, error) {
+	var models []database.SagaInstanceModel
+	if err := query.Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list sagas: %w", err)
+	}
+
+	instances := make([]*saga.Instance, len(models))
+	for i := range models {
+		instances[i] = fromModel(&models[i])
+	}
+	return instances, nil
+}
+
+func toModel(instance *saga.Instance) *database.SagaInstanceModel {
+	return &database.SagaInstanceModel{
+		ID:             instance.ID,
+		Name:           instance.Name,
+		Status:         string(instance.Status),
+		Step:           instance.Step,
+		Data:           string(instance.Data),
+		LastError:      instance.LastError,
+		Owner:          instance.Owner,
+		LeaseExpiresAt: instance.LeaseExpiresAt,
+		CreatedAt:      instance.CreatedAt,
+		UpdatedAt:      instance.UpdatedAt,
+	}
+}
+
+func fromModel(model *database.SagaInstanceModel) *saga.Instance {
+	return &saga.Instance{
+		ID:             model.ID,
+		Name:           model.Name,
+		Status:         saga.Status(model.Status),
+		Step:           model.Step,
+		Data:           json.RawMessage(model.Data),
+		LastError:      model.LastError,
+		Owner:          model.Owner,
+		LeaseExpiresAt: model.LeaseExpiresAt,
+		CreatedAt:      model.CreatedAt,
+		UpdatedAt:      model.UpdatedAt,
+	}
+}
@@ -0,0 +1,65 @@
+package saga_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-modular-monolith/internal/shared/database"
+	"go-modular-monolith/internal/shared/database/databasetest"
+	sagaStore "go-modular-monolith/internal/shared/saga"
+	"go-modular-monolith/pkg/migrate"
+	"go-modular-monolith/pkg/saga"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGormStoreLease(t *testing.T) {
+	for name, url := range databasetest.URLs() {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			db := databasetest.Open(t, url, migrate.Source{Module: database.SharedMigrationsModule, FS: database.Migrations()})
+			store := sagaStore.NewGormStore(db)
+
+			id := "lease-" + time.Now().Format("150405.000000000")
+			require.NoError(t, store.Create(ctx, &saga.Instance{
+				ID:        id,
+				Name:      "transfer",
+				Status:    saga.StatusRunning,
+				Data:      []byte("{}"),
+				CreatedAt: time.Now(),
+				UpdatedAt: time.Now(),
+			}))
+
+			leased, err := store.Lease(ctx, id, "replica-a", time.Now().Add(time.Minute))
+			require.NoError(t, err)
+			assert.True(t, leased)
+
+			leased, err = store.Lease(ctx, id, "replica-b", time.Now().Add(time.Minute))
+			require.NoError(t, err)
+			assert.False(t, leased, "another owner holds a valid lease")
+
+			// Renovar e gravar o progresso não tiram a lease do dono
+			leased, err = store.Lease(ctx, id, "replica-a", time.Now().Add(time.Minute))
+			require.NoError(t, err)
+			assert.True(t, leased)
+			require.NoError(t, store.Update(ctx, &saga.Instance{ID: id, Status: saga.StatusCompleted, Step: 1, Data: []byte("{}")}))
+
+			stored, err := store.Get(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, "replica-a", stored.Owner)
+			require.NotNil(t, stored.LeaseExpiresAt)
+
+			// Liberada, a lease pode ser assumida por outro processo
+			_, err = store.Lease(ctx, id, "replica-a", time.Now().Add(-time.Second))
+			require.NoError(t, err)
+			leased, err = store.Lease(ctx, id, "replica-b", time.Now().Add(time.Minute))
+			require.NoError(t, err)
+			assert.True(t, leased)
+
+			_, err = store.Lease(ctx, "missing", "replica-a", time.Now())
+			assert.ErrorIs(t, err, saga.ErrSagaNotFound)
+		})
+	}
+}
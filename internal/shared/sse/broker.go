@@ -0,0 +1,241 @@
+package sse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/events"
+)
+
+// ResetEventType é enviado quando o Last-Event-ID já saiu do buffer e
+// eventos podem ter sido perdidos; o cliente deve recarregar o estado
+const ResetEventType = "stream.reset"
+
+// BrokerConfig contém as configurações do broker
+type BrokerConfig struct {
+	BufferSize   int // Mensagens mantidas para retomada via Last-Event-ID
+	ClientBuffer int // Mensagens enfileiradas por cliente antes de desconectá-lo
+}
+
+// Message é uma mensagem SSE destinada a um stream
+type Message struct {
+	ID    uint64
+	Event string
+	Data  []byte
+	key   string
+}
+
+// Client é uma conexão inscrita em um stream
+type Client struct {
+	key      string
+	messages chan Message
+}
+
+// Messages retorna o canal de mensagens; ele é fechado quando o broker
+// desconecta o cliente (cliente lento ou shutdown)
+func (c *Client) Messages() <-chan Message {
+	return c.messages
+}
+
+// Broker distribui eventos do EventBus para os clientes SSE conectados.
+// Mantém um buffer circular global e limitado para retomada de conexões.
+type Broker struct {
+	config BrokerConfig
+
+	mu      sync.Mutex
+	seq     uint64
+	buffer  []Message // Buffer circular, ordenado a partir de head
+	head    int
+	clients map[string]map[*Client]struct{}
+	closed  bool
+}
+
+// NewBroker cria um novo broker SSE
+func NewBroker(config BrokerConfig) *Broker {
+	if config.BufferSize <= 0 {
+		config.BufferSize = 1000
+	}
+	if config.ClientBuffer <= 0 {
+		config.ClientBuffer = 32
+	}
+
+	return &Broker{
+		config:  config,
+		buffer:  make([]Message, 0, config.BufferSize),
+		clients: make(map[string]map[*Client]struct{}),
+	}
+}
+
+// OrderStream identifica o stream de pedidos de um usuário
+func OrderStream(userID string) string {
+	return "orders:" + userID
+}
+
+// StockStream identifica o stream de estoque de um produto
+func StockStream(productID string) string {
+	return "stock:" + productID
+}
+
+// HandleEvent é inscrito no EventBus e roteia o evento para o stream correspondente
+func (b *Broker) HandleEvent(ctx context.Context, event contracts.Event) error {
+	key, ok := streamKey(event)
+	if !ok {
+		return nil
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode stream event: %w", err)
+	}
+
+	b.Publish(key, event.Type, data)
+	return nil
+}
+
+// Publish grava a mensagem no buffer e a envia aos clientes do stream.
+// Clientes com a fila cheia são desconectados e retomam pelo Last-Event-ID.
+func (b *Broker) Publish(key, eventType string, data []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	b.seq++
+	msg := Message{ID: b.seq, Event: eventType, Data: data, key: key}
+
+	if len(b.buffer) < b.config.BufferSize {
+		b.buffer = append(b.buffer, msg)
+	} else {
+		b.buffer[b.head] = msg
+		b.head = (b.head + 1) % b.config.BufferSize
+	}
+
+	for client := range b.clients[key] {
+		select {
+		case client.messages <- msg:
+		default:
+			b.removeLocked(client)
+		}
+	}
+}
+
+// Subscribe conecta um cliente ao stream e retorna as mensagens do buffer
+// posteriores a lastEventID. Com lastEventID zero nada é reenviado.
+func (b *Broker) Subscribe(key string, lastEventID uint64) (*Client, []Message, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, nil, fmt.Errorf("event stream is closed")
+	}
+
+	client := &Client{key: key, messages: make(chan Message, b.config.ClientBuffer)}
+	if b.clients[key] == nil {
+		b.clients[key] = make(map[*Client]struct{})
+	}
+	b.clients[key][client] = struct{}{}
+
+	if lastEventID == 0 || lastEventID == b.seq {
+		return client, nil, nil
+	}
+
+	// IDs maiores que a sequência atual vêm de antes de um restart do servidor
+	if lastEventID > b.seq {
+		return client, []Message{resetMessage(b.seq)}, nil
+	}
+
+	var backlog []Message
+	if oldest := b.oldestLocked(); lastEventID+1 < oldest {
+		backlog = append(backlog, resetMessage(oldest-1))
+	}
+
+	for i := 0; i < len(b.buffer); i++ {
+		msg := b.buffer[(b.head+i)%len(b.buffer)]
+		if msg.key == key && msg.ID > lastEventID {
+			backlog = append(backlog, msg)
+		}
+	}
+
+	return client, backlog, nil
+}
+
+// Unsubscribe desconecta o cliente
+func (b *Broker) Unsubscribe(client *Client) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.removeLocked(client)
+}
+
+// Close desconecta todos os clientes; usado no shutdown para liberar as conexões
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for _, clients := range b.clients {
+		for client := range clients {
+			b.removeLocked(client)
+		}
+	}
+}
+
+func (b *Broker) removeLocked(client *Client) {
+	clients, exists := b.clients[client.key]
+	if !exists {
+		return
+	}
+	if _, subscribed := clients[client]; !subscribed {
+		return
+	}
+
+	delete(clients, client)
+	close(client.messages)
+	if len(clients) == 0 {
+		delete(b.clients, client.key)
+	}
+}
+
+func resetMessage(id uint64) Message {
+	return Message{ID: id, Event: ResetEventType, Data: []byte("{}")}
+}
+
+func (b *Broker) oldestLocked() uint64 {
+	if len(b.buffer) == 0 {
+		return 0
+	}
+	return b.buffer[b.head].ID
+}
+
+// streamKey identifica o stream de destino pelo tipo e payload do evento
+func streamKey(event contracts.Event) (string, bool) {
+	switch payload := event.Payload.(type) {
+	case contracts.OrderCreatedEvent:
+		return OrderStream(payload.UserID), true
+	case *contracts.OrderCreatedEvent:
+		return OrderStream(payload.UserID), true
+	case contracts.OrderStatusUpdatedEvent:
+		return OrderStream(payload.UserID), true
+	case *contracts.OrderStatusUpdatedEvent:
+		return OrderStream(payload.UserID), true
+	case contracts.OrderCancelledEvent:
+		return OrderStream(payload.UserID), true
+	case *contracts.OrderCancelledEvent:
+		return OrderStream(payload.UserID), true
+	case contracts.ProductStockUpdatedEvent:
+		return StockStream(payload.ProductID), true
+	case *contracts.ProductStockUpdatedEvent:
+		return StockStream(payload.ProductID), true
+	}
+	return "", false
+}
+
+// StreamedEventTypes são os padrões inscritos pelo broker no EventBus
+var StreamedEventTypes = []string{
+	"order.*",
+	events.ProductStockUpdatedEventType,
+}
@@ -0,0 +1,153 @@
+package sse_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-modular-monolith/internal/modules/user/adapters"
+	"go-modular-monolith/internal/shared/sse"
+	"go-modular-monolith/pkg/contracts"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func messageIDs(messages []sse.Message) []uint64 {
+	ids := make([]uint64, 0, len(messages))
+	for _, msg := range messages {
+		ids = append(ids, msg.ID)
+	}
+	return ids
+}
+
+func TestBrokerResumesFromLastEventID(t *testing.T) {
+	broker := sse.NewBroker(sse.BrokerConfig{BufferSize: 10})
+	defer broker.Close()
+
+	for i := 0; i < 3; i++ {
+		broker.Publish(sse.OrderStream("user-1"), "order.created", []byte("{}"))
+		broker.Publish(sse.OrderStream("user-2"), "order.created", []byte("{}"))
+	}
+
+	// IDs 1, 3 e 5 são do user-1; a retomada traz só os posteriores do mesmo stream
+	_, backlog, err := broker.Subscribe(sse.OrderStream("user-1"), 1)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{3, 5}, messageIDs(backlog))
+
+	_, backlog, err = broker.Subscribe(sse.OrderStream("user-1"), 0)
+	require.NoError(t, err)
+	assert.Empty(t, backlog, "new connections start from now")
+
+	_, backlog, err = broker.Subscribe(sse.OrderStream("user-1"), 6)
+	require.NoError(t, err)
+	assert.Empty(t, backlog, "already up to date")
+}
+
+func TestBrokerRingBufferOverwritesOldestMessages(t *testing.T) {
+	broker := sse.NewBroker(sse.BrokerConfig{BufferSize: 3})
+	defer broker.Close()
+
+	for i := 0; i < 5; i++ {
+		broker.Publish(sse.StockStream("product-1"), "product.stock.updated", []byte("{}"))
+	}
+
+	// Os IDs 1 e 2 foram sobrescritos: o cliente recebe o reset antes do restante
+	_, backlog, err := broker.Subscribe(sse.StockStream("product-1"), 1)
+	require.NoError(t, err)
+	require.Len(t, backlog, 4)
+	assert.Equal(t, sse.ResetEventType, backlog[0].Event)
+	assert.Equal(t, []uint64{2, 3, 4, 5}, messageIDs(backlog))
+
+	// Quem ainda tinha o ID 2 não perdeu nada
+	_, backlog, err = broker.Subscribe(sse.StockStream("product-1"), 2)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{3, 4, 5}, messageIDs(backlog))
+
+	// IDs acima da sequência vêm de antes de um restart
+	_, backlog, err = broker.Subscribe(sse.StockStream("product-1"), 42)
+	require.NoError(t, err)
+	require.Len(t, backlog, 1)
+	assert.Equal(t, sse.ResetEventType, backlog[0].Event)
+}
+
+func TestBrokerRoutesOrderEventsToTheOwner(t *testing.T) {
+	broker := sse.NewBroker(sse.BrokerConfig{})
+	defer broker.Close()
+
+	client, _, err := broker.Subscribe(sse.OrderStream("user-1"), 0)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, broker.HandleEvent(ctx, contracts.Event{Type: "order.created", Payload: contracts.OrderCreatedEvent{OrderID: "order-2", UserID: "user-2"}}))
+	require.NoError(t, broker.HandleEvent(ctx, contracts.Event{Type: "order.created", Payload: contracts.OrderCreatedEvent{OrderID: "order-1", UserID: "user-1"}}))
+	require.NoError(t, broker.HandleEvent(ctx, contracts.Event{Type: "user.created", Payload: contracts.UserCreatedEvent{UserID: "user-1"}}))
+
+	select {
+	case msg := <-client.Messages():
+		assert.Equal(t, uint64(2), msg.ID)
+		assert.Contains(t, string(msg.Data), "order-1")
+	case <-time.After(time.Second):
+		t.Fatal("order event not delivered")
+	}
+	select {
+	case msg := <-client.Messages():
+		t.Fatalf("unexpected message %d", msg.ID)
+	default:
+	}
+}
+
+func TestBrokerDisconnectsSlowClients(t *testing.T) {
+	broker := sse.NewBroker(sse.BrokerConfig{ClientBuffer: 1})
+	defer broker.Close()
+
+	client, _, err := broker.Subscribe(sse.StockStream("product-1"), 0)
+	require.NoError(t, err)
+
+	broker.Publish(sse.StockStream("product-1"), "product.stock.updated", []byte("{}"))
+	broker.Publish(sse.StockStream("product-1"), "product.stock.updated", []byte("{}"))
+
+	<-client.Messages()
+	_, open := <-client.Messages()
+	assert.False(t, open, "the channel is closed so the client reconnects with Last-Event-ID")
+}
+
+// streamOrders chama StreamOrders com o contexto já cancelado: a resposta
+// contém só o backlog posterior ao Last-Event-ID
+func streamOrders(handler *sse.Handler, token string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stream/orders?access_token="+token, nil).WithContext(ctx)
+	req.Header.Set("Last-Event-ID", "1")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	handler.StreamOrders(c)
+	return w
+}
+
+func TestStreamOrdersOnlyServesTheTokenOwner(t *testing.T) {
+	broker := sse.NewBroker(sse.BrokerConfig{})
+	defer broker.Close()
+	tokens := adapters.NewHMACTokenGenerator("secret", time.Minute, time.Hour)
+	handler := sse.NewHandler(broker, tokens, time.Minute)
+
+	broker.Publish(sse.OrderStream("user-1"), "order.created", []byte(`{"order_id":"order-0"}`))
+	broker.Publish(sse.OrderStream("user-1"), "order.created", []byte(`{"order_id":"order-1"}`))
+	broker.Publish(sse.OrderStream("user-2"), "order.created", []byte(`{"order_id":"order-2"}`))
+
+	token, err := tokens.GenerateAccessToken("user-1")
+	require.NoError(t, err)
+	w := streamOrders(handler, token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "order-1")
+	assert.NotContains(t, w.Body.String(), "order-2")
+
+	assert.Equal(t, http.StatusUnauthorized, streamOrders(handler, "access_token_for_user-2").Code)
+	assert.Equal(t, http.StatusUnauthorized, streamOrders(handler, "").Code)
+}
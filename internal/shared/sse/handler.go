@@ -0,0 +1,117 @@
+package sse
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-modular-monolith/pkg/contracts"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler expõe os streams SSE de pedidos e de estoque
+type Handler struct {
+	broker    *Broker
+	tokens    contracts.TokenGenerator
+	heartbeat time.Duration
+}
+
+// NewHandler cria uma nova instância do handler
+func NewHandler(broker *Broker, tokens contracts.TokenGenerator, heartbeat time.Duration) *Handler {
+	if heartbeat <= 0 {
+		heartbeat = 15 * time.Second
+	}
+
+	return &Handler{
+		broker:    broker,
+		tokens:    tokens,
+		heartbeat: heartbeat,
+	}
+}
+
+// StreamOrders envia as mudanças dos pedidos do usuário autenticado.
+// O token vem do cabeçalho Authorization ou do parâmetro access_token,
+// já que o EventSource do navegador não permite cabeçalhos customizados.
+func (h *Handler) StreamOrders(c *gin.Context) {
+	userID, err := h.tokens.ValidateToken(bearerToken(c))
+	if err != nil || userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or missing access token"})
+		return
+	}
+
+	h.serve(c, OrderStream(userID))
+}
+
+// StreamProductStock envia as atualizações de estoque de um produto
+func (h *Handler) StreamProductStock(c *gin.Context) {
+	h.serve(c, StockStream(c.Param("id")))
+}
+
+func (h *Handler) serve(c *gin.Context, key string) {
+	client, backlog, err := h.broker.Subscribe(key, lastEventID(c))
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		return
+	}
+	defer h.broker.Unsubscribe(client)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	w := c.Writer
+	fmt.Fprintf(w, "retry: %d\n\n", (3 * time.Second).Milliseconds())
+	for _, msg := range backlog {
+		writeMessage(w, msg)
+	}
+	w.Flush()
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case msg, ok := <-client.Messages():
+			if !ok {
+				return
+			}
+			writeMessage(w, msg)
+		case <-ticker.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+		}
+		w.Flush()
+	}
+}
+
+func writeMessage(w gin.ResponseWriter, msg Message) {
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", msg.ID, msg.Event, msg.Data)
+}
+
+// lastEventID lê o cabeçalho enviado pelo EventSource na reconexão
+// ou o parâmetro last_event_id, usado na primeira conexão
+func lastEventID(c *gin.Context) uint64 {
+	value := c.GetHeader("Last-Event-ID")
+	if value == "" {
+		value = c.Query("last_event_id")
+	}
+
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+func bearerToken(c *gin.Context) string {
+	if header := c.GetHeader("Authorization"); strings.HasPrefix(header, "Bearer ") {
+		return strings.TrimPrefix(header, "Bearer ")
+	}
+	return c.Query("access_token")
+}
@@ -4,18 +4,26 @@ import (
 	"fmt"
 	"reflect"
 	"sync"
+	"sync/atomic"
 )
 
 // Container é um simples DI container
 type Container struct {
-	services map[string]interface{}
-	mu       sync.RWMutex
+	services  map[string]interface{}
+	providers map[typeKey]*singleton // Registros tipados (Provide/Resolve)
+	scoped    map[string]*scopedProvider
+	hooks     []Hook // Hooks de ciclo de vida em ordem de registro
+	started   bool
+	trace     *buildTrace // Não nil enquanto Validate constrói os singletons
+	mu        sync.RWMutex
 }
 
 // NewContainer cria uma nova instância do container
 func NewContainer() *Container {
 	return &Container{
-		services: make(map[string]interface{}),
+		services:  make(map[string]interface{}),
+		providers: make(map[typeKey]*singleton),
+		scoped:    make(map[string]*scopedProvider),
 	}
 }
 
@@ -26,34 +34,43 @@ func (c *Container) Register(name string, service interface{}) {
 	c.services[name] = service
 }
 
-// RegisterSingleton registra um singleton no container
-func (c *Container) RegisterSingleton(name string, factory func() interface{}) {
+// RegisterSingleton registra um singleton no container. DependsOn precisa
+// listar tudo o que a factory resolve; Validate constrói o singleton e acusa
+// as dependências resolvidas sem declaração.
+func (c *Container) RegisterSingleton(name string, factory func() interface{}, opts ...Option) {
+	o := newOptions(opts)
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	// Lazy initialization - o serviço só é criado quando solicitado
 	c.services[name] = &singleton{
-		factory: factory,
-		once:    &sync.Once{},
+		name:     name,
+		factory:  func() (interface{}, error) { return factory(), nil },
+		deps:     o.deps,
+		optional: o.optional,
+		once:     &sync.Once{},
 	}
 }
 
 // Get obtém um serviço do container
 func (c *Container) Get(name string) (interface{}, error) {
 	c.mu.RLock()
-	defer c.mu.RUnlock()
-
 	service, exists := c.services[name]
+	trace := c.trace
+	c.mu.RUnlock()
+
+	trace.resolved(name)
 	if !exists {
 		return nil, fmt.Errorf("service '%s' not found", name)
 	}
 
-	// Se for um singleton, inicializa se necessário
+	// Se for um singleton, inicializa se necessário. A factory roda sem o lock:
+	// ela resolve as próprias dependências e pode registrar hooks.
 	if s, ok := service.(*singleton); ok {
-		s.once.Do(func() {
-			s.instance = s.factory()
-		})
-		return s.instance, nil
+		trace.enter(name)
+		defer trace.leave()
+		return s.get()
 	}
 
 	return service, nil
@@ -92,7 +109,28 @@ func (c *Container) GetAs(name string, target interface{}) error {
 }
 
 type singleton struct {
-	factory  func() interface{}
+	name     string
+	factory  func() (interface{}, error)
+	deps     []string // Dependências declaradas (IDs dos nós do grafo)
+	optional []string // Dependências que podem não estar registradas
 	instance interface{}
+	err      error
+	built    atomic.Bool
 	once     *sync.Once
 }
+
+// get cria a instância na primeira chamada; o erro da factory também é memorizado.
+// Um panic na factory (por exemplo, MustGet de uma dependência ausente) vira
+// erro em vez de deixar o singleton nil para sempre.
+func (s *singleton) get() (interface{}, error) {
+	s.once.Do(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				s.err = fmt.Errorf("failed to build service '%s': %v", s.name, r)
+			}
+			s.built.Store(true)
+		}()
+		s.instance, s.err = s.factory()
+	})
+	return s.instance, s.err
+}
@@ -0,0 +1,402 @@
+package container_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-modular-monolith/pkg/container"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type greeter interface {
+	Greet() string
+}
+
+type english struct{}
+
+func (english) Greet() string { return "hello" }
+
+type portuguese struct{}
+
+func (portuguese) Greet() string { return "olá" }
+
+type service struct {
+	greeter greeter
+}
+
+func TestResolveByType(t *testing.T) {
+	c := container.NewContainer()
+
+	builds := 0
+	container.Provide(c, func(c *container.Container) (greeter, error) {
+		builds++
+		return english{}, nil
+	})
+	container.Provide(c, func(c *container.Container) (*service, error) {
+		g, err := container.Resolve[greeter](c)
+		if err != nil {
+			return nil, err
+		}
+		return &service{greeter: g}, nil
+	})
+
+	svc, err := container.Resolve[*service](c)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", svc.greeter.Greet())
+
+	// Singleton: a factory roda uma única vez
+	again := container.MustResolve[*service](c)
+	assert.Same(t, svc, again)
+	container.MustResolve[greeter](c)
+	assert.Equal(t, 1, builds)
+}
+
+func TestResolveByName(t *testing.T) {
+	c := container.NewContainer()
+	container.ProvideValue[greeter](c, english{})
+	container.ProvideValue[greeter](c, portuguese{}, container.WithName("pt"))
+
+	assert.Equal(t, "hello", container.MustResolve[greeter](c).Greet())
+	assert.Equal(t, "olá", container.MustResolve[greeter](c, container.WithName("pt")).Greet())
+}
+
+func TestResolveMissingRegistration(t *testing.T) {
+	c := container.NewContainer()
+	container.ProvideValue[greeter](c, portuguese{}, container.WithName("pt"))
+
+	_, err := container.Resolve[*service](c)
+	require.ErrorIs(t, err, container.ErrNotFound)
+	assert.Contains(t, err.Error(), "no provider for *container_test.service")
+
+	_, err = container.Resolve[greeter](c, container.WithName("en"))
+	require.ErrorIs(t, err, container.ErrNotFound)
+	assert.Contains(t, err.Error(), `container_test.greeter (name "en")`)
+	assert.Contains(t, err.Error(), `registered names: ["pt"]`)
+
+	assert.PanicsWithError(t, err.Error(), func() {
+		container.MustResolve[greeter](c, container.WithName("en"))
+	})
+}
+
+func TestResolveFactoryError(t *testing.T) {
+	c := container.NewContainer()
+	container.Provide(c, func(c *container.Container) (*service, error) {
+		g, err := container.Resolve[greeter](c)
+		return &service{greeter: g}, err
+	})
+
+	_, err := container.Resolve[*service](c)
+	require.ErrorIs(t, err, container.ErrNotFound)
+	assert.Contains(t, err.Error(), "failed to build *container_test.service")
+	assert.Contains(t, err.Error(), "no provider for container_test.greeter")
+}
+
+func TestResolveNilFactoryResult(t *testing.T) {
+	c := container.NewContainer()
+	container.Provide(c, func(c *container.Container) (greeter, error) {
+		return nil, nil
+	})
+
+	var err error
+	require.NotPanics(t, func() {
+		_, err = container.Resolve[greeter](c)
+	})
+	require.ErrorIs(t, err, container.ErrTypeMismatch)
+	assert.EqualError(t, err, "service has unexpected type: provider for container_test.greeter returned <nil>")
+}
+
+func TestLookupChecksType(t *testing.T) {
+	c := container.NewContainer()
+	c.RegisterSingleton("greeter", func() interface{} { return english{} })
+
+	g, err := container.Lookup[greeter](c, "greeter")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", g.Greet())
+
+	_, err = container.Lookup[*service](c, "greeter")
+	require.ErrorIs(t, err, container.ErrTypeMismatch)
+	assert.EqualError(t, err, "service has unexpected type: service 'greeter' is container_test.english, not *container_test.service")
+
+	_, err = container.Lookup[greeter](c, "greter")
+	require.ErrorIs(t, err, container.ErrNotFound)
+	assert.Contains(t, err.Error(), "service 'greter' not found")
+}
+
+func TestLifecycleHooksFollowDependencyOrder(t *testing.T) {
+	c := container.NewContainer()
+
+	var calls []string
+	hook := func(name string) container.Hook {
+		return container.Hook{
+			Name: name,
+			OnStart: func(context.Context) error {
+				calls = append(calls, "start "+name)
+				return nil
+			},
+			OnStop: func(context.Context) error {
+				calls = append(calls, "stop "+name)
+				return nil
+			},
+		}
+	}
+
+	// database <- repository <- service: os hooks são registrados depois das dependências
+	c.RegisterSingleton("database", func() interface{} {
+		c.Append(hook("database"))
+		return "db"
+	})
+	c.RegisterSingleton("repository", func() interface{} {
+		c.MustGet("database")
+		c.Append(hook("repository"))
+		return "repo"
+	})
+	c.RegisterSingleton("service", func() interface{} {
+		c.MustGet("repository")
+		c.Append(hook("service"))
+		return "svc"
+	})
+	c.MustGet("service")
+
+	require.NoError(t, c.Start(context.Background()))
+	require.NoError(t, c.Stop(context.Background()))
+
+	assert.Equal(t, []string{
+		"start database", "start repository", "start service",
+		"stop service", "stop repository", "stop database",
+	}, calls)
+}
+
+func TestLifecycleStartFailureStopsStartedHooks(t *testing.T) {
+	c := container.NewContainer()
+
+	var stopped []string
+	c.Append(
+		container.Hook{Name: "a", OnStop: func(context.Context) error {
+			stopped = append(stopped, "a")
+			return nil
+		}},
+		container.Hook{Name: "b", OnStart: func(context.Context) error {
+			return errors.New("port in use")
+		}},
+		container.Hook{Name: "c", OnStop: func(context.Context) error {
+			stopped = append(stopped, "c")
+			return nil
+		}},
+	)
+
+	err := c.Start(context.Background())
+	assert.EqualError(t, err, "start hook of b failed: port in use")
+	assert.Equal(t, []string{"a"}, stopped)
+}
+
+func TestLifecycleHookTimeout(t *testing.T) {
+	c := container.NewContainer()
+
+	stopped := false
+	c.Append(
+		container.Hook{Name: "fast", OnStop: func(context.Context) error {
+			stopped = true
+			return nil
+		}},
+		container.Hook{
+			Name:    "stuck",
+			Timeout: 10 * time.Millisecond,
+			OnStop: func(context.Context) error {
+				time.Sleep(time.Second) // Ignora o contexto
+				return nil
+			},
+		},
+	)
+
+	require.NoError(t, c.Start(context.Background()))
+
+	start := time.Now()
+	err := c.Stop(context.Background())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "stop hook of stuck did not finish")
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.True(t, stopped, "hooks seguintes rodam mesmo após uma falha")
+}
+
+func TestValidateReportsMissingDependenciesAndCycles(t *testing.T) {
+	c := container.NewContainer()
+	c.Register("config", "cfg")
+	c.RegisterSingleton("database", func() interface{} { return "db" }, container.DependsOn("config"))
+	c.RegisterSingleton("repository", func() interface{} { return "repo" }, container.DependsOn("database", "cache"))
+	c.RegisterSingleton("a", func() interface{} { return "a" }, container.DependsOn("b"))
+	c.RegisterSingleton("b", func() interface{} { return "b" }, container.DependsOn("a"))
+	container.Provide(c, func(c *container.Container) (*service, error) {
+		return &service{}, nil
+	}, container.DependsOnType[greeter]())
+
+	err := c.Validate()
+	require.ErrorIs(t, err, container.ErrMissingDependency)
+	require.ErrorIs(t, err, container.ErrDependencyCycle)
+	assert.Contains(t, err.Error(), "'repository' depends on 'cache', which is not registered")
+	assert.Contains(t, err.Error(), "'*container_test.service' depends on 'container_test.greeter', which is not registered")
+	assert.Contains(t, err.Error(), "dependency cycle: a -> b -> a")
+
+	dot := c.Graph().DOT()
+	assert.Contains(t, dot, `"repository" -> "database";`)
+	assert.Contains(t, dot, `"cache" [color=red, fontcolor=red];`)
+
+	c.RegisterSingleton("cache", func() interface{} { return "cache" })
+	container.ProvideValue[greeter](c, english{})
+	c.RegisterSingleton("b", func() interface{} { return "b" })
+	assert.NoError(t, c.Validate())
+}
+
+func TestFactoryPanicBecomesError(t *testing.T) {
+	c := container.NewContainer()
+	c.RegisterSingleton("service", func() interface{} {
+		return c.MustGet("missing")
+	})
+
+	_, err := c.Get("service")
+	assert.EqualError(t, err, "failed to build service 'service': service 'missing' not found")
+
+	// A falha é memorizada em vez de devolver nil nas próximas resoluções
+	_, err = c.Get("service")
+	assert.Error(t, err)
+}
+
+// Validate constrói os singletons: o grafo só conhece DependsOn, e a factory
+// pode resolver mais do que declarou
+func TestValidateBuildsSingletonsAndReportsUndeclaredDependencies(t *testing.T) {
+	c := container.NewContainer()
+	c.Register("config", "cfg")
+	c.RegisterSingleton("database", func() interface{} {
+		return c.MustGet("config").(string) + "/db"
+	}, container.DependsOn("config"))
+	c.RegisterSingleton("repository", func() interface{} {
+		return c.MustGet("database").(string) + "/repo"
+	})
+	c.RegisterSingleton("service", func() interface{} {
+		return c.MustGet("repository").(string) + "/" + c.MustGet("cache").(string)
+	}, container.DependsOn("repository"))
+	container.Provide(c, func(c *container.Container) (*service, error) {
+		return &service{greeter: container.MustResolve[greeter](c)}, nil
+	})
+	container.ProvideValue[greeter](c, english{})
+
+	err := c.Validate()
+	require.ErrorIs(t, err, container.ErrUndeclaredDependency)
+	assert.Contains(t, err.Error(), "'repository' resolves 'database' without declaring it")
+	assert.Contains(t, err.Error(), "'service' resolves 'cache' without declaring it")
+	assert.Contains(t, err.Error(), "'*container_test.service' resolves 'container_test.greeter' without declaring it")
+	assert.Contains(t, err.Error(), "failed to build service 'service': service 'cache' not found")
+	// Só a factory que resolveu é acusada, não quem a resolveu
+	assert.NotContains(t, err.Error(), "'service' resolves 'database'")
+	assert.NotContains(t, err.Error(), "'service' resolves 'config'")
+
+	for _, node := range c.Graph().Nodes {
+		assert.True(t, node.Built, node.ID)
+	}
+
+	c.RegisterSingleton("cache", func() interface{} { return "cache" })
+	c.RegisterSingleton("repository", func() interface{} {
+		return c.MustGet("database").(string) + "/repo"
+	}, container.DependsOn("database"))
+	c.RegisterSingleton("service", func() interface{} {
+		return c.MustGet("repository").(string) + "/" + c.MustGet("cache").(string)
+	}, container.DependsOn("repository", "cache"))
+	container.Provide(c, func(c *container.Container) (*service, error) {
+		return &service{greeter: container.MustResolve[greeter](c)}, nil
+	}, container.DependsOnType[greeter]())
+	require.NoError(t, c.Validate())
+	assert.Equal(t, "cfg/db/repo/cache", c.MustGet("service"))
+
+	// Fora de Validate nada é registrado
+	c.RegisterSingleton("late", func() interface{} { return c.MustGet("cache") })
+	_, err = c.Get("late")
+	require.NoError(t, err)
+	assert.NoError(t, c.Validate(), "singletons já construídos não são reconferidos")
+}
+
+func TestValidateAcceptsOptionalDependencies(t *testing.T) {
+	register := func(c *container.Container) {
+		c.RegisterSingleton("unitOfWork", func() interface{} {
+			users, err := c.Get("userRepository")
+			if err != nil {
+				return "uow"
+			}
+			return "uow+" + users.(string)
+		}, container.DependsOnOptional("userRepository"))
+	}
+
+	c := container.NewContainer()
+	register(c)
+	require.NoError(t, c.Validate(), "o módulo de usuário pode estar desligado")
+	assert.Equal(t, "uow", c.MustGet("unitOfWork"))
+
+	c = container.NewContainer()
+	register(c)
+	c.RegisterSingleton("userRepository", func() interface{} { return "users" })
+	require.NoError(t, c.Validate())
+	assert.Equal(t, "uow+users", c.MustGet("unitOfWork"))
+	assert.Contains(t, c.Graph().DOT(), `"unitOfWork" -> "userRepository" [style=dashed];`)
+
+	// Registrada, a opcional entra na verificação de ciclos
+	c.RegisterSingleton("userRepository", func() interface{} { return "users" }, container.DependsOn("unitOfWork"))
+	assert.ErrorIs(t, c.Validate(), container.ErrDependencyCycle)
+}
+
+func TestScopedServicesAreCreatedPerScopeAndDisposed(t *testing.T) {
+	c := container.NewContainer()
+	c.RegisterSingleton("logger", func() interface{} { return "logger" })
+
+	var disposed []string
+	builds := 0
+	c.RegisterScoped("unitOfWork", func(s *container.Scope) interface{} {
+		builds++
+		id := s.MustGet("requestID").(string)
+		s.OnDispose(func(context.Context) error {
+			disposed = append(disposed, "unitOfWork "+id)
+			return nil
+		})
+		return "uow-" + id
+	})
+	container.ProvideScoped(c, func(s *container.Scope) (*service, error) {
+		s.MustGet("unitOfWork") // Dependência com o mesmo escopo
+		s.OnDispose(func(context.Context) error {
+			disposed = append(disposed, "service")
+			return nil
+		})
+		return &service{}, nil
+	}, container.DependsOn("unitOfWork"))
+
+	first := c.NewScope()
+	first.Set("requestID", "1")
+	second := c.NewScope()
+	second.Set("requestID", "2")
+
+	svc := container.MustResolveScoped[*service](first)
+	assert.Same(t, svc, container.MustResolveScoped[*service](first))
+	assert.NotSame(t, svc, container.MustResolveScoped[*service](second))
+	assert.Equal(t, "uow-1", first.MustGet("unitOfWork"))
+	assert.Equal(t, "uow-2", second.MustGet("unitOfWork"))
+	assert.Equal(t, 2, builds)
+
+	// Serviços sem escopo vêm do container pai
+	assert.Equal(t, "logger", first.MustGet("logger"))
+
+	require.NoError(t, first.Dispose(context.Background()))
+	assert.Equal(t, []string{"service", "unitOfWork 1"}, disposed)
+
+	_, err := first.Get("unitOfWork")
+	assert.ErrorIs(t, err, container.ErrScopeDisposed)
+}
+
+func TestValidateRejectsSingletonDependingOnScopedService(t *testing.T) {
+	c := container.NewContainer()
+	c.RegisterScoped("currentUser", func(s *container.Scope) interface{} { return "user" })
+	c.RegisterSingleton("reportService", func() interface{} { return "report" }, container.DependsOn("currentUser"))
+
+	err := c.Validate()
+	require.ErrorIs(t, err, container.ErrScopeMismatch)
+	assert.Contains(t, err.Error(), "'reportService' depends on 'currentUser'")
+}
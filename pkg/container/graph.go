@@ -0,0 +1,359 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrMissingDependency é retornado por Validate quando uma dependência declarada não tem registro
+var ErrMissingDependency = errors.New("missing dependency")
+
+// ErrDependencyCycle é retornado por Validate quando as dependências formam um ciclo
+var ErrDependencyCycle = errors.New("dependency cycle")
+
+// ErrUndeclaredDependency é retornado por Validate quando uma factory resolve um serviço que não declarou
+var ErrUndeclaredDependency = errors.New("undeclared dependency")
+
+// Tipos de nó do grafo
+const (
+	NodeValue     = "value"     // Register
+	NodeSingleton = "singleton" // RegisterSingleton
+	NodeTyped     = "typed"     // Provide
+	NodeScoped    = "scoped"    // RegisterScoped/ProvideScoped
+)
+
+// DependsOn declara dependências registradas por nome (Register/RegisterSingleton).
+// A declaração precisa cobrir todo Get/MustGet que a factory faz.
+func DependsOn(names ...string) Option {
+	return func(o *options) {
+		o.deps = append(o.deps, names...)
+	}
+}
+
+// DependsOnOptional declara dependências por nome que podem não estar
+// registradas, como o repositório de um módulo desligado
+func DependsOnOptional(names ...string) Option {
+	return func(o *options) {
+		o.optional = append(o.optional, names...)
+	}
+}
+
+// DependsOnType declara uma dependência registrada por tipo (Provide)
+func DependsOnType[T any](opts ...Option) Option {
+	key := keyOf[T](opts).String()
+	return func(o *options) {
+		o.deps = append(o.deps, key)
+	}
+}
+
+// GraphNode é um registro do container e as dependências que ele declara
+type GraphNode struct {
+	ID           string   `json:"id"`
+	Kind         string   `json:"kind"`
+	Dependencies []string `json:"dependencies"`
+	Optional     []string `json:"optional,omitempty"` // Dependências que podem faltar
+	Built        bool     `json:"built"`              // Instância já criada
+}
+
+// Graph é o grafo de dependências declarado no container
+type Graph struct {
+	Nodes []GraphNode `json:"nodes"`
+}
+
+// Graph retorna o grafo de dependências declarado, ordenado por ID
+func (c *Container) Graph() Graph {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	nodes := make([]GraphNode, 0, len(c.services)+len(c.providers))
+	for name, service := range c.services {
+		node := GraphNode{ID: name, Kind: NodeValue, Dependencies: []string{}, Built: true}
+		if s, ok := service.(*singleton); ok {
+			node.Kind = NodeSingleton
+			node.Dependencies = append(node.Dependencies, s.deps...)
+			node.Optional = append(node.Optional, s.optional...)
+			node.Built = s.built.Load()
+		}
+		nodes = append(nodes, node)
+	}
+	for name, provider := range c.scoped {
+		nodes = append(nodes, GraphNode{
+			ID:           name,
+			Kind:         NodeScoped,
+			Dependencies: append([]string{}, provider.deps...),
+		})
+	}
+	for key, s := range c.providers {
+		nodes = append(nodes, GraphNode{
+			ID:           key.String(),
+			Kind:         NodeTyped,
+			Dependencies: append([]string{}, s.deps...),
+			Optional:     append([]string(nil), s.optional...),
+			Built:        s.built.Load(),
+		})
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID < nodes[j].ID
+	})
+	return Graph{Nodes: nodes}
+}
+
+// Validate verifica o grafo declarado: toda dependência precisa de um registro,
+// singletons não podem depender de serviços com escopo e não pode haver ciclos.
+// Com o grafo correto, constrói cada singleton ainda não criado e confere o que
+// a factory resolveu com o que declarou, já que o grafo só enxerga DependsOn.
+// Resoluções feitas depois da factory (em handlers, por exemplo) não são vistas.
+// Todos os problemas encontrados são retornados juntos.
+func (c *Container) Validate() error {
+	graph := c.Graph()
+	if err := graph.Validate(); err != nil {
+		return err
+	}
+	return c.build(graph)
+}
+
+// build constrói os singletons pendentes registrando as resoluções de cada factory
+func (c *Container) build(graph Graph) error {
+	trace := &buildTrace{resolves: make(map[string]map[string]bool)}
+	c.mu.Lock()
+	c.trace = trace
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		c.trace = nil
+		c.mu.Unlock()
+	}()
+
+	var errs []error
+	var built []GraphNode
+	for _, node := range graph.Nodes {
+		if node.Built || (node.Kind != NodeSingleton && node.Kind != NodeTyped) {
+			continue
+		}
+		built = append(built, node)
+
+		var err error
+		if node.Kind == NodeSingleton {
+			_, err = c.Get(node.ID)
+		} else {
+			err = c.buildProvider(node.ID)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, node := range built {
+		for _, dep := range trace.undeclared(node) {
+			errs = append(errs, fmt.Errorf("%w: '%s' resolves '%s' without declaring it", ErrUndeclaredDependency, node.ID, dep))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// buildProvider cria o registro tipado identificado pelo ID do nó
+func (c *Container) buildProvider(id string) error {
+	c.mu.RLock()
+	var provider *singleton
+	for key, s := range c.providers {
+		if key.String() == id {
+			provider = s
+		}
+	}
+	trace := c.trace
+	c.mu.RUnlock()
+
+	if provider == nil {
+		return fmt.Errorf("%w: no provider for %s", ErrNotFound, id)
+	}
+
+	trace.enter(id)
+	defer trace.leave()
+	_, err := provider.get()
+	return err
+}
+
+// buildTrace registra, para cada factory em execução, os serviços que ela resolve.
+// Os métodos aceitam receiver nil, o caso fora de Validate.
+type buildTrace struct {
+	mu       sync.Mutex
+	building []string // Pilha de factories em execução
+	resolves map[string]map[string]bool
+}
+
+func (t *buildTrace) enter(id string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.building = append(t.building, id)
+}
+
+func (t *buildTrace) leave() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.building = t.building[:len(t.building)-1]
+}
+
+// resolved atribui a resolução à factory no topo da pilha
+func (t *buildTrace) resolved(id string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.building) == 0 {
+		return
+	}
+	parent := t.building[len(t.building)-1]
+	if t.resolves[parent] == nil {
+		t.resolves[parent] = make(map[string]bool)
+	}
+	t.resolves[parent][id] = true
+}
+
+// undeclared lista, em ordem, o que a factory do nó resolveu sem declarar
+func (t *buildTrace) undeclared(node GraphNode) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var deps []string
+	for dep := range t.resolves[node.ID] {
+		if indexOf(node.Dependencies, dep) < 0 && indexOf(node.Optional, dep) < 0 {
+			deps = append(deps, dep)
+		}
+	}
+	sort.Strings(deps)
+	return deps
+}
+
+// Validate verifica dependências ausentes, dependências de escopo e ciclos no grafo
+func (g Graph) Validate() error {
+	nodes := make(map[string]GraphNode, len(g.Nodes))
+	for _, node := range g.Nodes {
+		nodes[node.ID] = node
+	}
+
+	var errs []error
+	for _, node := range g.Nodes {
+		for _, dep := range node.Dependencies {
+			target, exists := nodes[dep]
+			switch {
+			case !exists:
+				errs = append(errs, fmt.Errorf("%w: '%s' depends on '%s', which is not registered", ErrMissingDependency, node.ID, dep))
+			case target.Kind == NodeScoped && node.Kind != NodeScoped:
+				// O singleton capturaria a instância do primeiro escopo que o resolvesse
+				errs = append(errs, fmt.Errorf("%w: '%s' depends on '%s'", ErrScopeMismatch, node.ID, dep))
+			}
+		}
+		for _, dep := range node.Optional {
+			if target, exists := nodes[dep]; exists && target.Kind == NodeScoped && node.Kind != NodeScoped {
+				errs = append(errs, fmt.Errorf("%w: '%s' depends on '%s'", ErrScopeMismatch, node.ID, dep))
+			}
+		}
+	}
+
+	// Busca em profundidade: um nó em visita encontrado de novo fecha um ciclo
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(nodes))
+	var path []string
+
+	var visit func(id string)
+	visit = func(id string) {
+		state[id] = visiting
+		path = append(path, id)
+
+		// Ausentes já foram reportadas (ou são opcionais)
+		deps := append(append([]string{}, nodes[id].Dependencies...), nodes[id].Optional...)
+		for _, dep := range deps {
+			if _, exists := nodes[dep]; !exists {
+				continue
+			}
+			switch state[dep] {
+			case unvisited:
+				visit(dep)
+			case visiting:
+				cycle := append([]string{}, path[indexOf(path, dep):]...)
+				cycle = append(cycle, dep)
+				errs = append(errs, fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(cycle, " -> ")))
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[id] = visited
+	}
+
+	for _, node := range g.Nodes {
+		if state[node.ID] == unvisited {
+			visit(node.ID)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// DOT exporta o grafo no formato do Graphviz. Arestas apontam do serviço para
+// a dependência; dependências ausentes aparecem em vermelho.
+func (g Graph) DOT() string {
+	registered := make(map[string]bool, len(g.Nodes))
+	for _, node := range g.Nodes {
+		registered[node.ID] = true
+	}
+
+	var b strings.Builder
+	b.WriteString("digraph container {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, fontname=\"Helvetica\"];\n")
+
+	for _, node := range g.Nodes {
+		style := ""
+		switch {
+		case node.Kind == NodeValue:
+			style = ", style=rounded"
+		case node.Kind == NodeScoped:
+			style = ", style=dotted"
+		case !node.Built:
+			style = ", style=dashed"
+		}
+		fmt.Fprintf(&b, "  %q [label=%q%s];\n", node.ID, node.ID+"\n("+node.Kind+")", style)
+	}
+
+	for _, node := range g.Nodes {
+		for _, dep := range node.Dependencies {
+			if !registered[dep] {
+				fmt.Fprintf(&b, "  %q [color=red, fontcolor=red];\n", dep)
+			}
+			fmt.Fprintf(&b, "  %q -> %q;\n", node.ID, dep)
+		}
+		for _, dep := range node.Optional {
+			if registered[dep] {
+				fmt.Fprintf(&b, "  %q -> %q [style=dashed];\n", node.ID, dep)
+			}
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
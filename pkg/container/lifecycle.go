@@ -0,0 +1,116 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultHookTimeout é o prazo de um hook que não define Timeout
+const DefaultHookTimeout = 15 * time.Second
+
+// Hook associa ações de início e de parada a um serviço.
+// Qualquer uma das funções pode ser nil.
+type Hook struct {
+	Name    string
+	OnStart func(ctx context.Context) error
+	OnStop  func(ctx context.Context) error
+	Timeout time.Duration // Prazo de cada chamada; zero usa DefaultHookTimeout
+}
+
+// Append registra hooks de ciclo de vida, normalmente na factory do serviço
+// depois de resolver as dependências. Como as dependências são construídas
+// antes, a ordem de registro é a ordem de dependência: Start segue essa
+// ordem e Stop a inversa.
+//
+// Hooks registrados depois do Start (serviços resolvidos tardiamente) têm o
+// OnStart executado imediatamente; uma falha entra em pânico, como um erro de
+// construção no MustGet.
+func (c *Container) Append(hooks ...Hook) {
+	c.mu.Lock()
+	c.hooks = append(c.hooks, hooks...)
+	started := c.started
+	c.mu.Unlock()
+
+	if !started {
+		return
+	}
+	for _, hook := range hooks {
+		if err := runHook(context.Background(), hook, hook.OnStart, "start"); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Start executa os OnStart na ordem de registro. Se um hook falha, os hooks já
+// iniciados são parados em ordem inversa e o erro é retornado.
+func (c *Container) Start(ctx context.Context) error {
+	c.mu.Lock()
+	if c.started {
+		c.mu.Unlock()
+		return errors.New("container already started")
+	}
+	c.started = true
+	hooks := append([]Hook(nil), c.hooks...)
+	c.mu.Unlock()
+
+	for i, hook := range hooks {
+		if err := runHook(ctx, hook, hook.OnStart, "start"); err != nil {
+			return errors.Join(err, stopHooks(ctx, hooks[:i]))
+		}
+	}
+	return nil
+}
+
+// Stop executa os OnStop em ordem inversa à de registro. Todos os hooks são
+// chamados mesmo que algum falhe; os erros são agregados.
+func (c *Container) Stop(ctx context.Context) error {
+	c.mu.Lock()
+	hooks := append([]Hook(nil), c.hooks...)
+	c.started = false
+	c.mu.Unlock()
+
+	return stopHooks(ctx, hooks)
+}
+
+func stopHooks(ctx context.Context, hooks []Hook) error {
+	var errs []error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if err := runHook(ctx, hooks[i], hooks[i].OnStop, "stop"); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// runHook executa fn com o prazo do hook. Se o prazo expira antes de fn
+// retornar, o hook falha mesmo que fn ignore o contexto.
+func runHook(ctx context.Context, hook Hook, fn func(ctx context.Context) error, phase string) error {
+	if fn == nil {
+		return nil
+	}
+
+	timeout := hook.Timeout
+	if timeout <= 0 {
+		timeout = DefaultHookTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- fn(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("%s hook of %s failed: %w", phase, hook.Name, err)
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%s hook of %s did not finish: %w", phase, hook.Name, ctx.Err())
+	}
+}
@@ -0,0 +1,205 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrScopeMismatch é retornado por Validate quando um singleton depende de um serviço com escopo
+var ErrScopeMismatch = errors.New("singleton depends on scoped service")
+
+// ErrScopeDisposed é retornado ao resolver em um escopo já descartado
+var ErrScopeDisposed = errors.New("scope already disposed")
+
+// scopedProvider cria uma instância por escopo
+type scopedProvider struct {
+	factory func(s *Scope) (interface{}, error)
+	deps    []string
+}
+
+// RegisterScoped registra um serviço criado uma vez por escopo (por exemplo,
+// por requisição HTTP). A factory recebe o escopo para resolver dependências
+// e registrar a limpeza com OnDispose.
+func (c *Container) RegisterScoped(name string, factory func(s *Scope) interface{}, opts ...Option) {
+	o := newOptions(opts)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.scoped[name] = &scopedProvider{
+		factory: func(s *Scope) (interface{}, error) { return factory(s), nil },
+		deps:    o.deps,
+	}
+}
+
+// ProvideScoped registra um serviço com escopo identificado pelo tipo T
+func ProvideScoped[T any](c *Container, factory func(s *Scope) (T, error), opts ...Option) {
+	key := keyOf[T](opts)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.scoped[key.String()] = &scopedProvider{
+		factory: func(s *Scope) (interface{}, error) {
+			instance, err := factory(s)
+			if err != nil {
+				return nil, fmt.Errorf("failed to build %s: %w", key, err)
+			}
+			return instance, nil
+		},
+		deps: newOptions(opts).deps,
+	}
+}
+
+// Scope é um escopo filho do container. Serviços com escopo são criados uma
+// vez por escopo; os demais são resolvidos no container pai.
+type Scope struct {
+	container *Container
+
+	mu        sync.Mutex
+	values    map[string]interface{}
+	instances map[string]*singleton
+	disposers []func(ctx context.Context) error
+	disposed  bool
+}
+
+// NewScope cria um escopo filho do container
+func (c *Container) NewScope() *Scope {
+	return &Scope{
+		container: c,
+		values:    make(map[string]interface{}),
+		instances: make(map[string]*singleton),
+	}
+}
+
+// Set registra um valor conhecido apenas na criação do escopo, como o ID da requisição
+func (s *Scope) Set(name string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[name] = value
+}
+
+// Container retorna o container pai
+func (s *Scope) Container() *Container {
+	return s.container
+}
+
+// Get obtém um serviço: valores do escopo, serviços com escopo (criados uma
+// vez por escopo) ou, por fim, serviços do container pai
+func (s *Scope) Get(name string) (interface{}, error) {
+	s.mu.Lock()
+	if s.disposed {
+		s.mu.Unlock()
+		return nil, ErrScopeDisposed
+	}
+	if value, exists := s.values[name]; exists {
+		s.mu.Unlock()
+		return value, nil
+	}
+
+	instance, exists := s.instances[name]
+	if !exists {
+		s.container.mu.RLock()
+		provider, scoped := s.container.scoped[name]
+		s.container.mu.RUnlock()
+
+		if !scoped {
+			s.mu.Unlock()
+			return s.container.Get(name)
+		}
+
+		instance = &singleton{
+			name:    name,
+			factory: func() (interface{}, error) { return provider.factory(s) },
+			once:    &sync.Once{},
+		}
+		s.instances[name] = instance
+	}
+	s.mu.Unlock()
+
+	// A factory roda sem o lock: ela pode resolver outros serviços do escopo
+	return instance.get()
+}
+
+// MustGet obtém um serviço do escopo ou entra em pânico
+func (s *Scope) MustGet(name string) interface{} {
+	service, err := s.Get(name)
+	if err != nil {
+		panic(err)
+	}
+	return service
+}
+
+// OnDispose registra uma limpeza executada quando o escopo é descartado
+func (s *Scope) OnDispose(fn func(ctx context.Context) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.disposers = append(s.disposers, fn)
+}
+
+// Dispose executa as limpezas registradas em ordem inversa e invalida o escopo.
+// Chamadas seguintes não fazem nada.
+func (s *Scope) Dispose(ctx context.Context) error {
+	s.mu.Lock()
+	if s.disposed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.disposed = true
+	disposers := s.disposers
+	s.disposers = nil
+	s.mu.Unlock()
+
+	var errs []error
+	for i := len(disposers) - 1; i >= 0; i-- {
+		if err := disposers[i](ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ResolveScoped obtém o serviço do tipo T no escopo: registros com escopo
+// (ProvideScoped) ou, na ausência deles, registros tipados do container pai
+func ResolveScoped[T any](s *Scope, opts ...Option) (T, error) {
+	var zero T
+	key := keyOf[T](opts)
+
+	s.container.mu.RLock()
+	_, scoped := s.container.scoped[key.String()]
+	s.container.mu.RUnlock()
+
+	if !scoped {
+		return Resolve[T](s.container, opts...)
+	}
+
+	instance, err := s.Get(key.String())
+	if err != nil {
+		return zero, err
+	}
+	return instance.(T), nil
+}
+
+// MustResolveScoped obtém o serviço do tipo T no escopo ou entra em pânico
+func MustResolveScoped[T any](s *Scope, opts ...Option) T {
+	instance, err := ResolveScoped[T](s, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return instance
+}
+
+type scopeContextKey struct{}
+
+// ContextWithScope retorna um contexto que carrega o escopo
+func ContextWithScope(ctx context.Context, s *Scope) context.Context {
+	return context.WithValue(ctx, scopeContextKey{}, s)
+}
+
+// ScopeFromContext obtém o escopo carregado pelo contexto, se houver
+func ScopeFromContext(ctx context.Context) (*Scope, bool) {
+	s, ok := ctx.Value(scopeContextKey{}).(*Scope)
+	return s, ok
+}
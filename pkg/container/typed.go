@@ -0,0 +1,161 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// ErrNotFound é retornado quando não há registro para o tipo (e nome) pedido
+var ErrNotFound = errors.New("service not found")
+
+// ErrTypeMismatch é retornado quando o serviço registrado não é do tipo pedido
+var ErrTypeMismatch = errors.New("service has unexpected type")
+
+// typeKey identifica um registro tipado: o tipo e um nome opcional
+type typeKey struct {
+	typ  reflect.Type
+	name string
+}
+
+func (k typeKey) String() string {
+	if k.name == "" {
+		return k.typ.String()
+	}
+	return fmt.Sprintf("%s (name %q)", k.typ, k.name)
+}
+
+// Option ajusta um registro ou uma resolução tipada
+type Option func(*options)
+
+type options struct {
+	name     string
+	deps     []string
+	optional []string
+}
+
+func newOptions(opts []Option) options {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithName distingue registros do mesmo tipo, como duas conexões de banco
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+func keyOf[T any](opts []Option) typeKey {
+	return typeKey{typ: reflect.TypeOf((*T)(nil)).Elem(), name: newOptions(opts).name}
+}
+
+// Provide registra um singleton identificado pelo tipo T. A factory é
+// executada na primeira resolução e recebe o container para resolver as
+// próprias dependências; um erro da factory é retornado em toda resolução.
+func Provide[T any](c *Container, factory func(c *Container) (T, error), opts ...Option) {
+	key := keyOf[T](opts)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.providers[key] = &singleton{
+		name:     key.String(),
+		deps:     newOptions(opts).deps,
+		optional: newOptions(opts).optional,
+		factory: func() (interface{}, error) {
+			instance, err := factory(c)
+			if err != nil {
+				return nil, fmt.Errorf("failed to build %s: %w", key, err)
+			}
+			return instance, nil
+		},
+		once: &sync.Once{},
+	}
+}
+
+// ProvideValue registra uma instância já construída identificada pelo tipo T
+func ProvideValue[T any](c *Container, value T, opts ...Option) {
+	Provide(c, func(*Container) (T, error) { return value, nil }, opts...)
+}
+
+// Resolve obtém o serviço registrado para o tipo T (e o nome, se informado)
+func Resolve[T any](c *Container, opts ...Option) (T, error) {
+	var zero T
+	key := keyOf[T](opts)
+
+	c.mu.RLock()
+	s, exists := c.providers[key]
+	trace := c.trace
+	c.mu.RUnlock()
+
+	trace.resolved(key.String())
+	if !exists {
+		return zero, fmt.Errorf("%w: no provider for %s%s", ErrNotFound, key, c.namesHint(key.typ))
+	}
+
+	trace.enter(key.String())
+	instance, err := s.get()
+	trace.leave()
+	if err != nil {
+		return zero, err
+	}
+
+	// Uma factory de interface pode retornar nil; isso não é um T utilizável
+	typed, ok := instance.(T)
+	if !ok {
+		return zero, fmt.Errorf("%w: provider for %s returned %T", ErrTypeMismatch, key, instance)
+	}
+	return typed, nil
+}
+
+// MustResolve obtém o serviço registrado para o tipo T ou entra em pânico
+func MustResolve[T any](c *Container, opts ...Option) T {
+	instance, err := Resolve[T](c, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return instance
+}
+
+// Lookup obtém um serviço registrado por nome (Register/RegisterSingleton)
+// verificando o tipo, em vez de uma type assertion que entra em pânico
+func Lookup[T any](c *Container, name string) (T, error) {
+	var zero T
+
+	service, err := c.Get(name)
+	if err != nil {
+		return zero, fmt.Errorf("%w: %v", ErrNotFound, err)
+	}
+
+	typed, ok := service.(T)
+	if !ok {
+		return zero, fmt.Errorf("%w: service '%s' is %T, not %s",
+			ErrTypeMismatch, name, service, reflect.TypeOf((*T)(nil)).Elem())
+	}
+	return typed, nil
+}
+
+// namesHint lista os nomes registrados para o tipo, para orientar a correção
+func (c *Container) namesHint(typ reflect.Type) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var names []string
+	for key := range c.providers {
+		if key.typ == typ {
+			names = append(names, fmt.Sprintf("%q", key.name))
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+
+	sort.Strings(names)
+	return fmt.Sprintf("; registered names: %v", names)
+}
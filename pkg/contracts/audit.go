@@ -0,0 +1,89 @@
+package contracts
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Entidades auditadas
+const (
+	AuditEntityUser    = "user"
+	AuditEntityProduct = "product"
+	AuditEntityOrder   = "order"
+)
+
+// Ações auditadas
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionRestore = "restore"
+	AuditActionPurge   = "purge"
+)
+
+// AuditChange é o valor de um campo antes e depois da alteração (null quando
+// o campo não existia, como em criações e exclusões)
+type AuditChange struct {
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
+
+// AuditEntry é um registro da trilha de auditoria: quem alterou qual entidade,
+// em qual requisição e o que mudou
+type AuditEntry struct {
+	ID         uint64                 `json:"id"`
+	Actor      string                 `json:"actor"`
+	RequestID  string                 `json:"request_id,omitempty"`
+	EntityType string                 `json:"entity_type"`
+	EntityID   string                 `json:"entity_id"`
+	Action     string                 `json:"action"`
+	Changes    map[string]AuditChange `json:"changes"` // Só os campos que mudaram
+	OccurredAt time.Time              `json:"occurred_at"`
+}
+
+// AuditQuery filtra a consulta da trilha. Campos vazios não filtram; os
+// registros vêm do mais recente para o mais antigo.
+type AuditQuery struct {
+	EntityType string
+	EntityID   string
+	Actor      string
+	Action     string
+	RequestID  string
+	Since      *time.Time
+	Until      *time.Time
+	Limit      int
+	Offset     int
+}
+
+// AuditLog é a trilha de auditoria append-only. Append participa da transação
+// do contexto: a alteração e o registro são gravados juntos ou nenhum deles.
+type AuditLog interface {
+	Append(ctx context.Context, entry *AuditEntry) error
+	List(ctx context.Context, query AuditQuery) ([]*AuditEntry, error)
+}
+
+// Auditor registra na trilha a alteração de uma entidade, calculando a
+// diferença entre before e after. before é nil em criações e after em exclusões.
+type Auditor interface {
+	Record(ctx context.Context, entityType, entityID, action string, before, after interface{}) error
+}
+
+type actorKey struct{}
+
+// SystemActor é o autor das alterações feitas fora de uma requisição
+// (consumidores de eventos, sagas, jobs)
+const SystemActor = "system"
+
+// WithActor retorna um contexto que identifica quem faz as alterações
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext retorna o autor das alterações do contexto, ou SystemActor
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return SystemActor
+}
@@ -0,0 +1,28 @@
+// Package contractstest verifica se uma implementação de repositório cumpre o
+// contrato de pkg/contracts: erros sentinela, semântica dos filtros, paginação,
+// substituição de itens e remoção em cascata. Cada backend (MySQL, SQLite e
+// memória) roda a mesma suíte a partir dos seus testes:
+//
+//	contractstest.TestProductRepository(t, func(t *testing.T) contracts.ProductRepository {
+//		return repository.NewMemoryProductRepository()
+//	})
+//
+// A fábrica é chamada uma vez por subteste. Como o repositório pode ser
+// compartilhado entre execuções (um MySQL de testes), a suíte usa IDs, emails e
+// categorias únicos e não supõe um repositório vazio.
+package contractstest
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func newID() string {
+	return uuid.New().String()
+}
+
+// now retorna o instante atual sem frações de segundo, que DATETIME não guarda
+func now() time.Time {
+	return time.Now().Truncate(time.Second)
+}
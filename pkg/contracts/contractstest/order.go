@@ -0,0 +1,207 @@
+package contractstest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-modular-monolith/pkg/contracts"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOrderRepository verifica o contrato de contracts.OrderRepository
+func TestOrderRepository(t *testing.T, newRepo func(t *testing.T) contracts.OrderRepository) {
+	ctx := context.Background()
+
+	t.Run("Create And Get", func(t *testing.T) {
+		repo := newRepo(t)
+		order := newOrder(newID(), 3)
+		require.NoError(t, repo.Create(ctx, order))
+
+		found, err := repo.GetByID(ctx, order.ID)
+		require.NoError(t, err)
+		assertOrder(t, order, found)
+		assert.Equal(t, 1, found.Version)
+
+		assert.ErrorIs(t, repo.Create(ctx, order), contracts.ErrAlreadyExists)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		repo := newRepo(t)
+		missing := newOrder(newID(), 1)
+
+		_, err := repo.GetByID(ctx, missing.ID)
+		assert.ErrorIs(t, err, contracts.ErrNotFound)
+		assert.ErrorIs(t, repo.Update(ctx, missing), contracts.ErrNotFound)
+		assert.ErrorIs(t, repo.Delete(ctx, missing.ID), contracts.ErrNotFound)
+
+		orders, err := repo.GetByUserID(ctx, missing.UserID)
+		require.NoError(t, err)
+		assert.Empty(t, orders)
+	})
+
+	t.Run("Get By User", func(t *testing.T) {
+		repo := newRepo(t)
+		userID := newID()
+
+		newer := newOrder(userID, 1)
+		older := newOrder(userID, 2)
+		older.CreatedAt = newer.CreatedAt.Add(-time.Hour)
+		require.NoError(t, repo.Create(ctx, newer))
+		require.NoError(t, repo.Create(ctx, older))
+		require.NoError(t, repo.Create(ctx, newOrder(newID(), 1)))
+
+		// Do mais antigo para o mais recente, só do usuário
+		orders, err := repo.GetByUserID(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, orders, 2)
+		assertOrder(t, older, orders[0])
+		assertOrder(t, newer, orders[1])
+	})
+
+	t.Run("Update Replaces Items", func(t *testing.T) {
+		repo := newRepo(t)
+		order := newOrder(newID(), 3)
+		require.NoError(t, repo.Create(ctx, order))
+
+		changed := newOrder(order.UserID, 1)
+		changed.ID = order.ID
+		changed.Status = contracts.OrderStatusCancelled
+		changed.CreatedAt = order.CreatedAt
+		changed.Version = order.Version
+		require.NoError(t, repo.Update(ctx, changed))
+
+		found, err := repo.GetByID(ctx, order.ID)
+		require.NoError(t, err)
+		assertOrder(t, changed, found)
+		assert.Equal(t, 2, found.Version)
+
+		// Sem itens
+		changed.Items = nil
+		changed.Total = 0
+		require.NoError(t, repo.Update(ctx, changed))
+
+		found, err = repo.GetByID(ctx, order.ID)
+		require.NoError(t, err)
+		assert.Empty(t, found.Items)
+	})
+
+	t.Run("Version Conflict", func(t *testing.T) {
+		repo := newRepo(t)
+		order := newOrder(newID(), 2)
+		require.NoError(t, repo.Create(ctx, order))
+
+		first, err := repo.GetByID(ctx, order.ID)
+		require.NoError(t, err)
+		second, err := repo.GetByID(ctx, order.ID)
+		require.NoError(t, err)
+
+		first.Status = contracts.OrderStatusConfirmed
+		require.NoError(t, repo.Update(ctx, first))
+
+		// A segunda alteração partiu da versão já substituída: nem o status nem os itens mudam
+		second.Status = contracts.OrderStatusCancelled
+		second.Items = second.Items[:1]
+		assert.ErrorIs(t, repo.Update(ctx, second), contracts.ErrVersionConflict)
+
+		found, err := repo.GetByID(ctx, order.ID)
+		require.NoError(t, err)
+		assertOrder(t, first, found)
+		assert.Equal(t, 2, found.Version)
+	})
+
+	t.Run("Delete Keeps Items", func(t *testing.T) {
+		repo := newRepo(t)
+		order := newOrder(newID(), 3)
+		require.NoError(t, repo.Create(ctx, order))
+		require.NoError(t, repo.Delete(ctx, order.ID))
+
+		_, err := repo.GetByID(ctx, order.ID)
+		assert.ErrorIs(t, err, contracts.ErrNotFound)
+		assert.ErrorIs(t, repo.Update(ctx, order), contracts.ErrNotFound)
+		assert.ErrorIs(t, repo.Delete(ctx, order.ID), contracts.ErrNotFound)
+
+		orders, err := repo.GetByUserID(ctx, order.UserID)
+		require.NoError(t, err)
+		assert.Empty(t, orders)
+
+		// Excluído, o pedido é listado com os itens e pode ser restaurado
+		deleted, err := repo.ListDeleted(ctx)
+		require.NoError(t, err)
+		require.Len(t, deleted, 1)
+		assertOrder(t, order, deleted[0])
+		assert.NotNil(t, deleted[0].DeletedAt)
+
+		require.NoError(t, repo.Restore(ctx, order.ID))
+		found, err := repo.GetByID(ctx, order.ID)
+		require.NoError(t, err)
+		assertOrder(t, order, found)
+		assert.Nil(t, found.DeletedAt)
+		assert.ErrorIs(t, repo.Restore(ctx, order.ID), contracts.ErrNotFound, "restored an order that was not deleted")
+	})
+
+	t.Run("Purge Cascades Items", func(t *testing.T) {
+		repo := newRepo(t)
+		order := newOrder(newID(), 3)
+		require.NoError(t, repo.Create(ctx, order))
+
+		assert.ErrorIs(t, repo.Purge(ctx, order.ID), contracts.ErrNotFound, "purged an order that was not deleted")
+		found, err := repo.GetByID(ctx, order.ID)
+		require.NoError(t, err)
+		assertOrder(t, order, found)
+
+		require.NoError(t, repo.Delete(ctx, order.ID))
+		assert.ErrorIs(t, repo.Create(ctx, order), contracts.ErrAlreadyExists)
+		require.NoError(t, repo.Purge(ctx, order.ID))
+		assert.ErrorIs(t, repo.Restore(ctx, order.ID), contracts.ErrNotFound)
+
+		deleted, err := repo.ListDeleted(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, deleted)
+
+		// Recriado com o mesmo ID, o pedido não herda itens do anterior
+		recreated := newOrder(order.UserID, 1)
+		recreated.ID = order.ID
+		require.NoError(t, repo.Create(ctx, recreated))
+
+		found, err = repo.GetByID(ctx, order.ID)
+		require.NoError(t, err)
+		assertOrder(t, recreated, found)
+	})
+}
+
+// newOrder cria um pedido pendente do usuário com n itens
+func newOrder(userID string, n int) *contracts.Order {
+	order := &contracts.Order{
+		ID:        newID(),
+		UserID:    userID,
+		Status:    contracts.OrderStatusPending,
+		CreatedAt: now(),
+		UpdatedAt: now(),
+	}
+	for i := 0; i < n; i++ {
+		item := contracts.OrderItem{ProductID: newID(), Quantity: i + 1, Price: 9.90}
+		order.Items = append(order.Items, item)
+		order.Total += float64(item.Quantity) * item.Price
+	}
+	return order
+}
+
+func assertOrder(t *testing.T, expected, actual *contracts.Order) {
+	t.Helper()
+	assert.Equal(t, expected.ID, actual.ID)
+	assert.Equal(t, expected.UserID, actual.UserID)
+	assert.Equal(t, expected.Status, actual.Status)
+	assert.InDelta(t, expected.Total, actual.Total, 0.001)
+	assert.WithinDuration(t, expected.CreatedAt, actual.CreatedAt, time.Second)
+
+	// Itens na ordem em que foram gravados
+	require.Len(t, actual.Items, len(expected.Items))
+	for i, item := range expected.Items {
+		assert.Equal(t, item.ProductID, actual.Items[i].ProductID)
+		assert.Equal(t, item.Quantity, actual.Items[i].Quantity)
+		assert.InDelta(t, item.Price, actual.Items[i].Price, 0.001)
+	}
+}
@@ -0,0 +1,287 @@
+package contractstest
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"go-modular-monolith/pkg/contracts"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestProductRepository verifica o contrato de contracts.ProductRepository
+func TestProductRepository(t *testing.T, newRepo func(t *testing.T) contracts.ProductRepository) {
+	ctx := context.Background()
+
+	t.Run("Create And Get", func(t *testing.T) {
+		repo := newRepo(t)
+		product := newProduct(newID(), "Teclado", 149.90)
+		require.NoError(t, repo.Create(ctx, product))
+
+		found, err := repo.GetByID(ctx, product.ID)
+		require.NoError(t, err)
+		assertProduct(t, product, found)
+		assert.Equal(t, 1, found.Version)
+
+		assert.ErrorIs(t, repo.Create(ctx, product), contracts.ErrAlreadyExists)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		repo := newRepo(t)
+		missing := newProduct(newID(), "Inexistente", 1)
+
+		_, err := repo.GetByID(ctx, missing.ID)
+		assert.ErrorIs(t, err, contracts.ErrNotFound)
+		assert.ErrorIs(t, repo.Update(ctx, missing), contracts.ErrNotFound)
+		assert.ErrorIs(t, repo.Delete(ctx, missing.ID), contracts.ErrNotFound)
+		_, err = repo.AdjustStock(ctx, missing.ID, 1)
+		assert.ErrorIs(t, err, contracts.ErrNotFound)
+
+		// Update não cria o produto
+		_, err = repo.GetByID(ctx, missing.ID)
+		assert.ErrorIs(t, err, contracts.ErrNotFound)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		repo := newRepo(t)
+		product := newProduct(newID(), "Mouse", 59.90)
+		require.NoError(t, repo.Create(ctx, product))
+
+		changed := newProduct(product.ID, "Mouse sem fio", 89.90)
+		changed.Description = ""
+		changed.Stock = 0
+		changed.CreatedAt = product.CreatedAt
+		changed.Version = product.Version
+		require.NoError(t, repo.Update(ctx, changed))
+
+		// Todos os campos são substituídos, inclusive os zerados
+		found, err := repo.GetByID(ctx, product.ID)
+		require.NoError(t, err)
+		assertProduct(t, changed, found)
+		assert.Equal(t, 2, found.Version)
+	})
+
+	t.Run("Version Conflict", func(t *testing.T) {
+		repo := newRepo(t)
+		product := newProduct(newID(), "Microfone", 399.90)
+		require.NoError(t, repo.Create(ctx, product))
+
+		stale, err := repo.GetByID(ctx, product.ID)
+		require.NoError(t, err)
+
+		// Ajustes de estoque também mudam a versão
+		adjusted, err := repo.AdjustStock(ctx, product.ID, -1)
+		require.NoError(t, err)
+		assert.Equal(t, 2, adjusted.Version)
+
+		stale.Price = 299.90
+		assert.ErrorIs(t, repo.Update(ctx, stale), contracts.ErrVersionConflict)
+
+		found, err := repo.GetByID(ctx, product.ID)
+		require.NoError(t, err)
+		assert.InDelta(t, product.Price, found.Price, 0.001)
+		assert.Equal(t, product.Stock-1, found.Stock)
+
+		found.Price = 299.90
+		require.NoError(t, repo.Update(ctx, found))
+		assert.Equal(t, 3, found.Version)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo := newRepo(t)
+		product := newProduct(newID(), "Monitor", 999.90)
+		require.NoError(t, repo.Create(ctx, product))
+		require.NoError(t, repo.Delete(ctx, product.ID))
+
+		_, err := repo.GetByID(ctx, product.ID)
+		assert.ErrorIs(t, err, contracts.ErrNotFound)
+		assert.ErrorIs(t, repo.Update(ctx, product), contracts.ErrNotFound)
+		assert.ErrorIs(t, repo.Delete(ctx, product.ID), contracts.ErrNotFound)
+		_, err = repo.AdjustStock(ctx, product.ID, 1)
+		assert.ErrorIs(t, err, contracts.ErrNotFound)
+
+		products, err := repo.List(ctx, contracts.ProductFilters{CategoryID: &product.CategoryID})
+		require.NoError(t, err)
+		assert.Empty(t, products)
+
+		// O ID continua reservado até o purge
+		assert.ErrorIs(t, repo.Create(ctx, product), contracts.ErrAlreadyExists)
+	})
+
+	t.Run("Restore And Purge", func(t *testing.T) {
+		repo := newRepo(t)
+		product := newProduct(newID(), "Webcam", 249.90)
+		purged := newProduct(newID(), "Headset", 199.90)
+		require.NoError(t, repo.Create(ctx, product))
+		require.NoError(t, repo.Create(ctx, purged))
+
+		assert.ErrorIs(t, repo.Restore(ctx, product.ID), contracts.ErrNotFound, "restored a product that was not deleted")
+		assert.ErrorIs(t, repo.Purge(ctx, product.ID), contracts.ErrNotFound, "purged a product that was not deleted")
+		require.NoError(t, repo.Delete(ctx, product.ID))
+		require.NoError(t, repo.Delete(ctx, purged.ID))
+
+		deleted, err := repo.ListDeleted(ctx)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{product.ID, purged.ID}, productIDs(deleted))
+		for _, p := range deleted {
+			assert.NotNil(t, p.DeletedAt)
+		}
+
+		require.NoError(t, repo.Restore(ctx, product.ID))
+		found, err := repo.GetByID(ctx, product.ID)
+		require.NoError(t, err)
+		assertProduct(t, product, found)
+		assert.Nil(t, found.DeletedAt)
+
+		require.NoError(t, repo.Purge(ctx, purged.ID))
+		assert.ErrorIs(t, repo.Restore(ctx, purged.ID), contracts.ErrNotFound)
+
+		deleted, err = repo.ListDeleted(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, deleted)
+
+		// Após o purge, o ID fica livre
+		require.NoError(t, repo.Create(ctx, purged))
+	})
+
+	t.Run("Adjust Stock", func(t *testing.T) {
+		repo := newRepo(t)
+		product := newProduct(newID(), "Cabo", 19.90)
+		product.Stock = 5
+		require.NoError(t, repo.Create(ctx, product))
+
+		adjusted, err := repo.AdjustStock(ctx, product.ID, -3)
+		require.NoError(t, err)
+		assert.Equal(t, 2, adjusted.Stock)
+
+		adjusted, err = repo.AdjustStock(ctx, product.ID, 4)
+		require.NoError(t, err)
+		assert.Equal(t, 6, adjusted.Stock)
+
+		_, err = repo.AdjustStock(ctx, product.ID, -7)
+		assert.ErrorIs(t, err, contracts.ErrInsufficientStock)
+
+		found, err := repo.GetByID(ctx, product.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 6, found.Stock, "failed adjustment changed the stock")
+	})
+
+	t.Run("List Filters", func(t *testing.T) {
+		repo := newRepo(t)
+		category := newID()
+		alpha := createProduct(t, repo, category, "Alpha Widget", 10)
+		beta := createProduct(t, repo, category, "beta WIDGET", 20)
+		gamma := createProduct(t, repo, category, "Gamma", 30)
+		delta := createProduct(t, repo, category, "Delta", 40)
+		createProduct(t, repo, newID(), "Widget de outra categoria", 20)
+		underscore := createProduct(t, repo, category, "Cabo_USB 100%", 50)
+
+		list := func(filters contracts.ProductFilters) []string {
+			filters.CategoryID = &category
+			products, err := repo.List(ctx, filters)
+			require.NoError(t, err)
+			return productIDs(products)
+		}
+
+		assert.Equal(t, sortedIDs(alpha, beta, gamma, delta, underscore), list(contracts.ProductFilters{}))
+
+		// Limites de preço inclusivos
+		assert.Equal(t, sortedIDs(beta, gamma, delta, underscore), list(contracts.ProductFilters{MinPrice: float64Ptr(20)}))
+		assert.Equal(t, sortedIDs(alpha, beta), list(contracts.ProductFilters{MaxPrice: float64Ptr(20)}))
+		assert.Equal(t, sortedIDs(beta, gamma), list(contracts.ProductFilters{MinPrice: float64Ptr(20), MaxPrice: float64Ptr(30)}))
+
+		// Nome: trecho, sem diferenciar maiúsculas
+		assert.Equal(t, sortedIDs(alpha, beta), list(contracts.ProductFilters{Name: stringPtr("widget")}))
+		assert.Equal(t, sortedIDs(beta), list(contracts.ProductFilters{Name: stringPtr("Widget"), MinPrice: float64Ptr(15)}))
+		assert.Empty(t, list(contracts.ProductFilters{Name: stringPtr("inexistente")}))
+
+		// Curingas do LIKE são literais: "_" e "%" não casam com qualquer caractere
+		assert.Equal(t, sortedIDs(underscore), list(contracts.ProductFilters{Name: stringPtr("cabo_usb")}))
+		assert.Equal(t, sortedIDs(underscore), list(contracts.ProductFilters{Name: stringPtr("100%")}))
+		assert.Empty(t, list(contracts.ProductFilters{Name: stringPtr("a_w")}), "matches Alpha Widget if _ is a wildcard")
+		assert.Empty(t, list(contracts.ProductFilters{Name: stringPtr("alpha%widget")}))
+		assert.Empty(t, list(contracts.ProductFilters{Name: stringPtr("!")}))
+
+		unknown := newID()
+		products, err := repo.List(ctx, contracts.ProductFilters{CategoryID: &unknown})
+		require.NoError(t, err)
+		assert.Empty(t, products)
+	})
+
+	t.Run("List Pagination", func(t *testing.T) {
+		repo := newRepo(t)
+		category := newID()
+		var ids []string
+		for i := 0; i < 5; i++ {
+			ids = append(ids, createProduct(t, repo, category, "Produto", 10))
+		}
+		sort.Strings(ids)
+
+		list := func(limit, offset int) []string {
+			products, err := repo.List(ctx, contracts.ProductFilters{CategoryID: &category, Limit: limit, Offset: offset})
+			require.NoError(t, err)
+			return productIDs(products)
+		}
+
+		// Ordem por ID, estável entre páginas
+		assert.Equal(t, ids[:2], list(2, 0))
+		assert.Equal(t, ids[2:4], list(2, 2))
+		assert.Equal(t, ids[4:], list(2, 4))
+		assert.Empty(t, list(2, 5))
+		assert.Equal(t, ids, list(0, 0), "zero limit returns everything")
+		assert.Equal(t, ids[3:], list(0, 3), "offset without limit")
+	})
+}
+
+func newProduct(id, name string, price float64) *contracts.Product {
+	return &contracts.Product{
+		ID:          id,
+		Name:        name,
+		Description: "Descrição de " + name,
+		Price:       price,
+		Stock:       10,
+		CategoryID:  "category-" + id[:8],
+		CreatedAt:   now(),
+		UpdatedAt:   now(),
+	}
+}
+
+// createProduct cria um produto na categoria e retorna o ID
+func createProduct(t *testing.T, repo contracts.ProductRepository, category, name string, price float64) string {
+	t.Helper()
+	product := newProduct(newID(), name, price)
+	product.CategoryID = category
+	require.NoError(t, repo.Create(context.Background(), product))
+	return product.ID
+}
+
+func assertProduct(t *testing.T, expected, actual *contracts.Product) {
+	t.Helper()
+	assert.Equal(t, expected.ID, actual.ID)
+	assert.Equal(t, expected.Name, actual.Name)
+	assert.Equal(t, expected.Description, actual.Description)
+	assert.InDelta(t, expected.Price, actual.Price, 0.001)
+	assert.Equal(t, expected.Stock, actual.Stock)
+	assert.Equal(t, expected.CategoryID, actual.CategoryID)
+	assert.WithinDuration(t, expected.CreatedAt, actual.CreatedAt, time.Second)
+}
+
+func productIDs(products []*contracts.Product) []string {
+	ids := make([]string, len(products))
+	for i, product := range products {
+		ids[i] = product.ID
+	}
+	return ids
+}
+
+func sortedIDs(ids ...string) []string {
+	sort.Strings(ids)
+	return ids
+}
+
+func float64Ptr(v float64) *float64 { return &v }
+
+func stringPtr(v string) *string { return &v }
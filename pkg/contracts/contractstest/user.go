@@ -0,0 +1,207 @@
+package contractstest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-modular-monolith/pkg/contracts"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestUserRepository verifica o contrato de contracts.UserRepository
+func TestUserRepository(t *testing.T, newRepo func(t *testing.T) contracts.UserRepository) {
+	ctx := context.Background()
+
+	t.Run("Create And Get", func(t *testing.T) {
+		repo := newRepo(t)
+		user := newUser()
+		require.NoError(t, repo.Create(ctx, user))
+
+		found, err := repo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		assertUser(t, user, found)
+		assert.Equal(t, 1, user.Version)
+		assert.Equal(t, 1, found.Version)
+
+		found, err = repo.GetByEmail(ctx, user.Email)
+		require.NoError(t, err)
+		assertUser(t, user, found)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		repo := newRepo(t)
+		missing := newUser()
+
+		_, err := repo.GetByID(ctx, missing.ID)
+		assert.ErrorIs(t, err, contracts.ErrNotFound)
+		_, err = repo.GetByEmail(ctx, missing.Email)
+		assert.ErrorIs(t, err, contracts.ErrNotFound)
+		assert.ErrorIs(t, repo.Update(ctx, missing), contracts.ErrNotFound)
+		assert.ErrorIs(t, repo.Delete(ctx, missing.ID), contracts.ErrNotFound)
+	})
+
+	t.Run("Duplicates", func(t *testing.T) {
+		repo := newRepo(t)
+		user := newUser()
+		require.NoError(t, repo.Create(ctx, user))
+
+		sameID := newUser()
+		sameID.ID = user.ID
+		assert.ErrorIs(t, repo.Create(ctx, sameID), contracts.ErrAlreadyExists)
+
+		sameEmail := newUser()
+		sameEmail.Email = user.Email
+		assert.ErrorIs(t, repo.Create(ctx, sameEmail), contracts.ErrAlreadyExists)
+
+		sameUsername := newUser()
+		sameUsername.Username = user.Username
+		assert.ErrorIs(t, repo.Create(ctx, sameUsername), contracts.ErrAlreadyExists)
+
+		// Nenhuma tentativa sobrescreveu o original
+		found, err := repo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		assertUser(t, user, found)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		repo := newRepo(t)
+		user := newUser()
+		other := newUser()
+		require.NoError(t, repo.Create(ctx, user))
+		require.NoError(t, repo.Create(ctx, other))
+
+		changed := newUser()
+		changed.ID = user.ID
+		changed.CreatedAt = user.CreatedAt
+		changed.Version = user.Version
+		require.NoError(t, repo.Update(ctx, changed))
+		assert.Equal(t, 2, changed.Version)
+
+		found, err := repo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		assertUser(t, changed, found)
+		assert.Equal(t, 2, found.Version)
+
+		_, err = repo.GetByEmail(ctx, user.Email)
+		assert.ErrorIs(t, err, contracts.ErrNotFound, "old email still resolves")
+
+		// Email de outro usuário
+		changed.Email = other.Email
+		assert.ErrorIs(t, repo.Update(ctx, changed), contracts.ErrAlreadyExists)
+	})
+
+	t.Run("Version Conflict", func(t *testing.T) {
+		repo := newRepo(t)
+		user := newUser()
+		require.NoError(t, repo.Create(ctx, user))
+
+		first, err := repo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		second, err := repo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+
+		first.Username = "first-" + user.ID[:8]
+		require.NoError(t, repo.Update(ctx, first))
+
+		// A segunda alteração partiu da versão já substituída
+		second.Username = "second-" + user.ID[:8]
+		assert.ErrorIs(t, repo.Update(ctx, second), contracts.ErrVersionConflict)
+		assert.Equal(t, 1, second.Version, "failed update changed the version")
+
+		found, err := repo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, first.Username, found.Username)
+		assert.Equal(t, 2, found.Version)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo := newRepo(t)
+		user := newUser()
+		require.NoError(t, repo.Create(ctx, user))
+		require.NoError(t, repo.Delete(ctx, user.ID))
+
+		_, err := repo.GetByID(ctx, user.ID)
+		assert.ErrorIs(t, err, contracts.ErrNotFound)
+		_, err = repo.GetByEmail(ctx, user.Email)
+		assert.ErrorIs(t, err, contracts.ErrNotFound)
+		assert.ErrorIs(t, repo.Update(ctx, user), contracts.ErrNotFound)
+		assert.ErrorIs(t, repo.Delete(ctx, user.ID), contracts.ErrNotFound)
+
+		// ID, email e username continuam reservados até o purge
+		assert.ErrorIs(t, repo.Create(ctx, user), contracts.ErrAlreadyExists)
+		sameEmail := newUser()
+		sameEmail.Email = user.Email
+		assert.ErrorIs(t, repo.Create(ctx, sameEmail), contracts.ErrAlreadyExists)
+	})
+
+	t.Run("Restore", func(t *testing.T) {
+		repo := newRepo(t)
+		user := newUser()
+		kept := newUser()
+		require.NoError(t, repo.Create(ctx, user))
+		require.NoError(t, repo.Create(ctx, kept))
+
+		assert.ErrorIs(t, repo.Restore(ctx, user.ID), contracts.ErrNotFound, "restored a user that was not deleted")
+		require.NoError(t, repo.Delete(ctx, user.ID))
+
+		deleted, err := repo.ListDeleted(ctx)
+		require.NoError(t, err)
+		require.Len(t, deleted, 1)
+		assertUser(t, user, deleted[0])
+		assert.NotNil(t, deleted[0].DeletedAt)
+
+		require.NoError(t, repo.Restore(ctx, user.ID))
+		found, err := repo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		assertUser(t, user, found)
+		assert.Nil(t, found.DeletedAt)
+
+		deleted, err = repo.ListDeleted(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, deleted)
+		assert.ErrorIs(t, repo.Restore(ctx, newID()), contracts.ErrNotFound)
+	})
+
+	t.Run("Purge", func(t *testing.T) {
+		repo := newRepo(t)
+		user := newUser()
+		require.NoError(t, repo.Create(ctx, user))
+
+		assert.ErrorIs(t, repo.Purge(ctx, user.ID), contracts.ErrNotFound, "purged a user that was not deleted")
+		require.NoError(t, repo.Delete(ctx, user.ID))
+		require.NoError(t, repo.Purge(ctx, user.ID))
+
+		assert.ErrorIs(t, repo.Restore(ctx, user.ID), contracts.ErrNotFound)
+		assert.ErrorIs(t, repo.Purge(ctx, user.ID), contracts.ErrNotFound)
+		deleted, err := repo.ListDeleted(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, deleted)
+
+		// Após o purge, ID, email e username ficam livres
+		require.NoError(t, repo.Create(ctx, user))
+	})
+}
+
+func newUser() *contracts.User {
+	id := newID()
+	return &contracts.User{
+		ID:        id,
+		Username:  "user-" + id[:8],
+		Email:     id + "@example.com",
+		Password:  "hash-" + id[:8],
+		CreatedAt: now(),
+		UpdatedAt: now(),
+	}
+}
+
+func assertUser(t *testing.T, expected, actual *contracts.User) {
+	t.Helper()
+	assert.Equal(t, expected.ID, actual.ID)
+	assert.Equal(t, expected.Username, actual.Username)
+	assert.Equal(t, expected.Email, actual.Email)
+	assert.Equal(t, expected.Password, actual.Password)
+	assert.WithinDuration(t, expected.CreatedAt, actual.CreatedAt, time.Second)
+}
@@ -0,0 +1,28 @@
+package contracts
+
+import "errors"
+
+// Erros dos repositórios. Todas as implementações (MySQL, SQLite e memória)
+// retornam estes erros embrulhados, para que o chamador use errors.Is.
+var (
+	// ErrNotFound indica que o registro não existe
+	ErrNotFound = errors.New("not found")
+
+	// ErrAlreadyExists indica um ID ou campo único (email, username) já em uso
+	ErrAlreadyExists = errors.New("already exists")
+
+	// ErrInsufficientStock indica que AdjustStock deixaria o estoque negativo
+	ErrInsufficientStock = errors.New("insufficient stock")
+
+	// ErrVersionConflict indica que o registro foi alterado desde a leitura:
+	// a versão passada ao Update não é mais a gravada
+	ErrVersionConflict = errors.New("version conflict")
+)
+
+// ErrPreconditionFailed é retornado pelos serviços quando a versão pedida pelo
+// cliente (If-Match) não é a versão atual do registro
+var ErrPreconditionFailed = errors.New("precondition failed")
+
+// ErrInvalidState é retornado pelos serviços quando a operação não é permitida
+// no estado atual do registro (ex.: excluir um pedido em andamento)
+var ErrInvalidState = errors.New("invalid state")
@@ -0,0 +1,127 @@
+package contracts
+
+import "errors"
+
+// Validações dos payloads de eventos, executadas pelo events.Registry no Publish
+
+func (e UserCreatedEvent) Validate() error {
+	if e.UserID == "" || e.Email == "" {
+		return errors.New("user_id and email are required")
+	}
+	return nil
+}
+
+func (e UserUpdatedEvent) Validate() error {
+	if e.UserID == "" {
+		return errors.New("user_id is required")
+	}
+	return nil
+}
+
+func (e UserDeletedEvent) Validate() error {
+	if e.UserID == "" {
+		return errors.New("user_id is required")
+	}
+	return nil
+}
+
+func (e UserRestoredEvent) Validate() error {
+	if e.UserID == "" {
+		return errors.New("user_id is required")
+	}
+	return nil
+}
+
+func (e UserPurgedEvent) Validate() error {
+	if e.UserID == "" {
+		return errors.New("user_id is required")
+	}
+	return nil
+}
+
+func (e ProductCreatedEvent) Validate() error {
+	if e.ProductID == "" {
+		return errors.New("product_id is required")
+	}
+	return nil
+}
+
+func (e ProductUpdatedEvent) Validate() error {
+	if e.ProductID == "" {
+		return errors.New("product_id is required")
+	}
+	return nil
+}
+
+func (e ProductDeletedEvent) Validate() error {
+	if e.ProductID == "" {
+		return errors.New("product_id is required")
+	}
+	return nil
+}
+
+func (e ProductRestoredEvent) Validate() error {
+	if e.ProductID == "" {
+		return errors.New("product_id is required")
+	}
+	return nil
+}
+
+func (e ProductPurgedEvent) Validate() error {
+	if e.ProductID == "" {
+		return errors.New("product_id is required")
+	}
+	return nil
+}
+
+func (e ProductStockUpdatedEvent) Validate() error {
+	if e.ProductID == "" {
+		return errors.New("product_id is required")
+	}
+	if e.NewStock < 0 {
+		return errors.New("new_stock cannot be negative")
+	}
+	return nil
+}
+
+func (e OrderCreatedEvent) Validate() error {
+	if e.OrderID == "" || e.UserID == "" {
+		return errors.New("order_id and user_id are required")
+	}
+	return nil
+}
+
+func (e OrderStatusUpdatedEvent) Validate() error {
+	if e.OrderID == "" || e.NewStatus == "" {
+		return errors.New("order_id and new_status are required")
+	}
+	return nil
+}
+
+func (e OrderCancelledEvent) Validate() error {
+	if e.OrderID == "" {
+		return errors.New("order_id is required")
+	}
+	return nil
+}
+
+func (e OrderDeletedEvent) Validate() error {
+	if e.OrderID == "" || e.UserID == "" {
+		return errors.New("order_id and user_id are required")
+	}
+	return nil
+}
+
+func (e OrderRestoredEvent) Validate() error {
+	if e.OrderID == "" || e.UserID == "" {
+		return errors.New("order_id and user_id are required")
+	}
+	return nil
+}
+
+func (e OrderPurgedEvent) Validate() error {
+	if e.OrderID == "" {
+		return errors.New("order_id is required")
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package contracts
 
 import (
 	"context"
+	"fmt"
 	"time"
 )
 
@@ -42,16 +43,66 @@ type Cache interface {
 	Delete(ctx context.Context, key string) error
 }
 
-// Database define a interface para transações de banco
+// Database define a interface para transações de banco (unidade de trabalho).
+// BeginTx com um contexto que já carrega uma transação abre um savepoint nela.
 type Database interface {
 	BeginTx(ctx context.Context) (Transaction, error)
 	Health() error
 }
 
+// Transaction entrega repositórios ligados à transação. Commit e Rollback
+// encerram a transação; chamadas seguintes retornam sql.ErrTxDone.
 type Transaction interface {
+	// Context retorna ctx carregando a transação: serviços, repositórios e o
+	// outbox chamados com ele participam dela
+	Context(ctx context.Context) context.Context
 	Commit() error
 	Rollback() error
 	UserRepository() UserRepository
 	ProductRepository() ProductRepository
 	OrderRepository() OrderRepository
 }
+
+// TransactionManager executa operações dentro de uma transação propagada pelo contexto.
+// Chamadas aninhadas participam da transação já aberta.
+type TransactionManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type primaryReadsKey struct{}
+
+// WithPrimaryReads retorna um contexto cujas leituras de repositório vão ao banco
+// primário, e não às réplicas. Use logo após uma escrita ou antes de ler para
+// alterar, quando o atraso de replicação não é aceitável.
+func WithPrimaryReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryReadsKey{}, true)
+}
+
+// PrimaryReads informa se o contexto pede leituras no banco primário
+func PrimaryReads(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryReadsKey{}).(bool)
+	return primary
+}
+
+type ifMatchKey struct{}
+
+// WithIfMatch retorna um contexto que só admite alterar registros em uma das
+// versões informadas (o If-Match da requisição). Uma lista vazia não admite nenhuma.
+func WithIfMatch(ctx context.Context, versions []int) context.Context {
+	return context.WithValue(ctx, ifMatchKey{}, versions)
+}
+
+// CheckIfMatch retorna ErrPreconditionFailed se o contexto pede outra versão;
+// sem WithIfMatch qualquer versão é aceita
+func CheckIfMatch(ctx context.Context, version int) error {
+	versions, ok := ctx.Value(ifMatchKey{}).([]int)
+	if !ok {
+		return nil
+	}
+	for _, v := range versions {
+		if v == version {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: current version is %d", ErrPreconditionFailed, version)
+}
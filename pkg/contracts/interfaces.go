@@ -16,6 +16,9 @@ type UserService interface {
 	UpdateUser(ctx context.Context, id string, req UpdateUserRequest) (*User, error)
 	DeleteUser(ctx context.Context, id string) error
 	ValidateUser(ctx context.Context, email, password string) (*User, error)
+	ListDeletedUsers(ctx context.Context) ([]*User, error)
+	RestoreUser(ctx context.Context, id string) (*User, error)
+	PurgeUser(ctx context.Context, id string) error
 }
 
 // ProductService define operações de negócio relacionadas a produtos
@@ -26,6 +29,10 @@ type ProductService interface {
 	DeleteProduct(ctx context.Context, id string) error
 	GetProducts(ctx context.Context, filters ProductFilters) ([]*Product, error)
 	UpdateStock(ctx context.Context, id string, quantity int) error
+	AdjustStock(ctx context.Context, id string, delta int) (*Product, error)
+	ListDeletedProducts(ctx context.Context) ([]*Product, error)
+	RestoreProduct(ctx context.Context, id string) (*Product, error)
+	PurgeProduct(ctx context.Context, id string) error
 }
 
 // OrderService define operações de negócio relacionadas a pedidos
@@ -35,9 +42,21 @@ type OrderService interface {
 	GetOrdersByUserID(ctx context.Context, userID string) ([]*Order, error)
 	UpdateOrderStatus(ctx context.Context, id string, status OrderStatus) error
 	CancelOrder(ctx context.Context, id string) error
+	DeleteOrder(ctx context.Context, id string) error
+	ListDeletedOrders(ctx context.Context) ([]*Order, error)
+	RestoreOrder(ctx context.Context, id string) (*Order, error)
+	PurgeOrder(ctx context.Context, id string) error
 }
 
 // Repository Interfaces (Adapters)
+//
+// Delete é um soft delete: o registro deixa de ser retornado pelas demais
+// consultas, mas continua ocupando ID e chaves únicas até o Purge. Restore e
+// Purge atuam apenas sobre registros excluídos e retornam ErrNotFound para os demais.
+//
+// Create grava o registro na versão 1. Update só grava se a versão do registro
+// ainda for a informada (ErrVersionConflict caso contrário) e a incrementa,
+// inclusive no registro passado.
 
 // UserRepository define a interface para persistência de usuários
 type UserRepository interface {
@@ -46,6 +65,9 @@ type UserRepository interface {
 	GetByEmail(ctx context.Context, email string) (*User, error)
 	Update(ctx context.Context, user *User) error
 	Delete(ctx context.Context, id string) error
+	ListDeleted(ctx context.Context) ([]*User, error)
+	Restore(ctx context.Context, id string) error
+	Purge(ctx context.Context, id string) error
 }
 
 // ProductRepository define a interface para persistência de produtos
@@ -55,6 +77,10 @@ type ProductRepository interface {
 	Update(ctx context.Context, product *Product) error
 	Delete(ctx context.Context, id string) error
 	List(ctx context.Context, filters ProductFilters) ([]*Product, error)
+	AdjustStock(ctx context.Context, id string, delta int) (*Product, error)
+	ListDeleted(ctx context.Context) ([]*Product, error)
+	Restore(ctx context.Context, id string) error
+	Purge(ctx context.Context, id string) error
 }
 
 // OrderRepository define a interface para persistência de pedidos
@@ -64,38 +90,55 @@ type OrderRepository interface {
 	GetByUserID(ctx context.Context, userID string) ([]*Order, error)
 	Update(ctx context.Context, order *Order) error
 	Delete(ctx context.Context, id string) error
+	ListDeleted(ctx context.Context) ([]*Order, error)
+	Restore(ctx context.Context, id string) error
+	Purge(ctx context.Context, id string) error // Remove também os itens
 }
 
-// Event Publisher para comunicação assíncrona entre módulos
+// Event Publisher para comunicação assíncrona entre módulos.
+// Subscribe aceita um tipo exato ou um padrão como "order.*", "*.created" ou "*".
+// Os handlers que casam com um evento o recebem na ordem de registro.
 type EventPublisher interface {
 	Publish(ctx context.Context, event Event) error
-	Subscribe(eventType string, handler EventHandler) error
+	Subscribe(pattern string, handler EventHandler) (Subscription, error)
 }
 
+// Subscription é o handle retornado por Subscribe para cancelar a inscrição
+type Subscription interface {
+	Unsubscribe() error
+}
+
+// EventHandler processa um evento. Deve respeitar o cancelamento de ctx: com
+// prazo por handler (events.Timeout) a nova tentativa só começa depois que a
+// anterior retorna, e um handler que ignora ctx segura a inscrição.
 type EventHandler func(ctx context.Context, event Event) error
 
 // Domain Models
 
 // User representa o modelo de domínio do usuário
 type User struct {
-	ID        string    `json:"id"`
-	Username  string    `json:"username"`
-	Email     string    `json:"email"`
-	Password  string    `json:"-"` // Não expor na serialização
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        string     `json:"id"`
+	Username  string     `json:"username"`
+	Email     string     `json:"email"`
+	Password  string     `json:"-"` // Não expor na serialização
+	Version   int        `json:"version"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"` // Preenchido em registros excluídos (soft delete)
 }
 
 // Product representa o modelo de domínio do produto
 type Product struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Price       float64   `json:"price"`
-	Stock       int       `json:"stock"`
-	CategoryID  string    `json:"category_id"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Price       float64    `json:"price"`
+	Stock       int        `json:"stock"`
+	CategoryID  string     `json:"category_id"`
+	Version     int        `json:"version"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
 }
 
 // Order representa o modelo de domínio do pedido
@@ -105,8 +148,10 @@ type Order struct {
 	Items     []OrderItem `json:"items"`
 	Status    OrderStatus `json:"status"`
 	Total     float64     `json:"total"`
+	Version   int         `json:"version"`
 	CreatedAt time.Time   `json:"created_at"`
 	UpdatedAt time.Time   `json:"updated_at"`
+	DeletedAt *time.Time  `json:"deleted_at,omitempty"`
 }
 
 type OrderItem struct {
@@ -175,10 +220,17 @@ type ProductFilters struct {
 
 // Events para comunicação entre módulos
 
+// Event é o envelope trafegado entre módulos. Type identifica o payload registrado
+// em events.Registry; os demais metadados são preenchidos no Publish quando vazios.
 type Event struct {
-	Type      string      `json:"type"`
-	Payload   interface{} `json:"payload"`
-	Timestamp time.Time   `json:"timestamp"`
+	ID            string      `json:"id"`
+	Type          string      `json:"type"`
+	Version       int         `json:"version"`                  // Versão do schema do payload
+	Source        string      `json:"source"`                   // Módulo de origem
+	CorrelationID string      `json:"correlation_id,omitempty"` // Requisição ou fluxo que originou o evento
+	CausationID   string      `json:"causation_id,omitempty"`   // Evento que causou este evento
+	Payload       interface{} `json:"payload"`
+	Timestamp     time.Time   `json:"timestamp"`
 }
 
 type UserCreatedEvent struct {
@@ -204,6 +256,65 @@ type ProductStockUpdatedEvent struct {
 	NewStock  int    `json:"new_stock"`
 }
 
+type ProductUpdatedEvent struct {
+	ProductID string `json:"product_id"`
+}
+
+type UserUpdatedEvent struct {
+	UserID string `json:"user_id"`
+}
+
+type UserDeletedEvent struct {
+	UserID string `json:"user_id"`
+}
+
+type UserRestoredEvent struct {
+	UserID string `json:"user_id"`
+}
+
+type UserPurgedEvent struct {
+	UserID string `json:"user_id"`
+}
+
+type ProductDeletedEvent struct {
+	ProductID string `json:"product_id"`
+}
+
+type ProductRestoredEvent struct {
+	ProductID string `json:"product_id"`
+}
+
+type ProductPurgedEvent struct {
+	ProductID string `json:"product_id"`
+}
+
+type OrderStatusUpdatedEvent struct {
+	OrderID   string      `json:"order_id"`
+	UserID    string      `json:"user_id"`
+	OldStatus OrderStatus `json:"old_status"`
+	NewStatus OrderStatus `json:"new_status"`
+}
+
+type OrderCancelledEvent struct {
+	OrderID string  `json:"order_id"`
+	UserID  string  `json:"user_id"`
+	Total   float64 `json:"total"`
+}
+
+type OrderDeletedEvent struct {
+	OrderID string `json:"order_id"`
+	UserID  string `json:"user_id"`
+}
+
+type OrderRestoredEvent struct {
+	OrderID string `json:"order_id"`
+	UserID  string `json:"user_id"`
+}
+
+type OrderPurgedEvent struct {
+	OrderID string `json:"order_id"`
+}
+
 type PasswordHasher interface {
 	Hash(password string) (string, error)
 	Verify(password, hash string) bool
@@ -224,9 +335,13 @@ type TokenGenerator interface {
 type UserHandler interface {
 	CreateUser(ctx *gin.Context)
 	GetUser(ctx *gin.Context)
+	GetCurrentUser(ctx *gin.Context)
 	UpdateUser(ctx *gin.Context)
 	DeleteUser(ctx *gin.Context)
 	ValidateUser(ctx *gin.Context)
+	ListDeletedUsers(ctx *gin.Context)
+	RestoreUser(ctx *gin.Context)
+	PurgeUser(ctx *gin.Context)
 }
 
 type ProductHandler interface {
@@ -236,6 +351,9 @@ type ProductHandler interface {
 	DeleteProduct(ctx *gin.Context)
 	GetProducts(ctx *gin.Context)
 	UpdateStock(ctx *gin.Context)
+	ListDeletedProducts(ctx *gin.Context)
+	RestoreProduct(ctx *gin.Context)
+	PurgeProduct(ctx *gin.Context)
 }
 
 type OrderHandler interface {
@@ -244,4 +362,8 @@ type OrderHandler interface {
 	GetOrdersByUser(ctx *gin.Context)
 	UpdateOrderStatus(ctx *gin.Context)
 	CancelOrder(ctx *gin.Context)
+	DeleteOrder(ctx *gin.Context)
+	ListDeletedOrders(ctx *gin.Context)
+	RestoreOrder(ctx *gin.Context)
+	PurgeOrder(ctx *gin.Context)
 }
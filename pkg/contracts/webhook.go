@@ -0,0 +1,110 @@
+package contracts
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookService define a interface para o serviço de webhooks de saída
+type WebhookService interface {
+	CreateEndpoint(ctx context.Context, req CreateWebhookEndpointRequest) (*WebhookEndpoint, error)
+	GetEndpoint(ctx context.Context, id string) (*WebhookEndpoint, error)
+	UpdateEndpoint(ctx context.Context, id string, req UpdateWebhookEndpointRequest) (*WebhookEndpoint, error)
+	DeleteEndpoint(ctx context.Context, id string) error
+	ListEndpoints(ctx context.Context) ([]*WebhookEndpoint, error)
+	GetDelivery(ctx context.Context, id string) (*WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, filter WebhookDeliveryFilter) ([]*WebhookDelivery, error)
+	ResendDelivery(ctx context.Context, id string) (*WebhookDelivery, error)
+	HandleEvent(ctx context.Context, event Event) error
+}
+
+// WebhookRepository define a interface para persistência de endpoints e entregas
+type WebhookRepository interface {
+	CreateEndpoint(ctx context.Context, endpoint *WebhookEndpoint) error
+	GetEndpoint(ctx context.Context, id string) (*WebhookEndpoint, error)
+	UpdateEndpoint(ctx context.Context, endpoint *WebhookEndpoint) error
+	DeleteEndpoint(ctx context.Context, id string) error
+	ListEndpoints(ctx context.Context) ([]*WebhookEndpoint, error)
+	CreateDelivery(ctx context.Context, delivery *WebhookDelivery) error
+	GetDelivery(ctx context.Context, id string) (*WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *WebhookDelivery) error
+	ListDeliveries(ctx context.Context, filter WebhookDeliveryFilter) ([]*WebhookDelivery, error)
+
+	// ClaimDueDeliveries reserva por lockFor as entregas pendentes vencidas e as
+	// retorna; uma entrega reservada não é retornada a outro dispatcher até o
+	// UpdateDelivery, que libera a reserva, ou até a reserva expirar
+	ClaimDueDeliveries(ctx context.Context, now time.Time, limit int, lockFor time.Duration) ([]*WebhookDelivery, error)
+}
+
+// WebhookEndpoint é um destino externo inscrito em tipos de evento
+type WebhookEndpoint struct {
+	ID         string    `json:"id"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"secret,omitempty"` // Retornado apenas na criação
+	EventTypes []string  `json:"event_types"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// WebhookDeliveryStatus representa o estado de uma entrega
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery registra o envio de um evento para um endpoint
+type WebhookDelivery struct {
+	ID            string                `json:"id"`
+	EndpointID    string                `json:"endpoint_id"`
+	EventID       string                `json:"event_id"`
+	EventType     string                `json:"event_type"`
+	Payload       json.RawMessage       `json:"payload"`
+	Status        WebhookDeliveryStatus `json:"status"`
+	Attempts      int                   `json:"attempts"`
+	ResponseCode  int                   `json:"response_code,omitempty"`
+	ResponseBody  string                `json:"response_body,omitempty"`
+	LastError     string                `json:"last_error,omitempty"`
+	NextAttemptAt *time.Time            `json:"next_attempt_at,omitempty"`
+	DeliveredAt   *time.Time            `json:"delivered_at,omitempty"`
+	CreatedAt     time.Time             `json:"created_at"`
+	UpdatedAt     time.Time             `json:"updated_at"`
+}
+
+type CreateWebhookEndpointRequest struct {
+	URL        string   `json:"url" validate:"required,url"`
+	Secret     string   `json:"secret,omitempty"` // Gerado quando vazio
+	EventTypes []string `json:"event_types" validate:"required,min=1"`
+	Active     *bool    `json:"active,omitempty"`
+}
+
+type UpdateWebhookEndpointRequest struct {
+	URL        *string  `json:"url,omitempty" validate:"omitempty,url"`
+	Secret     *string  `json:"secret,omitempty"`
+	EventTypes []string `json:"event_types,omitempty"`
+	Active     *bool    `json:"active,omitempty"`
+}
+
+type WebhookDeliveryFilter struct {
+	EndpointID *string
+	Status     *WebhookDeliveryStatus
+	Limit      int
+	Offset     int
+}
+
+type WebhookHandler interface {
+	CreateEndpoint(ctx *gin.Context)
+	GetEndpoint(ctx *gin.Context)
+	UpdateEndpoint(ctx *gin.Context)
+	DeleteEndpoint(ctx *gin.Context)
+	ListEndpoints(ctx *gin.Context)
+	GetDelivery(ctx *gin.Context)
+	ListDeliveries(ctx *gin.Context)
+	ResendDelivery(ctx *gin.Context)
+}
@@ -0,0 +1,225 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"go-modular-monolith/pkg/contracts"
+)
+
+// BackpressurePolicy define o comportamento do Publish quando a fila do tópico está cheia
+type BackpressurePolicy string
+
+const (
+	// BackpressureBlock bloqueia o Publish até haver espaço na fila (ou o contexto expirar)
+	BackpressureBlock BackpressurePolicy = "block"
+	// BackpressureDrop descarta o evento silenciosamente
+	BackpressureDrop BackpressurePolicy = "drop"
+	// BackpressureError retorna ErrQueueFull para o chamador
+	BackpressureError BackpressurePolicy = "error"
+)
+
+var (
+	// ErrQueueFull é retornado quando a fila do tópico está cheia e a política é BackpressureError
+	ErrQueueFull = errors.New("event queue is full")
+	// ErrBusClosed é retornado ao publicar depois do Close
+	ErrBusClosed = errors.New("event bus is closed")
+)
+
+// AsyncConfig contém as configurações do modo assíncrono
+type AsyncConfig struct {
+	Workers      int                // Workers por tópico
+	QueueSize    int                // Capacidade da fila de cada tópico
+	Backpressure BackpressurePolicy // Política aplicada quando a fila está cheia
+}
+
+// DefaultAsyncConfig retorna a configuração padrão do modo assíncrono
+func DefaultAsyncConfig() AsyncConfig {
+	return AsyncConfig{
+		Workers:      4,
+		QueueSize:    100,
+		Backpressure: BackpressureBlock,
+	}
+}
+
+// ParseBackpressurePolicy converte uma string de configuração em BackpressurePolicy
+func ParseBackpressurePolicy(value string) (BackpressurePolicy, error) {
+	switch policy := BackpressurePolicy(value); policy {
+	case BackpressureBlock, BackpressureDrop, BackpressureError:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid backpressure policy: %s", value)
+	}
+}
+
+// WithAsyncDispatch faz o bus entregar eventos em background através de um worker pool
+func WithAsyncDispatch(config AsyncConfig) Option {
+	defaults := DefaultAsyncConfig()
+	if config.Workers <= 0 {
+		config.Workers = defaults.Workers
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = defaults.QueueSize
+	}
+	if config.Backpressure == "" {
+		config.Backpressure = defaults.Backpressure
+	}
+
+	return func(e *EventBus) {
+		e.async = &asyncDispatcher{
+			config:   config,
+			dispatch: e.dispatch,
+			queues:   make(map[string]chan queuedEvent),
+			done:     make(chan struct{}),
+		}
+	}
+}
+
+// DroppedEvents retorna quantos eventos foram descartados pela política BackpressureDrop
+func (e *EventBus) DroppedEvents() uint64 {
+	if e.async == nil {
+		return 0
+	}
+	return e.async.dropped.Load()
+}
+
+type queuedEvent struct {
+	ctx   context.Context
+	event contracts.Event
+}
+
+// asyncDispatcher mantém uma fila limitada e um pool de workers por tópico.
+// O lock protege só o mapa de filas: envios acontecem fora dele, para que um
+// Publish bloqueado em uma fila cheia não impeça outros tópicos de serem criados.
+type asyncDispatcher struct {
+	config   AsyncConfig
+	dispatch func(ctx context.Context, event contracts.Event)
+
+	mu      sync.RWMutex
+	queues  map[string]chan queuedEvent
+	closed  bool
+	done    chan struct{}  // Fechado no close; desbloqueia envios em espera
+	senders sync.WaitGroup // Envios em andamento; as filas só fecham sem nenhum
+	wg      sync.WaitGroup // Workers
+	dropped atomic.Uint64
+}
+
+func (d *asyncDispatcher) enqueue(ctx context.Context, event contracts.Event) error {
+	queue, err := d.reserve(event.Type)
+	if err != nil {
+		return err
+	}
+	defer d.senders.Done()
+
+	// O handler roda depois da resposta HTTP, então o cancelamento da requisição
+	// não deve ser propagado (os valores do contexto são preservados)
+	item := queuedEvent{ctx: context.WithoutCancel(ctx), event: event}
+
+	switch d.config.Backpressure {
+	case BackpressureDrop:
+		select {
+		case queue <- item:
+		default:
+			d.dropped.Add(1)
+		}
+		return nil
+	case BackpressureError:
+		select {
+		case queue <- item:
+			return nil
+		default:
+			return ErrQueueFull
+		}
+	default:
+		// Um handler que publica recebe um contexto sem cancelamento: sem prazo
+		// próprio ele espera até haver espaço ou até o Close
+		select {
+		case queue <- item:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-d.done:
+			return ErrBusClosed
+		}
+	}
+}
+
+// reserve retorna a fila do tópico, criando-a com seus workers se preciso, e
+// registra um envio em andamento que o chamador encerra com senders.Done
+func (d *asyncDispatcher) reserve(eventType string) (chan queuedEvent, error) {
+	d.mu.RLock()
+	queue, exists := d.queues[eventType]
+	if exists && !d.closed {
+		d.senders.Add(1)
+		d.mu.RUnlock()
+		return queue, nil
+	}
+	d.mu.RUnlock()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return nil, ErrBusClosed
+	}
+	if queue, exists = d.queues[eventType]; !exists {
+		queue = d.startTopic(eventType)
+	}
+	d.senders.Add(1)
+	return queue, nil
+}
+
+// startTopic cria a fila e os workers do tópico; o chamador deve segurar o lock
+func (d *asyncDispatcher) startTopic(eventType string) chan queuedEvent {
+	queue := make(chan queuedEvent, d.config.QueueSize)
+	d.queues[eventType] = queue
+
+	for i := 0; i < d.config.Workers; i++ {
+		d.wg.Add(1)
+		go func() {
+			defer d.wg.Done()
+			for item := range queue {
+				d.dispatch(item.ctx, item.event)
+			}
+		}()
+	}
+
+	return queue
+}
+
+// close para de aceitar eventos e aguarda os workers drenarem as filas.
+// Envios bloqueados em filas cheias retornam ErrBusClosed; as filas só são
+// fechadas depois que nenhum envio está em andamento.
+func (d *asyncDispatcher) close(ctx context.Context) error {
+	d.mu.Lock()
+	alreadyClosed := d.closed
+	d.closed = true
+	d.mu.Unlock()
+
+	if !alreadyClosed {
+		close(d.done)
+		d.senders.Wait()
+
+		d.mu.Lock()
+		for _, queue := range d.queues {
+			close(queue)
+		}
+		d.mu.Unlock()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("event bus did not drain before deadline: %w", ctx.Err())
+	}
+}
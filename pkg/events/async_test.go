@@ -0,0 +1,164 @@
+package events_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/events"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockedBus cria um bus assíncrono com um worker e fila de um evento, cujo
+// handler de "order.created" espera release. Ao retornar, o worker está
+// ocupado e a fila cheia: o próximo Publish encontra a fila lotada.
+func blockedBus(t *testing.T, policy events.BackpressurePolicy) (bus *events.EventBus, release func(), handled *atomic.Int32) {
+	t.Helper()
+	bus = events.NewEventBus(events.WithAsyncDispatch(events.AsyncConfig{
+		Workers:      1,
+		QueueSize:    1,
+		Backpressure: policy,
+	}))
+
+	handled = &atomic.Int32{}
+	started := make(chan struct{}, 1)
+	gate := make(chan struct{})
+	_, err := bus.Subscribe("order.created", func(ctx context.Context, event contracts.Event) error {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-gate
+		handled.Add(1)
+		return nil
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, bus.Publish(ctx, contracts.Event{Type: "order.created"}))
+	<-started // O worker pegou o primeiro evento
+	require.NoError(t, bus.Publish(ctx, contracts.Event{Type: "order.created"}))
+
+	var once sync.Once
+	release = func() { once.Do(func() { close(gate) }) }
+	t.Cleanup(release)
+	return bus, release, handled
+}
+
+func TestAsyncBackpressureBlock(t *testing.T) {
+	bus, release, handled := blockedBus(t, events.BackpressureBlock)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, bus.Publish(ctx, contracts.Event{Type: "order.created"}), context.DeadlineExceeded)
+
+	// Com espaço na fila o Publish bloqueado segue adiante
+	done := make(chan error, 1)
+	go func() { done <- bus.Publish(context.Background(), contracts.Event{Type: "order.created"}) }()
+	release()
+	require.NoError(t, <-done)
+
+	require.NoError(t, bus.Close(context.Background()))
+	assert.Equal(t, int32(3), handled.Load())
+}
+
+func TestAsyncBackpressureDrop(t *testing.T) {
+	bus, release, handled := blockedBus(t, events.BackpressureDrop)
+
+	assert.NoError(t, bus.Publish(context.Background(), contracts.Event{Type: "order.created"}))
+	assert.Equal(t, uint64(1), bus.DroppedEvents())
+
+	release()
+	require.NoError(t, bus.Close(context.Background()))
+	assert.Equal(t, int32(2), handled.Load())
+}
+
+func TestAsyncBackpressureError(t *testing.T) {
+	bus, release, handled := blockedBus(t, events.BackpressureError)
+
+	assert.ErrorIs(t, bus.Publish(context.Background(), contracts.Event{Type: "order.created"}), events.ErrQueueFull)
+
+	release()
+	require.NoError(t, bus.Close(context.Background()))
+	assert.Equal(t, int32(2), handled.Load())
+}
+
+// Um handler que publica em tópicos novos não pode travar enquanto outro
+// Publish espera em uma fila cheia
+func TestAsyncHandlerPublishingWhileQueueIsFull(t *testing.T) {
+	bus := events.NewEventBus(events.WithAsyncDispatch(events.AsyncConfig{
+		Workers:      1,
+		QueueSize:    1,
+		Backpressure: events.BackpressureBlock,
+	}))
+
+	var followUps atomic.Int32
+	_, err := bus.Subscribe("order.*", func(ctx context.Context, event contracts.Event) error {
+		if event.Type == "order.created" {
+			// Cada publicação cria um tópico novo (e a fila dele)
+			return bus.Publish(ctx, contracts.Event{Type: fmt.Sprintf("order.follow-up-%d", followUps.Add(1))})
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 20; i++ {
+			assert.NoError(t, bus.Publish(context.Background(), contracts.Event{Type: "order.created"}))
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("publish deadlocked")
+	}
+
+	require.NoError(t, bus.Close(context.Background()))
+	assert.Equal(t, int32(20), followUps.Load())
+}
+
+func TestAsyncCloseDrainsQueuedEvents(t *testing.T) {
+	bus := events.NewEventBus(events.WithAsyncDispatch(events.AsyncConfig{Workers: 2, QueueSize: 50}))
+
+	var handled atomic.Int32
+	_, err := bus.Subscribe("order.created", func(ctx context.Context, event contracts.Event) error {
+		time.Sleep(time.Millisecond)
+		handled.Add(1)
+		return nil
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 30; i++ {
+		require.NoError(t, bus.Publish(context.Background(), contracts.Event{Type: "order.created"}))
+	}
+
+	require.NoError(t, bus.Close(context.Background()))
+	assert.Equal(t, int32(30), handled.Load())
+
+	assert.ErrorIs(t, bus.Publish(context.Background(), contracts.Event{Type: "order.created"}), events.ErrBusClosed)
+	assert.NoError(t, bus.Close(context.Background()), "close is idempotent")
+}
+
+func TestAsyncCloseReleasesBlockedPublish(t *testing.T) {
+	bus, release, _ := blockedBus(t, events.BackpressureBlock)
+
+	blocked := make(chan error, 1)
+	go func() { blocked <- bus.Publish(context.Background(), contracts.Event{Type: "order.created"}) }()
+	time.Sleep(10 * time.Millisecond)
+
+	closed := make(chan error, 1)
+	go func() { closed <- bus.Close(context.Background()) }()
+
+	assert.ErrorIs(t, <-blocked, events.ErrBusClosed)
+	release()
+	require.NoError(t, <-closed)
+}
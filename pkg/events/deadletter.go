@@ -0,0 +1,138 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrDeadLetterNotFound é retornado quando a dead letter não existe no store
+var ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+// DeadLetter representa um evento que esgotou as tentativas de uma inscrição
+type DeadLetter struct {
+	Metadata
+	ID             string          `json:"id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	EventTimestamp time.Time       `json:"event_timestamp"`
+	Subscription   string          `json:"subscription"`
+	Attempts       int             `json:"attempts"`
+	LastError      string          `json:"last_error"`
+	FailedAt       time.Time       `json:"failed_at"`
+}
+
+// DeadLetterFilter filtra a listagem e o expurgo de dead letters
+type DeadLetterFilter struct {
+	EventType    *string
+	Subscription *string
+	Limit        int
+	Offset       int
+}
+
+// DeadLetterStore persiste eventos que falharam definitivamente
+type DeadLetterStore interface {
+	Save(ctx context.Context, deadLetter *DeadLetter) error
+	Get(ctx context.Context, id string) (*DeadLetter, error)
+	List(ctx context.Context, filter DeadLetterFilter) ([]*DeadLetter, error)
+	Delete(ctx context.Context, id string) error
+	Purge(ctx context.Context, filter DeadLetterFilter) (int64, error)
+}
+
+// MemoryDeadLetterStore implementa DeadLetterStore em memória
+type MemoryDeadLetterStore struct {
+	deadLetters map[string]*DeadLetter
+	mu          sync.RWMutex
+}
+
+// NewMemoryDeadLetterStore cria um store de dead letters em memória
+func NewMemoryDeadLetterStore() *MemoryDeadLetterStore {
+	return &MemoryDeadLetterStore{
+		deadLetters: make(map[string]*DeadLetter),
+	}
+}
+
+func (s *MemoryDeadLetterStore) Save(ctx context.Context, deadLetter *DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored := *deadLetter
+	s.deadLetters[deadLetter.ID] = &stored
+	return nil
+}
+
+func (s *MemoryDeadLetterStore) Get(ctx context.Context, id string) (*DeadLetter, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	deadLetter, exists := s.deadLetters[id]
+	if !exists {
+		return nil, ErrDeadLetterNotFound
+	}
+	found := *deadLetter
+	return &found, nil
+}
+
+func (s *MemoryDeadLetterStore) List(ctx context.Context, filter DeadLetterFilter) ([]*DeadLetter, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []*DeadLetter
+	for _, deadLetter := range s.deadLetters {
+		if filter.matches(deadLetter) {
+			found := *deadLetter
+			result = append(result, &found)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].FailedAt.Before(result[j].FailedAt)
+	})
+
+	if filter.Offset > 0 {
+		if filter.Offset >= len(result) {
+			return []*DeadLetter{}, nil
+		}
+		result = result[filter.Offset:]
+	}
+	if filter.Limit > 0 && filter.Limit < len(result) {
+		result = result[:filter.Limit]
+	}
+
+	return result, nil
+}
+
+func (s *MemoryDeadLetterStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.deadLetters[id]; !exists {
+		return ErrDeadLetterNotFound
+	}
+	delete(s.deadLetters, id)
+	return nil
+}
+
+func (s *MemoryDeadLetterStore) Purge(ctx context.Context, filter DeadLetterFilter) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var purged int64
+	for id, deadLetter := range s.deadLetters {
+		if filter.matches(deadLetter) {
+			delete(s.deadLetters, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+func (f DeadLetterFilter) matches(deadLetter *DeadLetter) bool {
+	if f.EventType != nil && deadLetter.EventType != *f.EventType {
+		return false
+	}
+	if f.Subscription != nil && deadLetter.Subscription != *f.Subscription {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,156 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/events"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyHandler falha nas primeiras failures chamadas e conta as execuções
+type flakyHandler struct {
+	failures int
+	calls    int
+}
+
+func (h *flakyHandler) handle(ctx context.Context, event contracts.Event) error {
+	h.calls++
+	if h.calls <= h.failures {
+		return errors.New("inventory unavailable")
+	}
+	return nil
+}
+
+func listDeadLetters(t *testing.T, store events.DeadLetterStore) []*events.DeadLetter {
+	t.Helper()
+	stored, err := store.List(context.Background(), events.DeadLetterFilter{})
+	require.NoError(t, err)
+	return stored
+}
+
+func TestSubscriptionNameRequiredForDeadLettersAndDiskQueue(t *testing.T) {
+	handler := func(ctx context.Context, event contracts.Event) error { return nil }
+
+	_, err := events.NewEventBus(events.WithDeadLetterStore(events.NewMemoryDeadLetterStore())).Subscribe("order.*", handler)
+	assert.ErrorIs(t, err, events.ErrSubscriptionNameRequired)
+
+	queue, err := events.OpenDiskQueue(events.DiskConfig{Dir: t.TempDir()})
+	require.NoError(t, err)
+	bus := events.NewEventBus(events.WithDiskQueue(queue))
+	defer bus.Close(context.Background())
+	_, err = bus.SubscribeWithOptions("order.*", handler, events.WithRetryPolicy(events.NoRetry()))
+	assert.ErrorIs(t, err, events.ErrSubscriptionNameRequired)
+
+	// Sem identidade persistida o nome gerado basta
+	_, err = events.NewEventBus().Subscribe("order.*", handler)
+	assert.NoError(t, err)
+}
+
+func TestRetryPolicyRetriesUntilSuccess(t *testing.T) {
+	deadLetters := events.NewMemoryDeadLetterStore()
+	bus := events.NewEventBus(events.WithDeadLetterStore(deadLetters))
+
+	handler := &flakyHandler{failures: 2}
+	_, err := bus.SubscribeWithOptions("order.created", handler.handle,
+		events.WithSubscriptionName("inventory"),
+		events.WithRetryPolicy(events.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, bus.Publish(context.Background(), contracts.Event{Type: "order.created"}))
+	assert.Equal(t, 3, handler.calls)
+	assert.Empty(t, listDeadLetters(t, deadLetters))
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := events.RetryPolicy{MaxAttempts: 5, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2}
+
+	assert.Equal(t, 100*time.Millisecond, policy.Backoff(1))
+	assert.Equal(t, 200*time.Millisecond, policy.Backoff(2))
+	assert.Equal(t, 800*time.Millisecond, policy.Backoff(4))
+	assert.Equal(t, time.Second, policy.Backoff(5), "capped at MaxBackoff")
+
+	policy.Jitter = 0.2
+	for i := 0; i < 100; i++ {
+		backoff := policy.Backoff(2)
+		assert.GreaterOrEqual(t, backoff, 160*time.Millisecond)
+		assert.LessOrEqual(t, backoff, 240*time.Millisecond)
+	}
+}
+
+func TestExhaustedRetriesGoToDeadLettersAndRedrive(t *testing.T) {
+	ctx := context.Background()
+	deadLetters := events.NewMemoryDeadLetterStore()
+	bus := events.NewEventBus(events.WithDeadLetterStore(deadLetters), events.WithDefaultRetryPolicy(events.RetryPolicy{MaxAttempts: 2}))
+
+	handler := &flakyHandler{failures: 4}
+	_, err := bus.SubscribeWithOptions("order.*", handler.handle, events.WithSubscriptionName("inventory"))
+	require.NoError(t, err)
+
+	ctx = events.WithCorrelationID(ctx, "req-1")
+	require.NoError(t, bus.Publish(ctx, contracts.Event{Type: "order.created", Payload: map[string]string{"order_id": "order-1"}}))
+	assert.Equal(t, 2, handler.calls)
+
+	stored := listDeadLetters(t, deadLetters)
+	require.Len(t, stored, 1)
+	deadLetter := stored[0]
+	assert.Equal(t, "inventory", deadLetter.Subscription)
+	assert.Equal(t, "order.created", deadLetter.EventType)
+	assert.Equal(t, 2, deadLetter.Attempts)
+	assert.Equal(t, "inventory unavailable", deadLetter.LastError)
+	assert.Equal(t, "req-1", deadLetter.CorrelationID)
+	assert.JSONEq(t, `{"order_id":"order-1"}`, string(deadLetter.Payload))
+
+	// Ainda falhando: a dead letter acumula as tentativas
+	err = bus.Redrive(ctx, deadLetter.ID)
+	assert.ErrorContains(t, err, "redrive failed")
+	updated, err := deadLetters.Get(ctx, deadLetter.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 4, updated.Attempts)
+
+	// No segundo redrive o handler se recupera
+	require.NoError(t, bus.Redrive(ctx, deadLetter.ID))
+	assert.Equal(t, 5, handler.calls)
+	assert.Empty(t, listDeadLetters(t, deadLetters))
+}
+
+// O nome explícito é o que permite reentregar uma dead letter depois de um
+// restart, mesmo que as inscrições sejam registradas em outra ordem
+func TestRedriveFindsSubscriptionByNameAfterRestart(t *testing.T) {
+	ctx := context.Background()
+	deadLetters := events.NewMemoryDeadLetterStore()
+
+	bus := events.NewEventBus(events.WithDeadLetterStore(deadLetters))
+	_, err := bus.SubscribeWithOptions("order.created", (&flakyHandler{failures: 1}).handle, events.WithSubscriptionName("inventory"))
+	require.NoError(t, err)
+	require.NoError(t, bus.Publish(ctx, contracts.Event{Type: "order.created"}))
+	stored := listDeadLetters(t, deadLetters)
+	require.Len(t, stored, 1)
+
+	restarted := events.NewEventBus(events.WithDeadLetterStore(deadLetters))
+	var delivered []string
+	for _, name := range []string{"email", "inventory"} {
+		name := name
+		_, err := restarted.SubscribeWithOptions("order.created", func(ctx context.Context, event contracts.Event) error {
+			delivered = append(delivered, name)
+			return nil
+		}, events.WithSubscriptionName(name))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, restarted.Redrive(ctx, stored[0].ID))
+	assert.Equal(t, []string{"inventory"}, delivered)
+
+	_, err = deadLetters.Get(ctx, stored[0].ID)
+	assert.ErrorIs(t, err, events.ErrDeadLetterNotFound)
+
+	// Sem a inscrição a dead letter fica guardada
+	require.NoError(t, deadLetters.Save(ctx, &events.DeadLetter{ID: "dl-1", EventType: "order.created", Subscription: "removed"}))
+	assert.ErrorIs(t, restarted.Redrive(ctx, "dl-1"), events.ErrSubscriptionNotFound)
+}
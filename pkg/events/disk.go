@@ -0,0 +1,467 @@
+package events
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go-modular-monolith/pkg/contracts"
+
+	"github.com/google/uuid"
+)
+
+// DiskConfig contém as configurações da fila em disco
+type DiskConfig struct {
+	Dir          string        // Diretório do log e dos offsets
+	Fsync        bool          // Sincroniza o arquivo a cada evento (mais lento, sobrevive a queda da máquina)
+	PollInterval time.Duration // Intervalo de verificação de novos eventos pelos consumidores
+}
+
+// diskErrorBackoff espaça as novas tentativas de um consumidor após erros de
+// leitura ou de commit do offset, que não derrubam a inscrição
+var diskErrorBackoff = RetryPolicy{
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     30 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+// corruptRecordError é uma linha do log que não é um registro válido. Ler de
+// novo não muda o resultado: o consumidor a envia para a dead letter store.
+type corruptRecordError struct {
+	offset int64
+	line   []byte
+	err    error
+}
+
+func (e *corruptRecordError) Error() string {
+	return fmt.Sprintf("corrupted disk queue record at offset %d: %v", e.offset, e.err)
+}
+
+func (e *corruptRecordError) Unwrap() error {
+	return e.err
+}
+
+// diskRecord é uma linha do log em disco
+type diskRecord struct {
+	Metadata
+	Type      string          `json:"type"`
+	Payload   json.RawMessage `json:"payload"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+// DiskQueue é um log append-only em arquivo com offsets por consumer group.
+// Cada linha é um evento em JSON; o offset é a posição em bytes da próxima
+// linha a ser lida pelo grupo e é gravado depois que o handler processa o evento.
+type DiskQueue struct {
+	config DiskConfig
+
+	mu     sync.Mutex
+	file   *os.File
+	size   int64
+	notify chan struct{} // Fechado e recriado a cada append para acordar os consumidores
+	closed bool
+}
+
+// OpenDiskQueue abre (ou cria) a fila no diretório configurado.
+// Uma última linha incompleta, resultado de uma queda durante a escrita, é descartada.
+func OpenDiskQueue(config DiskConfig) (*DiskQueue, error) {
+	if config.Dir == "" {
+		return nil, errors.New("disk queue directory is required")
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = time.Second
+	}
+
+	if err := os.MkdirAll(filepath.Join(config.Dir, "offsets"), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create disk queue directory: %w", err)
+	}
+
+	file, err := os.OpenFile(filepath.Join(config.Dir, "events.log"), os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open disk queue: %w", err)
+	}
+
+	size, err := recoverTail(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &DiskQueue{
+		config: config,
+		file:   file,
+		size:   size,
+		notify: make(chan struct{}),
+	}, nil
+}
+
+// recoverTail trunca o arquivo no fim da última linha completa
+func recoverTail(file *os.File) (int64, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat disk queue: %w", err)
+	}
+
+	size := info.Size()
+	if size == 0 {
+		return 0, nil
+	}
+
+	// Procura o último '\n' a partir do fim, em blocos
+	const chunk = 4096
+	buf := make([]byte, chunk)
+	end := size
+	for end > 0 {
+		start := end - chunk
+		if start < 0 {
+			start = 0
+		}
+		n, err := file.ReadAt(buf[:end-start], start)
+		if err != nil && err != io.EOF {
+			return 0, fmt.Errorf("failed to read disk queue: %w", err)
+		}
+		if i := bytes.LastIndexByte(buf[:n], '\n'); i >= 0 {
+			end = start + int64(i) + 1
+			break
+		}
+		end = start
+	}
+
+	if end != size {
+		if err := file.Truncate(end); err != nil {
+			return 0, fmt.Errorf("failed to truncate incomplete disk queue record: %w", err)
+		}
+	}
+	return end, nil
+}
+
+// Append grava o evento no fim do log
+func (q *DiskQueue) Append(event contracts.Event) error {
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode event payload: %w", err)
+	}
+
+	line, err := json.Marshal(diskRecord{
+		Metadata:  MetadataOf(event),
+		Type:      event.Type,
+		Payload:   payload,
+		Timestamp: event.Timestamp,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode disk queue record: %w", err)
+	}
+	line = append(line, '\n')
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrBusClosed
+	}
+
+	if _, err := q.file.WriteAt(line, q.size); err != nil {
+		return fmt.Errorf("failed to append to disk queue: %w", err)
+	}
+	if q.config.Fsync {
+		if err := q.file.Sync(); err != nil {
+			return fmt.Errorf("failed to sync disk queue: %w", err)
+		}
+	}
+	q.size += int64(len(line))
+
+	close(q.notify)
+	q.notify = make(chan struct{})
+	return nil
+}
+
+// read lê o registro que começa no offset e retorna o offset seguinte.
+// Retorna io.EOF quando não há registro completo no offset e um
+// *corruptRecordError, com o offset seguinte, quando a linha é inválida.
+func (q *DiskQueue) read(offset int64) (*diskRecord, int64, error) {
+	q.mu.Lock()
+	size := q.size
+	q.mu.Unlock()
+
+	if offset >= size {
+		return nil, offset, io.EOF
+	}
+
+	reader := bufio.NewReader(io.NewSectionReader(q.file, offset, size-offset))
+	line, err := reader.ReadBytes('\n')
+	if err != nil {
+		return nil, offset, io.EOF
+	}
+
+	next := offset + int64(len(line))
+	var record diskRecord
+	if err := json.Unmarshal(line, &record); err != nil {
+		return nil, next, &corruptRecordError{offset: offset, line: line, err: err}
+	}
+	return &record, next, nil
+}
+
+// wait retorna um canal fechado no próximo append
+func (q *DiskQueue) wait() <-chan struct{} {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.notify
+}
+
+// end retorna o offset do fim do log
+func (q *DiskQueue) end() int64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.size
+}
+
+// CommittedOffset retorna o offset gravado do consumer group e se ele existe
+func (q *DiskQueue) CommittedOffset(group string) (int64, bool, error) {
+	data, err := os.ReadFile(q.offsetPath(group))
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read offset of %s: %w", group, err)
+	}
+
+	offset, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid offset of %s: %w", group, err)
+	}
+	return offset, true, nil
+}
+
+// Commit grava o offset do consumer group de forma atômica (arquivo temporário + rename)
+func (q *DiskQueue) Commit(group string, offset int64) error {
+	path := q.offsetPath(group)
+	tmp := path + ".tmp"
+
+	if err := os.WriteFile(tmp, []byte(strconv.FormatInt(offset, 10)), 0o644); err != nil {
+		return fmt.Errorf("failed to write offset of %s: %w", group, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to commit offset of %s: %w", group, err)
+	}
+	return nil
+}
+
+// offsetPath deriva o arquivo de offset do nome do grupo, que pode conter curingas
+func (q *DiskQueue) offsetPath(group string) string {
+	name := strings.NewReplacer("/", "_", "\\", "_", "*", "_all_", "?", "_any_").Replace(group)
+	return filepath.Join(q.config.Dir, "offsets", name+".offset")
+}
+
+// Close fecha o arquivo do log
+func (q *DiskQueue) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return nil
+	}
+	q.closed = true
+	close(q.notify)
+	return q.file.Close()
+}
+
+// WithDiskQueue faz o bus gravar os eventos na fila em disco. Cada inscrição é
+// um consumer group que lê o log a partir do seu offset gravado, de forma que
+// eventos sobrevivem a restarts e cada handler retoma de onde parou.
+// Um grupo novo começa no fim do log; use o replay do event store para
+// reprocessar o histórico.
+func WithDiskQueue(queue *DiskQueue) Option {
+	return func(e *EventBus) {
+		e.durable = &diskDispatcher{
+			queue:     queue,
+			bus:       e,
+			consumers: make(map[*subscription]*diskConsumer),
+		}
+	}
+}
+
+// diskDispatcher mantém um consumidor por inscrição
+type diskDispatcher struct {
+	queue *DiskQueue
+	bus   *EventBus
+
+	mu        sync.Mutex
+	consumers map[*subscription]*diskConsumer
+	wg        sync.WaitGroup
+	closed    bool
+}
+
+type diskConsumer struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// start inicia o consumidor da inscrição a partir do offset gravado do grupo
+func (d *diskDispatcher) start(sub *subscription) error {
+	offset, committed, err := d.queue.CommittedOffset(sub.name)
+	if err != nil {
+		return err
+	}
+	if !committed {
+		offset = d.queue.end()
+		if err := d.queue.Commit(sub.name, offset); err != nil {
+			return err
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return ErrBusClosed
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	consumer := &diskConsumer{cancel: cancel, done: make(chan struct{})}
+	d.consumers[sub] = consumer
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		defer close(consumer.done)
+		d.consume(ctx, sub, offset)
+	}()
+	return nil
+}
+
+// consume entrega os eventos do log à inscrição, gravando o offset após cada um.
+// Falhas do handler seguem a política de retry e a dead letter store da
+// inscrição; registros corrompidos ou recusados pelo registry vão direto para a
+// dead letter store. Erros de leitura e de commit são repetidos com backoff
+// até o consumidor ser encerrado: a inscrição nunca para sozinha.
+func (d *diskDispatcher) consume(ctx context.Context, sub *subscription, offset int64) {
+	failures := 0 // Erros seguidos de leitura, para o backoff
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		notify := d.queue.wait()
+		record, next, err := d.queue.read(offset)
+		var corrupt *corruptRecordError
+		switch {
+		case err == io.EOF:
+			select {
+			case <-ctx.Done():
+				return
+			case <-notify:
+			case <-time.After(d.queue.config.PollInterval):
+			}
+			continue
+
+		case errors.As(err, &corrupt):
+			d.deadLetterLine(ctx, sub, corrupt)
+
+		case err != nil:
+			failures++
+			fmt.Printf("Error reading disk queue for %s (attempt %d): %v\n", sub.name, failures, err)
+			if sleep(ctx, diskErrorBackoff.Backoff(failures)) != nil {
+				return
+			}
+			continue
+
+		case sub.matches(record.Type) && sub.active.Load():
+			event := record.Metadata.Event(record.Type, record.Payload, record.Timestamp)
+			if decoded, err := d.bus.decode(event); err != nil {
+				d.bus.deadLetter(ctx, sub, event, 0, fmt.Errorf("failed to decode event at offset %d: %w", offset, err))
+			} else {
+				d.bus.deliver(ctx, sub, decoded)
+			}
+		}
+		failures = 0
+
+		// Interrompido durante a entrega: o evento é reentregue no próximo start
+		if ctx.Err() != nil {
+			return
+		}
+
+		// Sem o offset gravado o evento seria reentregue após um restart
+		for attempt := 1; ; attempt++ {
+			err := d.queue.Commit(sub.name, next)
+			if err == nil {
+				break
+			}
+			fmt.Printf("Error committing offset for %s (attempt %d): %v\n", sub.name, attempt, err)
+			if sleep(ctx, diskErrorBackoff.Backoff(attempt)) != nil {
+				return
+			}
+		}
+		offset = next
+	}
+}
+
+// deadLetterLine envia uma linha corrompida à dead letter store, com o texto
+// original como payload. Tipo e data do evento são desconhecidos: a data é a da falha.
+func (d *diskDispatcher) deadLetterLine(ctx context.Context, sub *subscription, corrupt *corruptRecordError) {
+	if d.bus.deadLetters == nil {
+		fmt.Printf("Skipping %v for %s\n", corrupt, sub.name)
+		return
+	}
+
+	payload, err := json.Marshal(string(bytes.TrimSuffix(corrupt.line, []byte("\n"))))
+	if err == nil {
+		now := time.Now()
+		err = d.bus.deadLetters.Save(context.WithoutCancel(ctx), &DeadLetter{
+			ID:             uuid.New().String(),
+			Payload:        payload,
+			EventTimestamp: now,
+			Subscription:   sub.name,
+			LastError:      corrupt.Error(),
+			FailedAt:       now,
+		})
+	}
+	if err != nil {
+		fmt.Printf("Error storing dead letter for %v in %s: %v\n", corrupt, sub.name, err)
+	}
+}
+
+// stop encerra o consumidor da inscrição; o offset gravado é mantido
+func (d *diskDispatcher) stop(sub *subscription) {
+	d.mu.Lock()
+	consumer, exists := d.consumers[sub]
+	delete(d.consumers, sub)
+	d.mu.Unlock()
+
+	if exists {
+		consumer.cancel()
+	}
+}
+
+// close encerra todos os consumidores, aguarda o evento em andamento e fecha o log
+func (d *diskDispatcher) close(ctx context.Context) error {
+	d.mu.Lock()
+	d.closed = true
+	for _, consumer := range d.consumers {
+		consumer.cancel()
+	}
+	d.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return d.queue.Close()
+	case <-ctx.Done():
+		return fmt.Errorf("disk queue consumers did not stop before deadline: %w", ctx.Err())
+	}
+}
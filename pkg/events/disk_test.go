@@ -0,0 +1,180 @@
+package events_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/events"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// openDiskBus abre o bus sobre a fila em dir; cada chamada simula um restart
+func openDiskBus(t *testing.T, dir string, opts ...events.Option) (*events.EventBus, *events.DiskQueue) {
+	t.Helper()
+	queue, err := events.OpenDiskQueue(events.DiskConfig{Dir: dir, PollInterval: 10 * time.Millisecond})
+	require.NoError(t, err)
+	return events.NewEventBus(append(opts, events.WithDiskQueue(queue))...), queue
+}
+
+// subscribeOrders inscreve o grupo "orders" e retorna o canal dos IDs de pedido
+// recebidos. Sem registry o payload lido do disco é o JSON gravado.
+func subscribeOrders(t *testing.T, bus *events.EventBus) <-chan string {
+	t.Helper()
+	received := make(chan string, 10)
+	_, err := bus.SubscribeWithOptions("order.*", func(ctx context.Context, event contracts.Event) error {
+		var payload struct {
+			OrderID string `json:"order_id"`
+		}
+		if err := json.Unmarshal(event.Payload.(json.RawMessage), &payload); err != nil {
+			return err
+		}
+		received <- payload.OrderID
+		return nil
+	}, events.WithSubscriptionName("orders"))
+	require.NoError(t, err)
+	return received
+}
+
+func publishOrder(t *testing.T, bus *events.EventBus, payload interface{}) {
+	t.Helper()
+	require.NoError(t, bus.Publish(context.Background(), contracts.Event{Type: "order.created", Payload: payload}))
+}
+
+func receive(t *testing.T, received <-chan string) string {
+	t.Helper()
+	select {
+	case id := <-received:
+		return id
+	case <-time.After(5 * time.Second):
+		t.Fatal("event not delivered")
+		return ""
+	}
+}
+
+func assertNothingReceived(t *testing.T, received <-chan string) {
+	t.Helper()
+	select {
+	case id := <-received:
+		t.Fatalf("unexpected event %s", id)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestDiskQueueResumesFromCommittedOffset(t *testing.T) {
+	dir := t.TempDir()
+
+	bus, _ := openDiskBus(t, dir)
+	received := subscribeOrders(t, bus)
+	publishOrder(t, bus, map[string]interface{}{"order_id": "order-1"})
+	assert.Equal(t, "order-1", receive(t, received))
+	require.NoError(t, bus.Close(context.Background()))
+
+	// Publicados com o grupo parado
+	bus, _ = openDiskBus(t, dir)
+	publishOrder(t, bus, map[string]interface{}{"order_id": "order-2"})
+	publishOrder(t, bus, map[string]interface{}{"order_id": "order-3"})
+	require.NoError(t, bus.Close(context.Background()))
+
+	bus, _ = openDiskBus(t, dir)
+	defer bus.Close(context.Background())
+	received = subscribeOrders(t, bus)
+	assert.Equal(t, "order-2", receive(t, received))
+	assert.Equal(t, "order-3", receive(t, received))
+	assertNothingReceived(t, received)
+}
+
+func TestDiskQueueSendsCorruptRecordToDeadLetters(t *testing.T) {
+	dir := t.TempDir()
+
+	bus, _ := openDiskBus(t, dir)
+	subscribeOrders(t, bus)
+	require.NoError(t, bus.Close(context.Background()))
+
+	file, err := os.OpenFile(filepath.Join(dir, "events.log"), os.O_APPEND|os.O_WRONLY, 0o644)
+	require.NoError(t, err)
+	_, err = file.WriteString("{not json\n")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	deadLetters := events.NewMemoryDeadLetterStore()
+	bus, _ = openDiskBus(t, dir, events.WithDeadLetterStore(deadLetters))
+	defer bus.Close(context.Background())
+	received := subscribeOrders(t, bus)
+	publishOrder(t, bus, map[string]interface{}{"order_id": "order-1"})
+
+	assert.Equal(t, "order-1", receive(t, received), "the consumer moves past the corrupt record")
+
+	stored, err := deadLetters.List(context.Background(), events.DeadLetterFilter{})
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.Equal(t, "orders", stored[0].Subscription)
+	assert.Equal(t, `"{not json"`, string(stored[0].Payload))
+	assert.Contains(t, stored[0].LastError, "corrupted disk queue record at offset 0")
+}
+
+func TestDiskQueueSendsRejectedPayloadToDeadLetters(t *testing.T) {
+	dir := t.TempDir()
+
+	// Gravado por uma versão sem registry, com order_id de tipo errado
+	bus, _ := openDiskBus(t, dir)
+	subscribeOrders(t, bus)
+	publishOrder(t, bus, map[string]interface{}{"order_id": 42})
+	require.NoError(t, bus.Close(context.Background()))
+
+	deadLetters := events.NewMemoryDeadLetterStore()
+	bus, _ = openDiskBus(t, dir, events.WithDeadLetterStore(deadLetters), events.WithRegistry(events.NewDefaultRegistry()))
+	defer bus.Close(context.Background())
+
+	received := make(chan string, 10)
+	_, err := bus.SubscribeWithOptions("order.*", func(ctx context.Context, event contracts.Event) error {
+		received <- event.Payload.(contracts.OrderCreatedEvent).OrderID
+		return nil
+	}, events.WithSubscriptionName("orders"))
+	require.NoError(t, err)
+	publishOrder(t, bus, contracts.OrderCreatedEvent{OrderID: "order-1", UserID: "user-1", Total: 10})
+
+	assert.Equal(t, "order-1", receive(t, received))
+
+	stored, err := deadLetters.List(context.Background(), events.DeadLetterFilter{})
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.Equal(t, "order.created", stored[0].EventType)
+	assert.Equal(t, 0, stored[0].Attempts, "the handler never ran")
+	assert.Contains(t, stored[0].LastError, "failed to decode event at offset 0")
+	assert.Contains(t, stored[0].LastError, events.ErrInvalidPayload.Error())
+}
+
+// Um commit que falha é repetido; a inscrição continua viva
+func TestDiskQueueRetriesFailedCommits(t *testing.T) {
+	dir := t.TempDir()
+	bus, queue := openDiskBus(t, dir)
+	defer bus.Close(context.Background())
+	received := subscribeOrders(t, bus)
+
+	offsets := filepath.Join(dir, "offsets")
+	require.NoError(t, os.RemoveAll(offsets))
+	publishOrder(t, bus, map[string]interface{}{"order_id": "order-1"})
+	assert.Equal(t, "order-1", receive(t, received))
+
+	time.Sleep(150 * time.Millisecond) // Ao menos uma tentativa de commit falha
+	require.NoError(t, os.MkdirAll(offsets, 0o755))
+
+	publishOrder(t, bus, map[string]interface{}{"order_id": "order-2"})
+	assert.Equal(t, "order-2", receive(t, received))
+
+	assert.Eventually(t, func() bool {
+		offset, committed, err := queue.CommittedOffset("orders")
+		if err != nil || !committed {
+			return false
+		}
+		info, err := os.Stat(filepath.Join(dir, "events.log"))
+		return err == nil && offset == info.Size()
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,100 @@
+package events
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go-modular-monolith/pkg/contracts"
+
+	"github.com/google/uuid"
+)
+
+type correlationIDKey struct{}
+type causingEventKey struct{}
+
+// WithCorrelationID associa um correlation ID ao contexto (normalmente o ID da requisição)
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, correlationID)
+}
+
+// CorrelationIDFromContext retorna o correlation ID do contexto, se houver
+func CorrelationIDFromContext(ctx context.Context) string {
+	if correlationID, ok := ctx.Value(correlationIDKey{}).(string); ok {
+		return correlationID
+	}
+	if event, ok := ctx.Value(causingEventKey{}).(contracts.Event); ok {
+		return event.CorrelationID
+	}
+	return ""
+}
+
+// contextWithEvent marca o contexto do handler com o evento em processamento, de
+// forma que eventos publicados pelo handler herdem correlação e causalidade
+func contextWithEvent(ctx context.Context, event contracts.Event) context.Context {
+	return context.WithValue(ctx, causingEventKey{}, event)
+}
+
+// Enrich preenche os metadados vazios do envelope: ID, timestamp, origem,
+// correlation ID (da requisição ou do evento causador) e causation ID
+func Enrich(ctx context.Context, event contracts.Event) contracts.Event {
+	if event.ID == "" {
+		event.ID = uuid.New().String()
+	}
+
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	if event.Source == "" {
+		event.Source = strings.SplitN(event.Type, ".", 2)[0]
+	}
+
+	if causing, ok := ctx.Value(causingEventKey{}).(contracts.Event); ok && event.CausationID == "" && causing.ID != event.ID {
+		event.CausationID = causing.ID
+	}
+
+	if event.CorrelationID == "" {
+		event.CorrelationID = CorrelationIDFromContext(ctx)
+	}
+	if event.CorrelationID == "" {
+		// Eventos sem origem conhecida iniciam uma nova correlação
+		event.CorrelationID = event.ID
+	}
+
+	return event
+}
+
+// Metadata são os campos do envelope persistidos junto ao payload
+type Metadata struct {
+	EventID       string `json:"event_id"`
+	Version       int    `json:"version"`
+	Source        string `json:"source"`
+	CorrelationID string `json:"correlation_id,omitempty"`
+	CausationID   string `json:"causation_id,omitempty"`
+}
+
+// MetadataOf extrai os metadados do envelope do evento
+func MetadataOf(event contracts.Event) Metadata {
+	return Metadata{
+		EventID:       event.ID,
+		Version:       event.Version,
+		Source:        event.Source,
+		CorrelationID: event.CorrelationID,
+		CausationID:   event.CausationID,
+	}
+}
+
+// Event reconstrói o envelope a partir dos metadados, tipo, payload e timestamp
+func (m Metadata) Event(eventType string, payload interface{}, timestamp time.Time) contracts.Event {
+	return contracts.Event{
+		ID:            m.EventID,
+		Type:          eventType,
+		Version:       m.Version,
+		Source:        m.Source,
+		CorrelationID: m.CorrelationID,
+		CausationID:   m.CausationID,
+		Payload:       payload,
+		Timestamp:     timestamp,
+	}
+}
@@ -2,64 +2,399 @@ package events
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"go-modular-monolith/pkg/contracts"
 	"sync"
+	"time"
+
+	"github.com/google/uuid"
 )
 
+// ErrSubscriptionNotFound é retornado quando não existe inscrição com o nome informado
+var ErrSubscriptionNotFound = errors.New("subscription not found")
+
+// ErrSubscriptionNameRequired é retornado ao inscrever sem WithSubscriptionName
+// em um bus com dead letter store ou fila em disco. Dead letters, redrive e
+// offsets usam o nome como identidade, e o nome gerado ("<padrão>#N") depende
+// da ordem de registro, que muda entre deploys.
+var ErrSubscriptionNameRequired = errors.New("subscription name is required")
+
 // EventBus implementa um sistema de eventos em memória
 type EventBus struct {
-	handlers map[string][]contracts.EventHandler
+	handlers []*subscription // Na ordem de registro, que é a ordem de entrega
+	counters map[string]int
 	mu       sync.RWMutex
+	async    *asyncDispatcher
+	durable  *diskDispatcher
+
+	defaultRetry RetryPolicy
+	deadLetters  DeadLetterStore
+	eventLog     EventLog
+	registry     *Registry
+
+	publishInterceptors []Interceptor
+	handlerInterceptors []Interceptor
 }
 
+// Option configura o EventBus na criação
+type Option func(*EventBus)
+
 // NewEventBus cria uma nova instância do event bus
-func NewEventBus() *EventBus {
-	return &EventBus{
-		handlers: make(map[string][]contracts.EventHandler),
+func NewEventBus(opts ...Option) *EventBus {
+	bus := &EventBus{
+		counters:     make(map[string]int),
+		defaultRetry: NoRetry(),
+	}
+
+	for _, opt := range opts {
+		opt(bus)
+	}
+
+	return bus
+}
+
+// WithDefaultRetryPolicy define a política usada pelas inscrições que não informam a sua
+func WithDefaultRetryPolicy(policy RetryPolicy) Option {
+	return func(e *EventBus) {
+		e.defaultRetry = policy
+	}
+}
+
+// WithDeadLetterStore define onde são gravados os eventos que esgotaram as tentativas
+func WithDeadLetterStore(store DeadLetterStore) Option {
+	return func(e *EventBus) {
+		e.deadLetters = store
 	}
 }
 
-// Publish publica um evento para todos os handlers registrados
+// WithRegistry faz o bus validar e tipar os payloads no Publish; tipos
+// desconhecidos ou payloads incompatíveis são rejeitados
+func WithRegistry(registry *Registry) Option {
+	return func(e *EventBus) {
+		e.registry = registry
+	}
+}
+
+// Publish publica um evento para todos os handlers registrados,
+// passando pela cadeia de interceptors de publicação
 func (e *EventBus) Publish(ctx context.Context, event contracts.Event) error {
-	e.mu.RLock()
-	handlers, exists := e.handlers[event.Type]
-	e.mu.RUnlock()
+	publish := chain(e.publishInterceptors, InterceptorInfo{Operation: OperationPublish}, func(ctx context.Context, event contracts.Event) error {
+		return e.publish(ctx, event, e.async != nil)
+	})
+	return publish(ctx, Enrich(ctx, event))
+}
 
-	if !exists {
-		return nil // Não há handlers registrados para este tipo de evento
+// PublishSync é como Publish, mas mesmo no modo assíncrono só retorna depois
+// que os handlers processaram o evento (ou o enviaram para a dead letter
+// store). Com a fila em disco retorna após a gravação durável. É o que permite
+// ao relay do outbox marcar um evento como enviado sem perdê-lo.
+func (e *EventBus) PublishSync(ctx context.Context, event contracts.Event) error {
+	publish := chain(e.publishInterceptors, InterceptorInfo{Operation: OperationPublish}, func(ctx context.Context, event contracts.Event) error {
+		return e.publish(ctx, event, false)
+	})
+	return publish(ctx, Enrich(ctx, event))
+}
+
+func (e *EventBus) publish(ctx context.Context, event contracts.Event, queued bool) error {
+	event, err := e.decode(event)
+	if err != nil {
+		return err
 	}
 
-	// Executa todos os handlers de forma síncrona
-	// Em uma implementação real, você pode querer fazer isso de forma assíncrona
-	for _, handler := range handlers {
-		if err := handler(ctx, event); err != nil {
-			// Log do erro mas continue executando outros handlers
-			fmt.Printf("Error handling event %s: %v\n", event.Type, err)
+	// Todo evento publicado é gravado no log, mesmo sem handlers registrados
+	if e.eventLog != nil {
+		if _, err := e.eventLog.Append(ctx, event); err != nil {
+			return fmt.Errorf("failed to append event to log: %w", err)
 		}
 	}
 
+	// Na fila em disco o evento é gravado mesmo sem inscrições; cada consumer
+	// group o lê a partir do seu offset
+	if e.durable != nil {
+		return e.durable.queue.Append(event)
+	}
+
+	if len(e.matching(event.Type)) == 0 {
+		return nil // Não há handlers registrados para este tipo de evento
+	}
+
+	// No modo assíncrono o evento é enfileirado e entregue pelo worker pool
+	if queued {
+		return e.async.enqueue(ctx, event)
+	}
+
+	e.dispatch(ctx, event)
 	return nil
 }
 
-// Subscribe registra um handler para um tipo específico de evento
-func (e *EventBus) Subscribe(eventType string, handler contracts.EventHandler) error {
+// decode converte o payload para a struct registrada, quando há registry
+func (e *EventBus) decode(event contracts.Event) (contracts.Event, error) {
+	if e.registry == nil {
+		return event, nil
+	}
+	return e.registry.Decode(event)
+}
+
+// dispatch executa todos os handlers do evento de forma síncrona
+func (e *EventBus) dispatch(ctx context.Context, event contracts.Event) {
+	for _, sub := range e.matching(event.Type) {
+		// Inscrições canceladas durante a entrega não recebem mais eventos
+		if !sub.active.Load() {
+			continue
+		}
+		// Falhas de um handler não impedem a entrega aos demais
+		e.deliver(ctx, sub, event)
+	}
+}
+
+// matching retorna uma cópia das inscrições cujo tipo ou padrão casa com o
+// evento, na ordem de registro. A cópia permite iterar sem o lock enquanto
+// outras inscrições são canceladas.
+func (e *EventBus) matching(eventType string) []*subscription {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	var subscriptions []*subscription
+	for _, sub := range e.handlers {
+		if sub.matches(eventType) {
+			subscriptions = append(subscriptions, sub)
+		}
+	}
+	return subscriptions
+}
+
+// deliver executa o handler respeitando a política de retry e envia para a
+// dead letter store quando as tentativas se esgotam. Retorna se houve sucesso.
+func (e *EventBus) deliver(ctx context.Context, sub *subscription, event contracts.Event) bool {
+	attempts, err := e.invoke(ctx, sub, event)
+	if err == nil {
+		return true
+	}
+
+	e.deadLetter(ctx, sub, event, attempts, err)
+	return false
+}
+
+// deadLetter grava na dead letter store o evento que a inscrição não processou
+// após attempts tentativas; sem store o erro é apenas registrado
+func (e *EventBus) deadLetter(ctx context.Context, sub *subscription, event contracts.Event, attempts int, err error) {
+	if e.deadLetters == nil {
+		fmt.Printf("Error handling event %s in %s after %d attempts: %v\n", event.Type, sub.name, attempts, err)
+		return
+	}
+
+	deadLetter, buildErr := newDeadLetter(sub, event, attempts, err)
+	if buildErr == nil {
+		buildErr = e.deadLetters.Save(context.WithoutCancel(ctx), deadLetter)
+	}
+	if buildErr != nil {
+		fmt.Printf("Error storing dead letter for event %s in %s: %v (handler error: %v)\n", event.Type, sub.name, buildErr, err)
+	}
+}
+
+// invoke executa o handler até obter sucesso ou esgotar as tentativas.
+// Cada tentativa passa pela cadeia de interceptors de handler.
+func (e *EventBus) invoke(ctx context.Context, sub *subscription, event contracts.Event) (int, error) {
+	maxAttempts := sub.retry.attempts()
+	ctx = contextWithEvent(ctx, event)
+
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		info := InterceptorInfo{Operation: OperationHandle, Subscription: sub.name, Attempt: attempt}
+		if err = chain(e.handlerInterceptors, info, sub.handler)(ctx, event); err == nil {
+			return attempt, nil
+		}
+
+		if attempt == maxAttempts {
+			return attempt, err
+		}
+
+		// Uma tentativa que estourou o prazo pode ainda estar rodando: a
+		// seguinte só começa quando ela retornar, nunca em paralelo
+		var timeoutErr *TimeoutError
+		if errors.As(err, &timeoutErr) {
+			select {
+			case <-timeoutErr.Done():
+			case <-ctx.Done():
+				return attempt, fmt.Errorf("%w (retry interrupted: %v)", err, ctx.Err())
+			}
+		}
+
+		if sleepErr := sleep(ctx, sub.retry.Backoff(attempt)); sleepErr != nil {
+			return attempt, fmt.Errorf("%w (retry interrupted: %v)", err, sleepErr)
+		}
+	}
+
+	return maxAttempts, err
+}
+
+func newDeadLetter(sub *subscription, event contracts.Event, attempts int, cause error) (*DeadLetter, error) {
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event payload: %w", err)
+	}
+
+	return &DeadLetter{
+		Metadata:       MetadataOf(event),
+		ID:             uuid.New().String(),
+		EventType:      event.Type,
+		Payload:        payload,
+		EventTimestamp: event.Timestamp,
+		Subscription:   sub.name,
+		Attempts:       attempts,
+		LastError:      cause.Error(),
+		FailedAt:       time.Now(),
+	}, nil
+}
+
+// Subscribe registra um handler para um tipo de evento ou padrão ("order.*", "*.created", "*")
+// com nome gerado; só é aceito em buses sem dead letter store nem fila em disco
+func (e *EventBus) Subscribe(pattern string, handler contracts.EventHandler) (contracts.Subscription, error) {
+	return e.SubscribeWithOptions(pattern, handler)
+}
+
+// SubscribeWithOptions registra um handler com nome e política de retry próprios.
+// Com dead letter store ou fila em disco o nome é obrigatório.
+func (e *EventBus) SubscribeWithOptions(pattern string, handler contracts.EventHandler, opts ...SubscriptionOption) (contracts.Subscription, error) {
+	if err := validatePattern(pattern); err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	sub := &subscription{
+		bus:       e,
+		eventType: pattern,
+		handler:   handler,
+		retry:     e.defaultRetry,
+	}
+	for _, opt := range opts {
+		opt(sub)
+	}
+
+	if sub.name == "" && (e.durable != nil || e.deadLetters != nil) {
+		return nil, fmt.Errorf("%w for '%s' when dead letters or the disk queue are enabled", ErrSubscriptionNameRequired, pattern)
+	}
+	if sub.name == "" {
+		// Contador monotônico para não reaproveitar nomes de inscrições canceladas
+		e.counters[pattern]++
+		sub.name = fmt.Sprintf("%s#%d", pattern, e.counters[pattern])
+	}
+	if e.findSubscription(sub.name) != nil {
+		return nil, fmt.Errorf("subscription '%s' already exists", sub.name)
+	}
+
+	sub.active.Store(true)
+	if e.durable != nil {
+		if err := e.durable.start(sub); err != nil {
+			return nil, fmt.Errorf("failed to start consumer for '%s': %w", sub.name, err)
+		}
+	}
+
+	e.handlers = append(e.handlers, sub)
+	return sub, nil
+}
+
+// unsubscribe remove a inscrição do bus sem alterar as cópias em uso pelo dispatch
+func (e *EventBus) unsubscribe(sub *subscription) {
+	if e.durable != nil {
+		e.durable.stop(sub)
+	}
+
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
-	e.handlers[eventType] = append(e.handlers[eventType], handler)
+	// Um novo slice, para não alterar o array compartilhado com as cópias
+	remaining := make([]*subscription, 0, len(e.handlers))
+	for _, existing := range e.handlers {
+		if existing != sub {
+			remaining = append(remaining, existing)
+		}
+	}
+	e.handlers = remaining
+}
+
+// findSubscription busca a inscrição pelo nome; o chamador deve segurar o lock
+func (e *EventBus) findSubscription(name string) *subscription {
+	for _, sub := range e.handlers {
+		if sub.name == name {
+			return sub
+		}
+	}
 	return nil
 }
 
+// Redrive reentrega uma dead letter à inscrição que falhou.
+// Em caso de sucesso a dead letter é removida; caso contrário ela é atualizada.
+func (e *EventBus) Redrive(ctx context.Context, id string) error {
+	if e.deadLetters == nil {
+		return errors.New("dead letter store is not configured")
+	}
+
+	deadLetter, err := e.deadLetters.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	e.mu.RLock()
+	sub := e.findSubscription(deadLetter.Subscription)
+	e.mu.RUnlock()
+
+	if sub == nil {
+		return fmt.Errorf("%w: %s", ErrSubscriptionNotFound, deadLetter.Subscription)
+	}
+
+	event, err := e.decode(deadLetter.Metadata.Event(deadLetter.EventType, deadLetter.Payload, deadLetter.EventTimestamp))
+	if err != nil {
+		return err
+	}
+
+	attempts, handlerErr := e.invoke(ctx, sub, event)
+	if handlerErr != nil {
+		deadLetter.Attempts += attempts
+		deadLetter.LastError = handlerErr.Error()
+		deadLetter.FailedAt = time.Now()
+		if err := e.deadLetters.Save(ctx, deadLetter); err != nil {
+			return fmt.Errorf("failed to update dead letter: %w", err)
+		}
+		return fmt.Errorf("redrive failed: %w", handlerErr)
+	}
+
+	return e.deadLetters.Delete(ctx, id)
+}
+
+// Close encerra o bus drenando os eventos enfileirados.
+// No modo síncrono não há nada a drenar.
+func (e *EventBus) Close(ctx context.Context) error {
+	if e.durable != nil {
+		return e.durable.close(ctx)
+	}
+	if e.async == nil {
+		return nil
+	}
+	return e.async.close(ctx)
+}
+
 // Constants para tipos de eventos
 const (
 	UserCreatedEventType         = "user.created"
 	UserUpdatedEventType         = "user.updated"
 	UserDeletedEventType         = "user.deleted"
+	UserRestoredEventType        = "user.restored"
+	UserPurgedEventType          = "user.purged"
 	ProductCreatedEventType      = "product.created"
 	ProductUpdatedEventType      = "product.updated"
 	ProductStockUpdatedEventType = "product.stock.updated"
+	ProductDeletedEventType      = "product.deleted"
+	ProductRestoredEventType     = "product.restored"
+	ProductPurgedEventType       = "product.purged"
 	OrderCreatedEventType        = "order.created"
 	OrderStatusUpdatedEventType  = "order.status.updated"
 	OrderCancelledEventType      = "order.cancelled"
+	OrderDeletedEventType        = "order.deleted"
+	OrderRestoredEventType       = "order.restored"
+	OrderPurgedEventType         = "order.purged"
 )
@@ -0,0 +1,214 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go-modular-monolith/pkg/contracts"
+)
+
+// ErrNoReplayTargets é retornado por um replay sem inscrições: reentregar a
+// todas repetiria efeitos colaterais (emails, webhooks, estoque) de handlers
+// que não foram escritos para isso
+var ErrNoReplayTargets = errors.New("replay requires at least one subscription")
+
+// StoredEvent representa um evento gravado no log append-only
+type StoredEvent struct {
+	Metadata
+	Sequence   uint64          `json:"sequence"`
+	Type       string          `json:"type"`
+	Payload    json.RawMessage `json:"payload"`
+	Timestamp  time.Time       `json:"timestamp"`
+	RecordedAt time.Time       `json:"recorded_at"`
+}
+
+// EventLogQuery filtra a leitura do log. Eventos são retornados em ordem de sequência.
+type EventLogQuery struct {
+	Types         []string   // Vazio retorna todos os tipos
+	AfterSequence uint64     // Retorna apenas eventos com sequência maior que esta
+	Since         *time.Time // Retorna apenas eventos ocorridos a partir deste instante
+	Limit         int
+}
+
+// EventLog é o log durável e append-only de todos os eventos publicados
+type EventLog interface {
+	Append(ctx context.Context, event contracts.Event) (*StoredEvent, error)
+	Read(ctx context.Context, query EventLogQuery) ([]*StoredEvent, error)
+}
+
+// WithEventLog faz o bus gravar todo evento publicado no log antes de entregá-lo
+func WithEventLog(log EventLog) Option {
+	return func(e *EventBus) {
+		e.eventLog = log
+	}
+}
+
+// ReplayRequest define quais eventos do log são reentregues e para quais inscrições
+type ReplayRequest struct {
+	Types         []string   `json:"types"`
+	AfterSequence uint64     `json:"after_sequence"`
+	Since         *time.Time `json:"since,omitempty"`
+	Subscriptions []string   `json:"subscriptions"` // Obrigatório: inscrições que recebem os eventos
+	BatchSize     int        `json:"batch_size"`
+}
+
+// ReplayResult resume a execução de um replay
+type ReplayResult struct {
+	Events       int    `json:"events"`
+	Deliveries   int    `json:"deliveries"`
+	Failures     int    `json:"failures"`
+	LastSequence uint64 `json:"last_sequence"`
+}
+
+// Replay lê eventos do log e os entrega novamente às inscrições escolhidas.
+// Útil para reconstruir read models ou popular projeções de um módulo novo.
+// Falhas seguem a política de retry da inscrição e terminam na dead letter store.
+func (e *EventBus) Replay(ctx context.Context, req ReplayRequest) (*ReplayResult, error) {
+	if e.eventLog == nil {
+		return nil, fmt.Errorf("event log is not configured")
+	}
+
+	targets, err := e.replayTargets(req.Subscriptions)
+	if err != nil {
+		return nil, err
+	}
+
+	batchSize := req.BatchSize
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+
+	result := &ReplayResult{LastSequence: req.AfterSequence}
+	for {
+		stored, err := e.eventLog.Read(ctx, EventLogQuery{
+			Types:         req.Types,
+			AfterSequence: result.LastSequence,
+			Since:         req.Since,
+			Limit:         batchSize,
+		})
+		if err != nil {
+			return result, fmt.Errorf("failed to read event log: %w", err)
+		}
+
+		for _, storedEvent := range stored {
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+
+			event, err := e.decode(storedEvent.Metadata.Event(storedEvent.Type, storedEvent.Payload, storedEvent.Timestamp))
+			if err != nil {
+				return result, fmt.Errorf("failed to decode event %d: %w", storedEvent.Sequence, err)
+			}
+
+			for _, sub := range targets {
+				if !sub.matches(event.Type) {
+					continue
+				}
+				result.Deliveries++
+				if !e.deliver(ctx, sub, event) {
+					result.Failures++
+				}
+			}
+
+			result.Events++
+			result.LastSequence = storedEvent.Sequence
+		}
+
+		if len(stored) < batchSize {
+			return result, nil
+		}
+	}
+}
+
+// replayTargets resolve as inscrições pelo nome; ao menos uma é obrigatória
+func (e *EventBus) replayTargets(names []string) ([]*subscription, error) {
+	if len(names) == 0 {
+		return nil, ErrNoReplayTargets
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	var targets []*subscription
+	for _, name := range names {
+		sub := e.findSubscription(name)
+		if sub == nil {
+			return nil, fmt.Errorf("%w: %s", ErrSubscriptionNotFound, name)
+		}
+		targets = append(targets, sub)
+	}
+	return targets, nil
+}
+
+// MemoryEventLog implementa EventLog em memória
+type MemoryEventLog struct {
+	events []*StoredEvent
+	mu     sync.RWMutex
+}
+
+// NewMemoryEventLog cria um log de eventos em memória
+func NewMemoryEventLog() *MemoryEventLog {
+	return &MemoryEventLog{}
+}
+
+func (l *MemoryEventLog) Append(ctx context.Context, event contracts.Event) (*StoredEvent, error) {
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event payload: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	stored := &StoredEvent{
+		Metadata:   MetadataOf(event),
+		Sequence:   uint64(len(l.events) + 1),
+		Type:       event.Type,
+		Payload:    payload,
+		Timestamp:  event.Timestamp,
+		RecordedAt: time.Now(),
+	}
+	l.events = append(l.events, stored)
+
+	found := *stored
+	return &found, nil
+}
+
+func (l *MemoryEventLog) Read(ctx context.Context, query EventLogQuery) ([]*StoredEvent, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	var result []*StoredEvent
+	for _, stored := range l.events {
+		if stored.Sequence <= query.AfterSequence {
+			continue
+		}
+		if query.Since != nil && stored.Timestamp.Before(*query.Since) {
+			continue
+		}
+		if len(query.Types) > 0 && !containsString(query.Types, stored.Type) {
+			continue
+		}
+
+		found := *stored
+		result = append(result, &found)
+		if query.Limit > 0 && len(result) == query.Limit {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package events_test
+
+import (
+	"context"
+	"testing"
+
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/events"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayDeliversOnlyToNamedSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	bus := events.NewEventBus(events.WithEventLog(events.NewMemoryEventLog()))
+
+	var projection, email []string
+	_, err := bus.SubscribeWithOptions("order.*", func(ctx context.Context, event contracts.Event) error {
+		projection = append(projection, event.Type)
+		return nil
+	}, events.WithSubscriptionName("reporting.projection"))
+	require.NoError(t, err)
+	_, err = bus.SubscribeWithOptions("order.created", func(ctx context.Context, event contracts.Event) error {
+		email = append(email, event.Type)
+		return nil
+	}, events.WithSubscriptionName("notification.email"))
+	require.NoError(t, err)
+
+	require.NoError(t, bus.Publish(ctx, contracts.Event{Type: "order.created"}))
+	require.NoError(t, bus.Publish(ctx, contracts.Event{Type: "order.cancelled"}))
+	projection, email = nil, nil
+
+	result, err := bus.Replay(ctx, events.ReplayRequest{Subscriptions: []string{"reporting.projection"}})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Events)
+	assert.Equal(t, 2, result.Deliveries)
+	assert.Equal(t, uint64(2), result.LastSequence)
+	assert.Equal(t, []string{"order.created", "order.cancelled"}, projection)
+	assert.Empty(t, email, "other subscriptions are not replayed")
+}
+
+func TestReplayRequiresSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	bus := events.NewEventBus(events.WithEventLog(events.NewMemoryEventLog()))
+
+	delivered := 0
+	_, err := bus.Subscribe("order.created", func(ctx context.Context, event contracts.Event) error {
+		delivered++
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, bus.Publish(ctx, contracts.Event{Type: "order.created"}))
+
+	_, err = bus.Replay(ctx, events.ReplayRequest{Types: []string{"order.created"}})
+	assert.ErrorIs(t, err, events.ErrNoReplayTargets)
+
+	_, err = bus.Replay(ctx, events.ReplayRequest{Subscriptions: []string{"unknown"}})
+	assert.ErrorIs(t, err, events.ErrSubscriptionNotFound)
+
+	assert.Equal(t, 1, delivered, "nothing was replayed")
+}
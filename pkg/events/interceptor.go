@@ -0,0 +1,244 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"go-modular-monolith/pkg/contracts"
+)
+
+// Operações interceptadas pelo pipeline
+const (
+	OperationPublish = "publish"
+	OperationHandle  = "handle"
+)
+
+// InterceptorInfo descreve a operação interceptada
+type InterceptorInfo struct {
+	Operation    string // OperationPublish ou OperationHandle
+	Subscription string // Nome da inscrição; vazio no publish
+	Attempt      int    // Tentativa atual do handler (a partir de 1); zero no publish
+}
+
+// Interceptor envolve o Publish ou a execução de um handler. Deve chamar next
+// para continuar a cadeia e pode alterar o contexto, o evento ou o erro.
+type Interceptor func(ctx context.Context, event contracts.Event, info InterceptorInfo, next contracts.EventHandler) error
+
+// WithPublishInterceptors adiciona interceptors em volta do Publish.
+// O primeiro interceptor informado é o mais externo.
+func WithPublishInterceptors(interceptors ...Interceptor) Option {
+	return func(e *EventBus) {
+		e.publishInterceptors = append(e.publishInterceptors, interceptors...)
+	}
+}
+
+// WithHandlerInterceptors adiciona interceptors em volta de cada execução de handler.
+// Cada tentativa da política de retry passa pela cadeia inteira.
+func WithHandlerInterceptors(interceptors ...Interceptor) Option {
+	return func(e *EventBus) {
+		e.handlerInterceptors = append(e.handlerInterceptors, interceptors...)
+	}
+}
+
+// chain monta a cadeia de interceptors terminando em final
+func chain(interceptors []Interceptor, info InterceptorInfo, final contracts.EventHandler) contracts.EventHandler {
+	next := final
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, inner := interceptors[i], next
+		next = func(ctx context.Context, event contracts.Event) error {
+			return interceptor(ctx, event, info, inner)
+		}
+	}
+	return next
+}
+
+// PanicError é o erro produzido pelo Recover a partir de um panic
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Recover converte panics em erros, que seguem a política de retry e a dead letter store
+func Recover() Interceptor {
+	return func(ctx context.Context, event contracts.Event, info InterceptorInfo, next contracts.EventHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = &PanicError{Value: r, Stack: debug.Stack()}
+			}
+		}()
+		return next(ctx, event)
+	}
+}
+
+// Logging registra cada operação com o tipo, IDs do evento e duração
+func Logging(logger contracts.Logger) Interceptor {
+	return func(ctx context.Context, event contracts.Event, info InterceptorInfo, next contracts.EventHandler) error {
+		start := time.Now()
+		err := next(ctx, event)
+
+		fields := []contracts.Field{
+			{Key: "operation", Value: info.Operation},
+			{Key: "event_type", Value: event.Type},
+			{Key: "event_id", Value: event.ID},
+			{Key: "correlation_id", Value: event.CorrelationID},
+			{Key: "duration", Value: time.Since(start)},
+		}
+		if info.Operation == OperationHandle {
+			fields = append(fields,
+				contracts.Field{Key: "subscription", Value: info.Subscription},
+				contracts.Field{Key: "attempt", Value: info.Attempt},
+			)
+		}
+
+		if err != nil {
+			logger.Error("Event "+info.Operation+" failed", append(fields, contracts.Field{Key: "error", Value: err.Error()})...)
+		} else {
+			logger.Debug("Event "+info.Operation+" succeeded", fields...)
+		}
+		return err
+	}
+}
+
+// TimeoutError é o erro do Timeout quando o prazo expira antes do handler
+// retornar. O handler segue executando até observar o cancelamento do
+// contexto; Done é fechado quando ele retorna.
+type TimeoutError struct {
+	Operation string
+	Timeout   time.Duration
+	done      <-chan struct{}
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("event %s exceeded deadline of %s: %v", e.Operation, e.Timeout, context.DeadlineExceeded)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// Done é fechado quando a execução que estourou o prazo retorna
+func (e *TimeoutError) Done() <-chan struct{} {
+	return e.done
+}
+
+// Timeout aplica um prazo à operação. Se o prazo expira antes do handler
+// retornar, a operação falha com um *TimeoutError (errors.Is com
+// context.DeadlineExceeded) mesmo que o handler ignore o contexto. Go não
+// interrompe goroutines: o handler precisa respeitar ctx para parar de fato,
+// e a política de retry só inicia a tentativa seguinte depois que ele retorna.
+func Timeout(timeout time.Duration) Interceptor {
+	return func(ctx context.Context, event contracts.Event, info InterceptorInfo, next contracts.EventHandler) error {
+		if timeout <= 0 {
+			return next(ctx, event)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		type result struct {
+			err   error
+			panic interface{}
+		}
+		done := make(chan result, 1)
+		returned := make(chan struct{})
+		go func() {
+			defer close(returned)
+			defer func() {
+				if r := recover(); r != nil {
+					done <- result{panic: r}
+				}
+			}()
+			done <- result{err: next(ctx, event)}
+		}()
+
+		select {
+		case res := <-done:
+			if res.panic != nil {
+				// Repropaga na goroutine do chamador para o Recover externo
+				panic(res.panic)
+			}
+			return res.err
+		case <-ctx.Done():
+			return &TimeoutError{Operation: info.Operation, Timeout: timeout, done: returned}
+		}
+	}
+}
+
+// LatencyStats agrega as medições de uma operação
+type LatencyStats struct {
+	Count   uint64        `json:"count"`
+	Errors  uint64        `json:"errors"`
+	Total   time.Duration `json:"total_ns"`
+	Max     time.Duration `json:"max_ns"`
+	Average time.Duration `json:"average_ns"`
+}
+
+// LatencyMetrics coleta latência e erros por operação, tipo de evento e inscrição
+type LatencyMetrics struct {
+	stats map[string]*LatencyStats
+	mu    sync.Mutex
+}
+
+// NewLatencyMetrics cria um coletor de latência em memória
+func NewLatencyMetrics() *LatencyMetrics {
+	return &LatencyMetrics{
+		stats: make(map[string]*LatencyStats),
+	}
+}
+
+// Interceptor retorna o interceptor que alimenta o coletor
+func (m *LatencyMetrics) Interceptor() Interceptor {
+	return func(ctx context.Context, event contracts.Event, info InterceptorInfo, next contracts.EventHandler) error {
+		start := time.Now()
+		err := next(ctx, event)
+		m.observe(metricKey(event.Type, info), time.Since(start), err)
+		return err
+	}
+}
+
+func (m *LatencyMetrics) observe(key string, elapsed time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats, exists := m.stats[key]
+	if !exists {
+		stats = &LatencyStats{}
+		m.stats[key] = stats
+	}
+
+	stats.Count++
+	if err != nil {
+		stats.Errors++
+	}
+	stats.Total += elapsed
+	if elapsed > stats.Max {
+		stats.Max = elapsed
+	}
+	stats.Average = stats.Total / time.Duration(stats.Count)
+}
+
+// Snapshot retorna uma cópia das medições, indexadas por "operação:tipo[:inscrição]"
+func (m *LatencyMetrics) Snapshot() map[string]LatencyStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[string]LatencyStats, len(m.stats))
+	for key, stats := range m.stats {
+		snapshot[key] = *stats
+	}
+	return snapshot
+}
+
+func metricKey(eventType string, info InterceptorInfo) string {
+	if info.Subscription == "" {
+		return info.Operation + ":" + eventType
+	}
+	return info.Operation + ":" + eventType + ":" + info.Subscription
+}
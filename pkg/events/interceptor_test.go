@@ -0,0 +1,184 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/events"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingLogger guarda as mensagens registradas, por nível
+type recordingLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+type logEntry struct {
+	level   string
+	message string
+	fields  map[string]interface{}
+}
+
+func (l *recordingLogger) record(level, message string, fields []contracts.Field) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entry := logEntry{level: level, message: message, fields: make(map[string]interface{})}
+	for _, field := range fields {
+		entry.fields[field.Key] = field.Value
+	}
+	l.entries = append(l.entries, entry)
+}
+
+func (l *recordingLogger) Debug(msg string, fields ...contracts.Field) {
+	l.record("debug", msg, fields)
+}
+
+func (l *recordingLogger) Info(msg string, fields ...contracts.Field) {
+	l.record("info", msg, fields)
+}
+
+func (l *recordingLogger) Warn(msg string, fields ...contracts.Field) {
+	l.record("warn", msg, fields)
+}
+
+func (l *recordingLogger) Error(msg string, fields ...contracts.Field) {
+	l.record("error", msg, fields)
+}
+
+func (l *recordingLogger) Fatal(msg string, fields ...contracts.Field) {
+	l.record("fatal", msg, fields)
+}
+
+func (l *recordingLogger) With(...contracts.Field) contracts.Logger {
+	return l
+}
+
+var handleInfo = events.InterceptorInfo{Operation: events.OperationHandle, Subscription: "orders", Attempt: 1}
+
+func TestRecoverConvertsPanicsToErrors(t *testing.T) {
+	err := events.Recover()(context.Background(), contracts.Event{Type: "order.created"}, handleInfo, func(ctx context.Context, event contracts.Event) error {
+		panic("boom")
+	})
+
+	var panicErr *events.PanicError
+	require.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, "boom", panicErr.Value)
+	assert.NotEmpty(t, panicErr.Stack)
+
+	// No bus o panic segue para a dead letter store como qualquer erro
+	deadLetters := events.NewMemoryDeadLetterStore()
+	bus := events.NewEventBus(events.WithDeadLetterStore(deadLetters), events.WithHandlerInterceptors(events.Recover()))
+	_, err = bus.SubscribeWithOptions("order.created", func(ctx context.Context, event contracts.Event) error {
+		panic("boom")
+	}, events.WithSubscriptionName("orders"))
+	require.NoError(t, err)
+
+	require.NoError(t, bus.Publish(context.Background(), contracts.Event{Type: "order.created"}))
+	stored, err := deadLetters.List(context.Background(), events.DeadLetterFilter{})
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.Equal(t, "panic: boom", stored[0].LastError)
+}
+
+func TestTimeoutCancelsTheHandlerContext(t *testing.T) {
+	returned := make(chan struct{})
+	err := events.Timeout(10*time.Millisecond)(context.Background(), contracts.Event{Type: "order.created"}, handleInfo, func(ctx context.Context, event contracts.Event) error {
+		defer close(returned)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	var timeoutErr *events.TimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, "event handle exceeded deadline of 10ms: context deadline exceeded", err.Error())
+
+	<-returned
+	select {
+	case <-timeoutErr.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Done not closed after the handler returned")
+	}
+}
+
+func TestTimeoutRepanicsInCallerGoroutine(t *testing.T) {
+	interceptor := events.Timeout(time.Second)
+	err := events.Recover()(context.Background(), contracts.Event{Type: "order.created"}, handleInfo, func(ctx context.Context, event contracts.Event) error {
+		return interceptor(ctx, event, handleInfo, func(ctx context.Context, event contracts.Event) error {
+			panic("boom")
+		})
+	})
+
+	var panicErr *events.PanicError
+	assert.ErrorAs(t, err, &panicErr)
+}
+
+// Um handler que ignora o contexto não ganha uma segunda cópia em paralelo:
+// a nova tentativa espera a anterior retornar
+func TestTimeoutDoesNotRetryWhileHandlerIsRunning(t *testing.T) {
+	bus := events.NewEventBus(events.WithHandlerInterceptors(events.Timeout(10 * time.Millisecond)))
+
+	var mu sync.Mutex
+	var running, maxRunning, attempts int
+	_, err := bus.SubscribeWithOptions("order.created", func(ctx context.Context, event contracts.Event) error {
+		mu.Lock()
+		attempts++
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			running--
+			mu.Unlock()
+		}()
+
+		time.Sleep(50 * time.Millisecond) // Ignora ctx
+		return errors.New("still failing")
+	}, events.WithSubscriptionName("orders"), events.WithRetryPolicy(events.RetryPolicy{MaxAttempts: 3}))
+	require.NoError(t, err)
+
+	require.NoError(t, bus.Publish(context.Background(), contracts.Event{Type: "order.created"}))
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, 1, maxRunning)
+}
+
+func TestLoggingRecordsOutcome(t *testing.T) {
+	logger := &recordingLogger{}
+	logging := events.Logging(logger)
+	event := contracts.Event{ID: "evt-1", Type: "order.created", CorrelationID: "req-1"}
+
+	require.NoError(t, logging(context.Background(), event, handleInfo, func(ctx context.Context, event contracts.Event) error {
+		return nil
+	}))
+	err := logging(context.Background(), event, events.InterceptorInfo{Operation: events.OperationPublish}, func(ctx context.Context, event contracts.Event) error {
+		return errors.New("bus unavailable")
+	})
+	assert.EqualError(t, err, "bus unavailable", "the error is passed through")
+
+	require.Len(t, logger.entries, 2)
+
+	succeeded := logger.entries[0]
+	assert.Equal(t, "debug", succeeded.level)
+	assert.Equal(t, "Event handle succeeded", succeeded.message)
+	assert.Equal(t, "evt-1", succeeded.fields["event_id"])
+	assert.Equal(t, "req-1", succeeded.fields["correlation_id"])
+	assert.Equal(t, "orders", succeeded.fields["subscription"])
+	assert.Equal(t, 1, succeeded.fields["attempt"])
+
+	failed := logger.entries[1]
+	assert.Equal(t, "error", failed.level)
+	assert.Equal(t, "Event publish failed", failed.message)
+	assert.Equal(t, "bus unavailable", failed.fields["error"])
+	assert.NotContains(t, failed.fields, "subscription")
+}
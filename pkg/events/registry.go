@@ -0,0 +1,156 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"go-modular-monolith/pkg/contracts"
+)
+
+var (
+	// ErrUnknownEventType é retornado ao publicar um tipo de evento não registrado
+	ErrUnknownEventType = errors.New("unknown event type")
+	// ErrInvalidPayload é retornado quando o payload não corresponde ao tipo registrado
+	ErrInvalidPayload = errors.New("invalid event payload")
+)
+
+// PayloadValidator é implementado por payloads que validam o próprio conteúdo
+type PayloadValidator interface {
+	Validate() error
+}
+
+// Registry associa cada tipo de evento à struct Go do seu payload
+type Registry struct {
+	mu    sync.RWMutex
+	types map[string]registeredType
+}
+
+type registeredType struct {
+	payloadType reflect.Type
+	version     int
+}
+
+// NewRegistry cria um registry vazio
+func NewRegistry() *Registry {
+	return &Registry{
+		types: make(map[string]registeredType),
+	}
+}
+
+// NewDefaultRegistry cria um registry com todos os eventos de domínio da aplicação
+func NewDefaultRegistry() *Registry {
+	r := NewRegistry()
+	r.MustRegister(UserCreatedEventType, 1, contracts.UserCreatedEvent{})
+	r.MustRegister(UserUpdatedEventType, 1, contracts.UserUpdatedEvent{})
+	r.MustRegister(UserDeletedEventType, 1, contracts.UserDeletedEvent{})
+	r.MustRegister(UserRestoredEventType, 1, contracts.UserRestoredEvent{})
+	r.MustRegister(UserPurgedEventType, 1, contracts.UserPurgedEvent{})
+	r.MustRegister(ProductCreatedEventType, 1, contracts.ProductCreatedEvent{})
+	r.MustRegister(ProductUpdatedEventType, 1, contracts.ProductUpdatedEvent{})
+	r.MustRegister(ProductStockUpdatedEventType, 1, contracts.ProductStockUpdatedEvent{})
+	r.MustRegister(ProductDeletedEventType, 1, contracts.ProductDeletedEvent{})
+	r.MustRegister(ProductRestoredEventType, 1, contracts.ProductRestoredEvent{})
+	r.MustRegister(ProductPurgedEventType, 1, contracts.ProductPurgedEvent{})
+	r.MustRegister(OrderCreatedEventType, 1, contracts.OrderCreatedEvent{})
+	r.MustRegister(OrderStatusUpdatedEventType, 1, contracts.OrderStatusUpdatedEvent{})
+	r.MustRegister(OrderCancelledEventType, 1, contracts.OrderCancelledEvent{})
+	r.MustRegister(OrderDeletedEventType, 1, contracts.OrderDeletedEvent{})
+	r.MustRegister(OrderRestoredEventType, 1, contracts.OrderRestoredEvent{})
+	r.MustRegister(OrderPurgedEventType, 1, contracts.OrderPurgedEvent{})
+	return r
+}
+
+// Register associa o tipo de evento à struct do payload (informada por um valor de exemplo)
+func (r *Registry) Register(eventType string, version int, prototype interface{}) error {
+	payloadType := reflect.TypeOf(prototype)
+	if payloadType == nil || payloadType.Kind() != reflect.Struct {
+		return fmt.Errorf("payload of %s must be a struct", eventType)
+	}
+	if version < 1 {
+		return fmt.Errorf("version of %s must be at least 1", eventType)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.types[eventType]; exists {
+		return fmt.Errorf("event type '%s' already registered", eventType)
+	}
+
+	r.types[eventType] = registeredType{payloadType: payloadType, version: version}
+	return nil
+}
+
+// MustRegister registra o tipo de evento ou entra em pânico
+func (r *Registry) MustRegister(eventType string, version int, prototype interface{}) {
+	if err := r.Register(eventType, version, prototype); err != nil {
+		panic(err)
+	}
+}
+
+// Decode valida o evento e converte o payload para a struct registrada.
+// Payloads em JSON (vindos do outbox, do event log ou de dead letters) são decodificados.
+func (r *Registry) Decode(event contracts.Event) (contracts.Event, error) {
+	r.mu.RLock()
+	registered, exists := r.types[event.Type]
+	r.mu.RUnlock()
+
+	if !exists {
+		return event, fmt.Errorf("%w: %s", ErrUnknownEventType, event.Type)
+	}
+
+	if event.Version == 0 {
+		event.Version = registered.version
+	} else if event.Version != registered.version {
+		return event, fmt.Errorf("%w: %s expects version %d, got %d", ErrInvalidPayload, event.Type, registered.version, event.Version)
+	}
+
+	payload, err := decodePayload(event.Payload, registered.payloadType)
+	if err != nil {
+		return event, fmt.Errorf("%w: %s: %v", ErrInvalidPayload, event.Type, err)
+	}
+
+	if validator, ok := payload.(PayloadValidator); ok {
+		if err := validator.Validate(); err != nil {
+			return event, fmt.Errorf("%w: %s: %v", ErrInvalidPayload, event.Type, err)
+		}
+	}
+
+	event.Payload = payload
+	return event, nil
+}
+
+func decodePayload(payload interface{}, payloadType reflect.Type) (interface{}, error) {
+	var raw []byte
+
+	switch value := payload.(type) {
+	case nil:
+		return nil, errors.New("payload is required")
+	case json.RawMessage:
+		raw = value
+	case []byte:
+		raw = value
+	default:
+		valueType := reflect.TypeOf(payload)
+		if valueType == payloadType {
+			return payload, nil
+		}
+		if valueType.Kind() == reflect.Ptr && valueType.Elem() == payloadType {
+			return reflect.ValueOf(payload).Elem().Interface(), nil
+		}
+		return nil, fmt.Errorf("expected %s, got %s", payloadType, valueType)
+	}
+
+	target := reflect.New(payloadType)
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target.Interface()); err != nil {
+		return nil, err
+	}
+
+	return target.Elem().Interface(), nil
+}
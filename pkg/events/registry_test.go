@@ -0,0 +1,87 @@
+package events_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/events"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryDecodesRegisteredPayloads(t *testing.T) {
+	registry := events.NewDefaultRegistry()
+	expected := contracts.OrderCreatedEvent{OrderID: "order-1", UserID: "user-1", Total: 10}
+
+	for name, payload := range map[string]interface{}{
+		"struct":  expected,
+		"pointer": &expected,
+		"json":    json.RawMessage(`{"order_id":"order-1","user_id":"user-1","total":10}`),
+		"bytes":   []byte(`{"order_id":"order-1","user_id":"user-1","total":10}`),
+	} {
+		t.Run(name, func(t *testing.T) {
+			event, err := registry.Decode(contracts.Event{Type: events.OrderCreatedEventType, Payload: payload})
+			require.NoError(t, err)
+			assert.Equal(t, expected, event.Payload)
+			assert.Equal(t, 1, event.Version, "the registered version is filled in")
+		})
+	}
+}
+
+func TestRegistryRejectsInvalidEvents(t *testing.T) {
+	registry := events.NewDefaultRegistry()
+
+	_, err := registry.Decode(contracts.Event{Type: "order.shipped", Payload: contracts.OrderCreatedEvent{OrderID: "order-1"}})
+	assert.ErrorIs(t, err, events.ErrUnknownEventType)
+
+	for name, event := range map[string]contracts.Event{
+		"missing payload":   {Type: events.OrderCreatedEventType},
+		"other struct":      {Type: events.OrderCreatedEventType, Payload: contracts.OrderCancelledEvent{OrderID: "order-1", UserID: "user-1"}},
+		"map":               {Type: events.OrderCreatedEventType, Payload: map[string]interface{}{"order_id": "order-1"}},
+		"mistyped field":    {Type: events.OrderCreatedEventType, Payload: json.RawMessage(`{"order_id":42,"user_id":"user-1"}`)},
+		"unknown field":     {Type: events.OrderCreatedEventType, Payload: json.RawMessage(`{"order_id":"order-1","user_id":"user-1","coupon":"X"}`)},
+		"malformed json":    {Type: events.OrderCreatedEventType, Payload: json.RawMessage(`{"order_id":`)},
+		"failed validation": {Type: events.OrderCreatedEventType, Payload: contracts.OrderCreatedEvent{UserID: "user-1"}},
+		"other version":     {Type: events.OrderCreatedEventType, Version: 2, Payload: contracts.OrderCreatedEvent{OrderID: "order-1", UserID: "user-1"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := registry.Decode(event)
+			assert.ErrorIs(t, err, events.ErrInvalidPayload)
+		})
+	}
+}
+
+func TestRegistryRegistration(t *testing.T) {
+	registry := events.NewRegistry()
+	require.NoError(t, registry.Register("invoice.issued", 2, contracts.OrderCreatedEvent{}))
+
+	assert.Error(t, registry.Register("invoice.issued", 2, contracts.OrderCreatedEvent{}), "already registered")
+	assert.Error(t, registry.Register("invoice.paid", 1, "not a struct"))
+	assert.Error(t, registry.Register("invoice.paid", 0, contracts.OrderCreatedEvent{}))
+	assert.Panics(t, func() { registry.MustRegister("invoice.issued", 2, contracts.OrderCreatedEvent{}) })
+}
+
+// Com registry, o Publish recusa o evento antes de gravá-lo ou entregá-lo
+func TestPublishRejectsInvalidPayloads(t *testing.T) {
+	eventLog := events.NewMemoryEventLog()
+	bus := events.NewEventBus(events.WithRegistry(events.NewDefaultRegistry()), events.WithEventLog(eventLog))
+
+	delivered := 0
+	_, err := bus.Subscribe("order.*", func(ctx context.Context, event contracts.Event) error {
+		delivered++
+		return nil
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	assert.ErrorIs(t, bus.Publish(ctx, contracts.Event{Type: "order.shipped", Payload: contracts.OrderCreatedEvent{OrderID: "order-1"}}), events.ErrUnknownEventType)
+	assert.ErrorIs(t, bus.Publish(ctx, contracts.Event{Type: events.OrderCreatedEventType, Payload: json.RawMessage(`{"order_id":true}`)}), events.ErrInvalidPayload)
+
+	stored, err := eventLog.Read(ctx, events.EventLogQuery{})
+	require.NoError(t, err)
+	assert.Empty(t, stored)
+	assert.Zero(t, delivered)
+}
@@ -0,0 +1,81 @@
+package events
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy define quantas vezes e com qual intervalo um handler com erro é reexecutado
+type RetryPolicy struct {
+	MaxAttempts    int           // Total de tentativas, incluindo a primeira
+	InitialBackoff time.Duration // Espera antes da segunda tentativa
+	MaxBackoff     time.Duration // Limite superior da espera entre tentativas
+	Multiplier     float64       // Fator de crescimento exponencial
+	Jitter         float64       // Fração aleatória (0 a 1) aplicada sobre a espera
+}
+
+// DefaultRetryPolicy retorna a política padrão: 3 tentativas com backoff exponencial
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// NoRetry retorna uma política de tentativa única
+func NoRetry() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 1}
+}
+
+// Backoff calcula a espera antes da tentativa seguinte à tentativa informada (a partir de 1)
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	if p.InitialBackoff <= 0 {
+		return 0
+	}
+
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	backoff := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		// Espalha as tentativas em [backoff*(1-jitter), backoff*(1+jitter)]
+		backoff += backoff * p.Jitter * (2*rand.Float64() - 1)
+	}
+
+	return time.Duration(backoff)
+}
+
+func (p RetryPolicy) attempts() int {
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// sleep aguarda a duração informada ou até o contexto ser cancelado
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
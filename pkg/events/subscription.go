@@ -0,0 +1,82 @@
+package events
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"sync/atomic"
+
+	"go-modular-monolith/pkg/contracts"
+)
+
+// subscription associa um handler ao seu nome, padrão e política de retry
+type subscription struct {
+	bus       *EventBus
+	name      string
+	eventType string // Tipo exato ou padrão com curingas
+	handler   contracts.EventHandler
+	retry     RetryPolicy
+	active    atomic.Bool
+}
+
+// SubscriptionOption configura uma inscrição individual
+type SubscriptionOption func(*subscription)
+
+// WithSubscriptionName nomeia a inscrição; o nome identifica as dead letters
+// para redrive e o offset do consumer group na fila em disco, e deve se manter
+// estável entre deploys
+func WithSubscriptionName(name string) SubscriptionOption {
+	return func(s *subscription) {
+		s.name = name
+	}
+}
+
+// WithRetryPolicy define a política de retry da inscrição
+func WithRetryPolicy(policy RetryPolicy) SubscriptionOption {
+	return func(s *subscription) {
+		s.retry = policy
+	}
+}
+
+// Unsubscribe cancela a inscrição. Pode ser chamado durante um Publish:
+// entregas já em andamento terminam, mas o handler não recebe novos eventos.
+// Chamadas repetidas não têm efeito.
+func (s *subscription) Unsubscribe() error {
+	if !s.active.CompareAndSwap(true, false) {
+		return nil
+	}
+	s.bus.unsubscribe(s)
+	return nil
+}
+
+func (s *subscription) matches(eventType string) bool {
+	return matchPattern(s.eventType, eventType)
+}
+
+// matchPattern verifica se o tipo casa com o padrão da inscrição.
+// "*" casa qualquer sequência de caracteres, inclusive pontos: "order.*"
+// recebe "order.created" e "order.status.updated".
+func matchPattern(pattern, eventType string) bool {
+	if pattern == eventType {
+		return true
+	}
+	if !isPattern(pattern) {
+		return false
+	}
+	matched, err := path.Match(pattern, eventType)
+	return err == nil && matched
+}
+
+func isPattern(pattern string) bool {
+	return strings.ContainsAny(pattern, `*?[\`)
+}
+
+func validatePattern(pattern string) error {
+	if pattern == "" {
+		return fmt.Errorf("event type pattern cannot be empty")
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid event type pattern '%s': %w", pattern, err)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package events_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/events"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder guarda os nomes das inscrições na ordem em que receberam eventos
+type recorder struct {
+	mu        sync.Mutex
+	delivered []string
+}
+
+func (r *recorder) handler(name string) contracts.EventHandler {
+	return func(ctx context.Context, event contracts.Event) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.delivered = append(r.delivered, name)
+		return nil
+	}
+}
+
+func (r *recorder) take() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delivered := r.delivered
+	r.delivered = nil
+	return delivered
+}
+
+func TestWildcardMatching(t *testing.T) {
+	bus := events.NewEventBus()
+	rec := &recorder{}
+	patterns := []string{"order.created", "order.*", "*.created", "*", "order.?reated"}
+	for _, pattern := range patterns {
+		_, err := bus.Subscribe(pattern, rec.handler(pattern))
+		require.NoError(t, err)
+	}
+
+	for eventType, expected := range map[string][]string{
+		"order.created":        {"order.created", "order.*", "*.created", "*", "order.?reated"},
+		"order.status.updated": {"order.*", "*"},
+		"user.created":         {"*.created", "*"},
+		"orders.created":       {"*.created", "*"},
+		"order":                {"*"},
+	} {
+		require.NoError(t, bus.Publish(context.Background(), contracts.Event{Type: eventType}))
+		assert.Equal(t, expected, rec.take(), eventType)
+	}
+
+	for _, invalid := range []string{"", "order.[", `order.\`} {
+		_, err := bus.Subscribe(invalid, rec.handler(invalid))
+		assert.Error(t, err, invalid)
+	}
+}
+
+// Inscrições com padrões diferentes recebem o evento na ordem de registro,
+// em toda publicação
+func TestDeliveryFollowsRegistrationOrder(t *testing.T) {
+	bus := events.NewEventBus()
+	rec := &recorder{}
+	names := []string{"audit", "inventory", "email", "projection", "webhooks", "metrics"}
+	patterns := []string{"*", "order.created", "order.*", "*.created", "order.created", "order.*"}
+	for i, name := range names {
+		_, err := bus.SubscribeWithOptions(patterns[i], rec.handler(name), events.WithSubscriptionName(name))
+		require.NoError(t, err)
+	}
+
+	for i := 0; i < 20; i++ {
+		require.NoError(t, bus.Publish(context.Background(), contracts.Event{Type: "order.created"}))
+		require.Equal(t, names, rec.take())
+	}
+}
+
+func TestUnsubscribeDuringPublish(t *testing.T) {
+	bus := events.NewEventBus()
+	rec := &recorder{}
+
+	var email contracts.Subscription
+	_, err := bus.SubscribeWithOptions("order.created", func(ctx context.Context, event contracts.Event) error {
+		require.NoError(t, email.Unsubscribe())
+		return rec.handler("inventory")(ctx, event)
+	}, events.WithSubscriptionName("inventory"))
+	require.NoError(t, err)
+
+	email, err = bus.SubscribeWithOptions("order.*", rec.handler("email"), events.WithSubscriptionName("email"))
+	require.NoError(t, err)
+
+	var once contracts.Subscription
+	once, err = bus.SubscribeWithOptions("*", func(ctx context.Context, event contracts.Event) error {
+		require.NoError(t, once.Unsubscribe())
+		return rec.handler("once")(ctx, event)
+	}, events.WithSubscriptionName("once"))
+	require.NoError(t, err)
+
+	// email é cancelado antes da sua vez e não recebe o evento em andamento;
+	// once se cancela, mas termina a entrega atual
+	require.NoError(t, bus.Publish(context.Background(), contracts.Event{Type: "order.created"}))
+	assert.Equal(t, []string{"inventory", "once"}, rec.take())
+
+	require.NoError(t, bus.Publish(context.Background(), contracts.Event{Type: "order.created"}))
+	assert.Equal(t, []string{"inventory"}, rec.take())
+
+	assert.NoError(t, email.Unsubscribe(), "repeated calls have no effect")
+
+	// O nome de uma inscrição cancelada pode ser reutilizado
+	_, err = bus.SubscribeWithOptions("order.*", rec.handler("email"), events.WithSubscriptionName("email"))
+	require.NoError(t, err)
+	require.NoError(t, bus.Publish(context.Background(), contracts.Event{Type: "order.created"}))
+	assert.Equal(t, []string{"inventory", "email"}, rec.take())
+}
+
+func TestConcurrentUnsubscribeAndPublish(t *testing.T) {
+	bus := events.NewEventBus()
+	rec := &recorder{}
+
+	subscriptions := make([]contracts.Subscription, 50)
+	for i := range subscriptions {
+		sub, err := bus.Subscribe("order.*", rec.handler("handler"))
+		require.NoError(t, err)
+		subscriptions[i] = sub
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			assert.NoError(t, bus.Publish(context.Background(), contracts.Event{Type: "order.created"}))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for _, sub := range subscriptions {
+			assert.NoError(t, sub.Unsubscribe())
+		}
+	}()
+	wg.Wait()
+
+	rec.take()
+	require.NoError(t, bus.Publish(context.Background(), contracts.Event{Type: "order.created"}))
+	assert.Empty(t, rec.take())
+}
@@ -0,0 +1,170 @@
+package migrate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Migration é uma alteração versionada do schema de um módulo. As versões são
+// numeradas por módulo: user 1, user 2, order 1...
+type Migration struct {
+	Module  string
+	Version int
+	Name    string
+	Up      string
+	Down    string // Vazio quando a migração não pode ser revertida
+}
+
+// ID identifica a migração nas mensagens, como "order/0002_add_status_index"
+func (m Migration) ID() string {
+	return fmt.Sprintf("%s/%04d_%s", m.Module, m.Version, m.Name)
+}
+
+// Checksum é o SHA-256 do SQL de subida, gravado ao aplicar para detectar
+// arquivos alterados depois de aplicados
+func (m Migration) Checksum() string {
+	sum := sha256.Sum256([]byte(m.Up))
+	return hex.EncodeToString(sum[:])
+}
+
+// Source são os arquivos de migração de um módulo
+type Source struct {
+	Module string
+	FS     fs.FS
+}
+
+// Dialect retorna a fonte restrita ao subdiretório do dialeto (mysql, sqlite).
+// Módulos mantêm um subdiretório por banco suportado com as mesmas versões.
+func (s Source) Dialect(dialect string) (Source, error) {
+	info, err := fs.Stat(s.FS, dialect)
+	if err != nil || !info.IsDir() {
+		return Source{}, fmt.Errorf("module %s has no %s migrations", s.Module, dialect)
+	}
+
+	files, err := fs.Sub(s.FS, dialect)
+	if err != nil {
+		return Source{}, err
+	}
+	return Source{Module: s.Module, FS: files}, nil
+}
+
+// fileName: 0001_create_users.up.sql / 0001_create_users.down.sql
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Load lê as migrações das fontes. A ordem resultante é a ordem de aplicação:
+// fontes na ordem informada e, em cada uma, versões crescentes.
+func Load(sources ...Source) ([]Migration, error) {
+	var all []Migration
+	for _, source := range sources {
+		migrations, err := loadSource(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load migrations of %s: %w", source.Module, err)
+		}
+		all = append(all, migrations...)
+	}
+	return all, nil
+}
+
+func loadSource(source Source) ([]Migration, error) {
+	entries, err := fs.ReadDir(source.FS, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q (expected 0001_name.up.sql)", entry.Name())
+		}
+
+		version, _ := strconv.Atoi(match[1])
+		if version <= 0 {
+			return nil, fmt.Errorf("invalid migration version in %q", entry.Name())
+		}
+
+		content, err := fs.ReadFile(source.FS, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, exists := byVersion[version]
+		if !exists {
+			m = &Migration{Module: source.Module, Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("version %d is used by %q and %q", version, m.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if strings.TrimSpace(m.Up) == "" {
+			return nil, fmt.Errorf("migration %s has no up file", m.ID())
+		}
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// splitStatements separa o SQL em comandos terminados por ";", ignorando
+// ponto e vírgula dentro de strings e linhas de comentário "--"
+func splitStatements(sql string) []string {
+	var (
+		statements []string
+		current    strings.Builder
+		quote      rune
+	)
+
+	for _, line := range strings.Split(sql, "\n") {
+		if quote == 0 && strings.HasPrefix(strings.TrimSpace(line), "--") {
+			continue
+		}
+
+		for _, r := range line {
+			switch {
+			case quote != 0:
+				if r == quote {
+					quote = 0
+				}
+			case r == '\'' || r == '"' || r == '`':
+				quote = r
+			case r == ';':
+				if stmt := strings.TrimSpace(current.String()); stmt != "" {
+					statements = append(statements, stmt)
+				}
+				current.Reset()
+				continue
+			}
+			current.WriteRune(r)
+		}
+		current.WriteRune('\n')
+	}
+
+	if stmt := strings.TrimSpace(current.String()); stmt != "" {
+		statements = append(statements, stmt)
+	}
+	return statements
+}
@@ -0,0 +1,65 @@
+package migrate
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadOrdersMigrationsBySourceAndVersion(t *testing.T) {
+	migrations, err := Load(
+		Source{Module: "user", FS: fstest.MapFS{
+			"0002_add_email.up.sql":      {Data: []byte("ALTER TABLE users ADD email TEXT;")},
+			"0001_create_users.up.sql":   {Data: []byte("CREATE TABLE users (id TEXT);")},
+			"0001_create_users.down.sql": {Data: []byte("DROP TABLE users;")},
+			"README.md":                  {Data: []byte("ignorado")},
+		}},
+		Source{Module: "order", FS: fstest.MapFS{
+			"0001_create_orders.up.sql": {Data: []byte("CREATE TABLE orders (id TEXT);")},
+		}},
+	)
+	require.NoError(t, err)
+
+	var ids []string
+	for _, m := range migrations {
+		ids = append(ids, m.ID())
+	}
+	assert.Equal(t, []string{"user/0001_create_users", "user/0002_add_email", "order/0001_create_orders"}, ids)
+	assert.Equal(t, "DROP TABLE users;", migrations[0].Down)
+	assert.Empty(t, migrations[1].Down)
+	assert.Len(t, migrations[0].Checksum(), 64)
+}
+
+func TestLoadRejectsInvalidFiles(t *testing.T) {
+	_, err := Load(Source{Module: "user", FS: fstest.MapFS{
+		"create_users.sql": {Data: []byte("CREATE TABLE users (id TEXT);")},
+	}})
+	assert.ErrorContains(t, err, `invalid migration file name "create_users.sql"`)
+
+	_, err = Load(Source{Module: "user", FS: fstest.MapFS{
+		"0001_create_users.down.sql": {Data: []byte("DROP TABLE users;")},
+	}})
+	assert.ErrorContains(t, err, "user/0001_create_users has no up file")
+
+	_, err = Load(Source{Module: "user", FS: fstest.MapFS{
+		"0001_create_users.up.sql": {Data: []byte("CREATE TABLE users (id TEXT);")},
+		"0001_add_email.up.sql":    {Data: []byte("ALTER TABLE users ADD email TEXT;")},
+	}})
+	assert.ErrorContains(t, err, "version 1 is used by")
+}
+
+func TestSplitStatements(t *testing.T) {
+	statements := splitStatements(`-- Comentário; ignorado
+CREATE TABLE products (id TEXT, name TEXT);
+INSERT INTO products VALUES ('prod-001', 'Monitor 27"; 4K');
+
+INSERT INTO products VALUES ('prod-002', 'It''s; fine')`)
+
+	assert.Equal(t, []string{
+		"CREATE TABLE products (id TEXT, name TEXT)",
+		`INSERT INTO products VALUES ('prod-001', 'Monitor 27"; 4K')`,
+		"INSERT INTO products VALUES ('prod-002', 'It''s; fine')",
+	}, statements)
+}
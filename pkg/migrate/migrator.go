@@ -0,0 +1,424 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// ErrChecksumMismatch é retornado quando o arquivo de uma migração aplicada foi alterado
+var ErrChecksumMismatch = errors.New("applied migration was modified")
+
+// ErrLocked é retornado quando outro processo mantém o lock das migrações além do prazo
+var ErrLocked = errors.New("migrations are locked by another runner")
+
+// ErrIrreversible é retornado ao reverter uma migração sem arquivo down
+var ErrIrreversible = errors.New("migration has no down file")
+
+// ErrUnknownVersion é retornado quando o alvo de To não existe no módulo
+var ErrUnknownVersion = errors.New("unknown migration version")
+
+// Estado de uma migração em Status
+const (
+	StatePending  = "pending"
+	StateApplied  = "applied"
+	StateModified = "modified" // Aplicada, mas o arquivo mudou desde então
+	StateMissing  = "missing"  // Registrada no banco, sem arquivo correspondente
+)
+
+// Status é a situação de uma migração conhecida pelo banco ou pelos arquivos
+type Status struct {
+	Migration
+	State     string
+	AppliedAt *time.Time
+}
+
+// applied é uma linha de schema_migrations
+type applied struct {
+	module    string
+	version   int
+	name      string
+	checksum  string
+	appliedAt time.Time
+}
+
+type key struct {
+	module  string
+	version int
+}
+
+// Option ajusta o Migrator
+type Option func(*Migrator)
+
+// WithLockTimeout define quanto tempo aguardar o lock de outro processo (padrão 1 minuto)
+func WithLockTimeout(timeout time.Duration) Option {
+	return func(m *Migrator) {
+		m.lockTimeout = timeout
+	}
+}
+
+// WithStaleLockAfter define a idade a partir da qual um lock é considerado
+// abandonado por um processo que caiu e pode ser tomado (padrão 15 minutos)
+func WithStaleLockAfter(age time.Duration) Option {
+	return func(m *Migrator) {
+		m.staleAfter = age
+	}
+}
+
+// Migrator aplica e reverte migrações registrando o histórico em
+// schema_migrations. Cada migração roda em uma transação junto com o seu
+// registro; em bancos com DDL não transacional (MySQL) uma falha no meio da
+// migração pode deixar parte dela aplicada.
+type Migrator struct {
+	db          *sql.DB
+	migrations  []Migration
+	owner       string
+	lockTimeout time.Duration
+	staleAfter  time.Duration
+}
+
+// New cria um Migrator para as migrações informadas, na ordem de aplicação
+func New(db *sql.DB, migrations []Migration, opts ...Option) *Migrator {
+	hostname, _ := os.Hostname()
+	m := &Migrator{
+		db:          db,
+		migrations:  migrations,
+		owner:       fmt.Sprintf("%s:%d", hostname, os.Getpid()),
+		lockTimeout: time.Minute,
+		staleAfter:  15 * time.Minute,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Status lista as migrações conhecidas e o estado de cada uma
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	if err := m.ensureTables(ctx); err != nil {
+		return nil, err
+	}
+
+	done, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	known := make(map[key]bool, len(m.migrations))
+	for _, migration := range m.migrations {
+		k := key{migration.Module, migration.Version}
+		known[k] = true
+
+		status := Status{Migration: migration, State: StatePending}
+		if row, ok := done[k]; ok {
+			appliedAt := row.appliedAt
+			status.AppliedAt = &appliedAt
+			status.State = StateApplied
+			if row.checksum != migration.Checksum() {
+				status.State = StateModified
+			}
+		}
+		statuses = append(statuses, status)
+	}
+
+	for k, row := range done {
+		if known[k] {
+			continue
+		}
+		appliedAt := row.appliedAt
+		statuses = append(statuses, Status{
+			Migration: Migration{Module: row.module, Version: row.version, Name: row.name},
+			State:     StateMissing,
+			AppliedAt: &appliedAt,
+		})
+	}
+	return statuses, nil
+}
+
+// Pending retorna as migrações ainda não aplicadas, na ordem de aplicação
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkModified(statuses); err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, status := range statuses {
+		if status.State == StatePending {
+			pending = append(pending, status.Migration)
+		}
+	}
+	return pending, nil
+}
+
+// Up aplica todas as migrações pendentes e retorna as aplicadas
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var result []Migration
+	err := m.withLock(ctx, func() error {
+		pending, err := m.Pending(ctx)
+		if err != nil {
+			return err
+		}
+		for _, migration := range pending {
+			if err := m.apply(ctx, migration); err != nil {
+				return err
+			}
+			result = append(result, migration)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// Down reverte as últimas steps migrações aplicadas, da mais recente para a
+// mais antiga pela data de aplicação em schema_migrations
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var result []Migration
+	err := m.withLock(ctx, func() error {
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, status := range newestFirst(statuses) {
+			if len(result) == steps {
+				break
+			}
+			if err := m.revert(ctx, status); err != nil {
+				return err
+			}
+			result = append(result, status.Migration)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// To leva o módulo até a versão informada: aplica as versões pendentes até
+// ela ou reverte as aplicadas acima dela. Versão 0 reverte todo o módulo.
+func (m *Migrator) To(ctx context.Context, module string, version int) ([]Migration, error) {
+	var result []Migration
+	err := m.withLock(ctx, func() error {
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		if err := checkModified(statuses); err != nil {
+			return err
+		}
+
+		var moduleStatuses []Status
+		found := version == 0
+		for _, status := range statuses {
+			if status.Module != module {
+				continue
+			}
+			moduleStatuses = append(moduleStatuses, status)
+			if status.Version == version && status.State != StateMissing {
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("%w: %s has no version %d", ErrUnknownVersion, module, version)
+		}
+
+		// Reverte as aplicadas acima do alvo, da mais recente para a mais antiga
+		for _, status := range newestFirst(moduleStatuses) {
+			if status.Version <= version {
+				continue
+			}
+			if err := m.revert(ctx, status); err != nil {
+				return err
+			}
+			result = append(result, status.Migration)
+		}
+
+		// Aplica as pendentes até o alvo
+		for _, status := range moduleStatuses {
+			if status.Version > version || status.State != StatePending {
+				continue
+			}
+			if err := m.apply(ctx, status.Migration); err != nil {
+				return err
+			}
+			result = append(result, status.Migration)
+		}
+		return nil
+	})
+	return result, err
+}
+
+func (m *Migrator) apply(ctx context.Context, migration Migration) error {
+	return m.inTx(ctx, migration, migration.Up, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO schema_migrations (module, version, name, checksum, applied_at) VALUES (?, ?, ?, ?, ?)",
+			migration.Module, migration.Version, migration.Name, migration.Checksum(), time.Now().UTC(),
+		)
+		return err
+	})
+}
+
+func (m *Migrator) revert(ctx context.Context, status Status) error {
+	if status.State == StateMissing {
+		return fmt.Errorf("%w: %s is applied but its files are missing", ErrIrreversible, status.ID())
+	}
+	if status.Down == "" {
+		return fmt.Errorf("%w: %s", ErrIrreversible, status.ID())
+	}
+
+	return m.inTx(ctx, status.Migration, status.Down, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx,
+			"DELETE FROM schema_migrations WHERE module = ? AND version = ?",
+			status.Module, status.Version,
+		)
+		return err
+	})
+}
+
+// inTx executa os comandos da migração e o registro no histórico na mesma transação
+func (m *Migrator) inTx(ctx context.Context, migration Migration, script string, record func(tx *sql.Tx) error) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction for %s: %w", migration.ID(), err)
+	}
+	defer tx.Rollback()
+
+	for i, stmt := range splitStatements(script) {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("migration %s failed at statement %d: %w", migration.ID(), i+1, err)
+		}
+	}
+	if err := record(tx); err != nil {
+		return fmt.Errorf("failed to record migration %s: %w", migration.ID(), err)
+	}
+	return tx.Commit()
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[key]applied, error) {
+	rows, err := m.db.QueryContext(ctx, "SELECT module, version, name, checksum, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	done := make(map[key]applied)
+	for rows.Next() {
+		var row applied
+		if err := rows.Scan(&row.module, &row.version, &row.name, &row.checksum, &row.appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+		}
+		done[key{row.module, row.version}] = row
+	}
+	return done, rows.Err()
+}
+
+func (m *Migrator) ensureTables(ctx context.Context) error {
+	for _, stmt := range []string{
+		`CREATE TABLE IF NOT EXISTS schema_migrations (
+			module VARCHAR(100) NOT NULL,
+			version BIGINT NOT NULL,
+			name VARCHAR(255) NOT NULL,
+			checksum VARCHAR(64) NOT NULL,
+			applied_at TIMESTAMP NOT NULL,
+			PRIMARY KEY (module, version)
+		)`,
+		`CREATE TABLE IF NOT EXISTS schema_migrations_lock (
+			id INT NOT NULL PRIMARY KEY,
+			owner VARCHAR(255) NOT NULL,
+			locked_at TIMESTAMP NOT NULL
+		)`,
+	} {
+		if _, err := m.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to create migration tables: %w", err)
+		}
+	}
+	return nil
+}
+
+// withLock executa fn com o lock de schema_migrations_lock: uma única linha
+// inserida por quem executa as migrações. Outro processo aguarda até o prazo;
+// um lock mais antigo que staleAfter é considerado abandonado e tomado.
+func (m *Migrator) withLock(ctx context.Context, fn func() error) error {
+	if err := m.ensureTables(ctx); err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(m.lockTimeout)
+	for {
+		_, err := m.db.ExecContext(ctx,
+			"INSERT INTO schema_migrations_lock (id, owner, locked_at) VALUES (1, ?, ?)",
+			m.owner, time.Now().UTC(),
+		)
+		if err == nil {
+			break
+		}
+
+		var owner string
+		var lockedAt time.Time
+		row := m.db.QueryRowContext(ctx, "SELECT owner, locked_at FROM schema_migrations_lock WHERE id = 1")
+		if scanErr := row.Scan(&owner, &lockedAt); scanErr != nil {
+			if errors.Is(scanErr, sql.ErrNoRows) {
+				continue // Liberado entre o INSERT e o SELECT
+			}
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+
+		if time.Since(lockedAt) > m.staleAfter {
+			// Remove apenas o lock lido, para não tirar o de quem o tomou antes
+			if _, err := m.db.ExecContext(ctx,
+				"DELETE FROM schema_migrations_lock WHERE id = 1 AND owner = ?", owner,
+			); err != nil {
+				return fmt.Errorf("failed to remove stale migration lock: %w", err)
+			}
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%w: held by %s since %s", ErrLocked, owner, lockedAt.Format(time.RFC3339))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+
+	defer m.db.ExecContext(context.WithoutCancel(ctx), "DELETE FROM schema_migrations_lock WHERE id = 1 AND owner = ?", m.owner)
+	return fn()
+}
+
+// newestFirst retorna as migrações aplicadas da mais recente para a mais
+// antiga por applied_at. Migrações aplicadas no mesmo instante (a precisão do
+// TIMESTAMP no MySQL é de segundos) seguem a ordem inversa de declaração.
+func newestFirst(statuses []Status) []Status {
+	var done []Status
+	for i := len(statuses) - 1; i >= 0; i-- {
+		if statuses[i].State != StatePending {
+			done = append(done, statuses[i])
+		}
+	}
+
+	sort.SliceStable(done, func(i, j int) bool {
+		return done[i].AppliedAt.After(*done[j].AppliedAt)
+	})
+	return done
+}
+
+func checkModified(statuses []Status) error {
+	var errs []error
+	for _, status := range statuses {
+		if status.State == StateModified {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrChecksumMismatch, status.ID()))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,201 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	userSource = Source{Module: "user", FS: fstest.MapFS{
+		"0001_create_users.up.sql":   {Data: []byte("CREATE TABLE users (id TEXT);")},
+		"0001_create_users.down.sql": {Data: []byte("DROP TABLE users;")},
+		"0002_add_email.up.sql":      {Data: []byte("ALTER TABLE users ADD email TEXT;")},
+		"0002_add_email.down.sql":    {Data: []byte("ALTER TABLE users DROP COLUMN email;")},
+	}}
+	orderSource = Source{Module: "order", FS: fstest.MapFS{
+		"0001_create_orders.up.sql":   {Data: []byte("CREATE TABLE orders (id TEXT);")},
+		"0001_create_orders.down.sql": {Data: []byte("DROP TABLE orders;")},
+	}}
+)
+
+// openSQLite abre um SQLite em arquivo temporário, compartilhado por todas as
+// conexões do pool (e pelos Migrators do mesmo teste)
+func openSQLite(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "migrate.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func load(t *testing.T, sources ...Source) []Migration {
+	t.Helper()
+	migrations, err := Load(sources...)
+	require.NoError(t, err)
+	return migrations
+}
+
+func ids(migrations []Migration) []string {
+	result := make([]string, 0, len(migrations))
+	for _, migration := range migrations {
+		result = append(result, migration.ID())
+	}
+	return result
+}
+
+func states(t *testing.T, m *Migrator) map[string]string {
+	t.Helper()
+	statuses, err := m.Status(context.Background())
+	require.NoError(t, err)
+	result := make(map[string]string, len(statuses))
+	for _, status := range statuses {
+		result[status.ID()] = status.State
+	}
+	return result
+}
+
+func TestMigratorUp(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	m := New(db, load(t, userSource, orderSource))
+
+	applied, err := m.Up(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user/0001_create_users", "user/0002_add_email", "order/0001_create_orders"}, ids(applied))
+
+	_, err = db.ExecContext(ctx, "INSERT INTO users (id, email) VALUES ('user-1', 'john@example.com')")
+	assert.NoError(t, err)
+
+	applied, err = m.Up(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, applied, "nothing left to apply")
+	assert.Equal(t, map[string]string{
+		"user/0001_create_users":   StateApplied,
+		"user/0002_add_email":      StateApplied,
+		"order/0001_create_orders": StateApplied,
+	}, states(t, m))
+}
+
+// Down segue a ordem de aplicação, não a de declaração: um módulo declarado
+// primeiro, mas migrado por último, é o primeiro a ser revertido
+func TestMigratorDownRevertsMostRecentlyApplied(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+
+	_, err := New(db, load(t, userSource)).Up(ctx)
+	require.NoError(t, err)
+
+	m := New(db, load(t, orderSource, userSource))
+	applied, err := m.Up(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"order/0001_create_orders"}, ids(applied))
+
+	reverted, err := m.Down(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"order/0001_create_orders", "user/0002_add_email"}, ids(reverted))
+	assert.Equal(t, map[string]string{
+		"order/0001_create_orders": StatePending,
+		"user/0001_create_users":   StateApplied,
+		"user/0002_add_email":      StatePending,
+	}, states(t, m))
+}
+
+func TestMigratorDownBreaksTiesByDeclarationOrder(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	m := New(db, load(t, userSource, orderSource))
+
+	_, err := m.Up(ctx)
+	require.NoError(t, err)
+	// Mesmo segundo para todas, como no TIMESTAMP do MySQL
+	_, err = db.ExecContext(ctx, "UPDATE schema_migrations SET applied_at = ?", time.Now().UTC().Truncate(time.Second))
+	require.NoError(t, err)
+
+	reverted, err := m.Down(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"order/0001_create_orders", "user/0002_add_email", "user/0001_create_users"}, ids(reverted))
+}
+
+func TestMigratorTo(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	m := New(db, load(t, userSource, orderSource))
+
+	changed, err := m.To(ctx, "user", 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user/0001_create_users"}, ids(changed))
+
+	changed, err = m.To(ctx, "user", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user/0002_add_email"}, ids(changed))
+
+	changed, err = m.To(ctx, "user", 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user/0002_add_email", "user/0001_create_users"}, ids(changed))
+	assert.Equal(t, StatePending, states(t, m)["order/0001_create_orders"], "other modules are untouched")
+
+	_, err = m.To(ctx, "user", 3)
+	assert.ErrorIs(t, err, ErrUnknownVersion)
+}
+
+func TestMigratorRejectsModifiedMigrations(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+
+	_, err := New(db, load(t, userSource)).Up(ctx)
+	require.NoError(t, err)
+
+	modified := Source{Module: "user", FS: fstest.MapFS{
+		"0001_create_users.up.sql": {Data: []byte("CREATE TABLE users (id TEXT, name TEXT);")},
+		"0002_add_email.up.sql":    {Data: []byte("ALTER TABLE users ADD email TEXT;")},
+		"0003_add_phone.up.sql":    {Data: []byte("ALTER TABLE users ADD phone TEXT;")},
+	}}
+	m := New(db, load(t, modified))
+
+	_, err = m.Up(ctx)
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+	assert.ErrorContains(t, err, "user/0001_create_users")
+	assert.Equal(t, map[string]string{
+		"user/0001_create_users": StateModified,
+		"user/0002_add_email":    StateApplied,
+		"user/0003_add_phone":    StatePending,
+	}, states(t, m), "nothing is applied while a migration is modified")
+
+	_, err = m.To(ctx, "user", 3)
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+}
+
+func TestMigratorLock(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	m := New(db, load(t, userSource), WithLockTimeout(0), WithStaleLockAfter(time.Hour))
+	require.NoError(t, m.ensureTables(ctx))
+
+	_, err := db.ExecContext(ctx, "INSERT INTO schema_migrations_lock (id, owner, locked_at) VALUES (1, 'other-host:42', ?)", time.Now().UTC())
+	require.NoError(t, err)
+
+	_, err = m.Up(ctx)
+	assert.ErrorIs(t, err, ErrLocked)
+	assert.ErrorContains(t, err, "other-host:42")
+	assert.Equal(t, StatePending, states(t, m)["user/0001_create_users"])
+
+	// Um lock mais antigo que staleAfter é de um processo que caiu e é tomado
+	_, err = db.ExecContext(ctx, "UPDATE schema_migrations_lock SET locked_at = ?", time.Now().UTC().Add(-2*time.Hour))
+	require.NoError(t, err)
+
+	applied, err := m.Up(ctx)
+	require.NoError(t, err)
+	assert.Len(t, applied, 2)
+
+	var locks int
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM schema_migrations_lock").Scan(&locks))
+	assert.Zero(t, locks, "lock is released after running")
+}
@@ -0,0 +1,180 @@
+package module
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go-modular-monolith/pkg/container"
+	"go-modular-monolith/pkg/events"
+	"go-modular-monolith/pkg/migrate"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrUnknownModule é retornado quando uma dependência ou configuração cita um módulo inexistente
+var ErrUnknownModule = errors.New("unknown module")
+
+// ErrDependencyDisabled é retornado quando um módulo habilitado depende de um desabilitado
+var ErrDependencyDisabled = errors.New("module dependency is disabled")
+
+// ErrDependencyCycle é retornado quando as dependências entre módulos formam um ciclo
+var ErrDependencyCycle = errors.New("module dependency cycle")
+
+// Manager mantém os módulos habilitados em ordem de dependência: cada módulo
+// aparece depois dos módulos dos quais depende
+type Manager struct {
+	modules  []Module
+	disabled []string
+}
+
+// NewManager valida os módulos e os ordena por dependência. Os módulos em
+// disabled são ignorados; um módulo habilitado que dependa de um deles é um
+// erro. Módulos sem relação de dependência mantêm a ordem em que foram informados.
+func NewManager(modules []Module, disabled []string) (*Manager, error) {
+	known := make(map[string]Module, len(modules))
+	for _, m := range modules {
+		if _, exists := known[m.Name()]; exists {
+			return nil, fmt.Errorf("module '%s' is declared twice", m.Name())
+		}
+		known[m.Name()] = m
+	}
+
+	off := make(map[string]bool, len(disabled))
+	for _, name := range disabled {
+		if _, exists := known[name]; !exists {
+			return nil, fmt.Errorf("%w: '%s' cannot be disabled", ErrUnknownModule, name)
+		}
+		off[name] = true
+	}
+
+	var errs []error
+	for _, m := range modules {
+		if off[m.Name()] {
+			continue
+		}
+		for _, dep := range m.Dependencies() {
+			switch {
+			case known[dep] == nil:
+				errs = append(errs, fmt.Errorf("%w: '%s' depends on '%s'", ErrUnknownModule, m.Name(), dep))
+			case off[dep]:
+				errs = append(errs, fmt.Errorf("%w: '%s' depends on '%s'", ErrDependencyDisabled, m.Name(), dep))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	// Ordenação topológica por busca em profundidade
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(modules))
+	var path []string
+	ordered := make([]Module, 0, len(modules))
+
+	var visit func(m Module) error
+	visit = func(m Module) error {
+		state[m.Name()] = visiting
+		path = append(path, m.Name())
+
+		for _, dep := range m.Dependencies() {
+			switch state[dep] {
+			case unvisited:
+				if err := visit(known[dep]); err != nil {
+					return err
+				}
+			case visiting:
+				cycle := append(append([]string{}, path[indexOf(path, dep):]...), dep)
+				return fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(cycle, " -> "))
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[m.Name()] = visited
+		ordered = append(ordered, m)
+		return nil
+	}
+
+	for _, m := range modules {
+		if off[m.Name()] || state[m.Name()] != unvisited {
+			continue
+		}
+		if err := visit(m); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Manager{modules: ordered, disabled: append([]string{}, disabled...)}, nil
+}
+
+// Modules retorna os módulos habilitados em ordem de dependência
+func (m *Manager) Modules() []Module {
+	return append([]Module{}, m.modules...)
+}
+
+// Disabled retorna os nomes dos módulos desabilitados pela configuração
+func (m *Manager) Disabled() []string {
+	return append([]string{}, m.disabled...)
+}
+
+// Register registra os serviços de todos os módulos habilitados
+func (m *Manager) Register(c *container.Container) error {
+	for _, mod := range m.modules {
+		if err := mod.Register(c); err != nil {
+			return fmt.Errorf("failed to register module '%s': %w", mod.Name(), err)
+		}
+	}
+	return nil
+}
+
+// Migrations retorna as fontes de migração dos módulos habilitados em ordem de dependência
+func (m *Manager) Migrations() []migrate.Source {
+	var sources []migrate.Source
+	for _, mod := range m.modules {
+		if files := mod.Migrations(); files != nil {
+			sources = append(sources, migrate.Source{Module: mod.Name(), FS: files})
+		}
+	}
+	return sources
+}
+
+// RegisterRoutes registra as rotas dos módulos habilitados
+func (m *Manager) RegisterRoutes(router *gin.RouterGroup, c *container.Container) {
+	for _, mod := range m.modules {
+		mod.RegisterRoutes(router, c)
+	}
+}
+
+// Subscribe inscreve os consumidores de eventos dos módulos habilitados
+func (m *Manager) Subscribe(c *container.Container, bus *events.EventBus) error {
+	for _, mod := range m.modules {
+		if err := mod.Subscribe(c, bus); err != nil {
+			return fmt.Errorf("failed to subscribe module '%s': %w", mod.Name(), err)
+		}
+	}
+	return nil
+}
+
+// Health verifica os módulos habilitados. O mapa tem uma entrada por módulo,
+// nil quando o módulo está operacional.
+func (m *Manager) Health(ctx context.Context, c *container.Container) map[string]error {
+	results := make(map[string]error, len(m.modules))
+	for _, mod := range m.modules {
+		results[mod.Name()] = mod.Health(ctx, c)
+	}
+	return results
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,92 @@
+package module_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go-modular-monolith/pkg/container"
+	"go-modular-monolith/pkg/module"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeModule struct {
+	module.Base
+	name   string
+	deps   []string
+	health error
+	log    *[]string
+}
+
+func (m *fakeModule) Name() string           { return m.name }
+func (m *fakeModule) Dependencies() []string { return m.deps }
+
+func (m *fakeModule) Register(c *container.Container) error {
+	*m.log = append(*m.log, m.name)
+	return nil
+}
+
+func (m *fakeModule) Health(context.Context, *container.Container) error { return m.health }
+
+func newModules(log *[]string) []module.Module {
+	return []module.Module{
+		&fakeModule{name: "order", deps: []string{"user", "product"}, log: log},
+		&fakeModule{name: "webhook", log: log},
+		&fakeModule{name: "product", log: log},
+		&fakeModule{name: "user", log: log},
+	}
+}
+
+func TestManagerOrdersModulesByDependency(t *testing.T) {
+	var registered []string
+	manager, err := module.NewManager(newModules(&registered), nil)
+	require.NoError(t, err)
+
+	require.NoError(t, manager.Register(container.NewContainer()))
+	assert.Equal(t, []string{"user", "product", "order", "webhook"}, registered)
+}
+
+func TestManagerSkipsDisabledModules(t *testing.T) {
+	var registered []string
+	manager, err := module.NewManager(newModules(&registered), []string{"webhook"})
+	require.NoError(t, err)
+
+	require.NoError(t, manager.Register(container.NewContainer()))
+	assert.Equal(t, []string{"user", "product", "order"}, registered)
+	assert.Equal(t, []string{"webhook"}, manager.Disabled())
+
+	// Um módulo habilitado não pode depender de um desabilitado
+	_, err = module.NewManager(newModules(&registered), []string{"product"})
+	require.ErrorIs(t, err, module.ErrDependencyDisabled)
+	assert.Contains(t, err.Error(), "'order' depends on 'product'")
+
+	_, err = module.NewManager(newModules(&registered), []string{"billing"})
+	assert.ErrorIs(t, err, module.ErrUnknownModule)
+}
+
+func TestManagerRejectsCycles(t *testing.T) {
+	var log []string
+	_, err := module.NewManager([]module.Module{
+		&fakeModule{name: "a", deps: []string{"b"}, log: &log},
+		&fakeModule{name: "b", deps: []string{"a"}, log: &log},
+	}, nil)
+	require.ErrorIs(t, err, module.ErrDependencyCycle)
+	assert.Contains(t, err.Error(), "a -> b -> a")
+}
+
+func TestManagerHealth(t *testing.T) {
+	var log []string
+	down := errors.New("table not found")
+	manager, err := module.NewManager([]module.Module{
+		&fakeModule{name: "user", log: &log},
+		&fakeModule{name: "product", health: down, log: &log},
+	}, nil)
+	require.NoError(t, err)
+
+	results := manager.Health(context.Background(), container.NewContainer())
+	assert.Len(t, results, 2)
+	assert.NoError(t, results["user"])
+	assert.ErrorIs(t, results["product"], down)
+}
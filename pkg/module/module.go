@@ -0,0 +1,58 @@
+package module
+
+import (
+	"context"
+	"io/fs"
+
+	"go-modular-monolith/pkg/container"
+	"go-modular-monolith/pkg/events"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Module é uma unidade plugável da aplicação. Cada módulo declara de quais
+// outros depende, registra os próprios serviços no container e expõe rotas,
+// migrações, inscrições em eventos e verificação de saúde.
+type Module interface {
+	// Name identifica o módulo na configuração e nas dependências de outros módulos
+	Name() string
+
+	// Dependencies lista os nomes dos módulos que precisam estar habilitados
+	Dependencies() []string
+
+	// Register registra repositórios, serviços e handlers do módulo no container
+	Register(c *container.Container) error
+
+	// RegisterRoutes registra as rotas do módulo no grupo /api/v1
+	RegisterRoutes(router *gin.RouterGroup, c *container.Container)
+
+	// Migrations retorna os arquivos de migração SQL do módulo, um
+	// subdiretório por dialeto (mysql/0001_nome.up.sql, sqlite/0001_nome.up.sql),
+	// ou nil se ele não tem tabelas
+	Migrations() fs.FS
+
+	// Subscribe inscreve os consumidores de eventos do módulo
+	Subscribe(c *container.Container, bus *events.EventBus) error
+
+	// Health verifica se o módulo está operacional
+	Health(ctx context.Context, c *container.Container) error
+}
+
+// Base implementa Module sem fazer nada. Módulos a embutem e implementam
+// apenas o que usam, além de Name e Register.
+type Base struct{}
+
+// Dependencies não declara dependências
+func (Base) Dependencies() []string { return nil }
+
+// RegisterRoutes não registra rotas
+func (Base) RegisterRoutes(*gin.RouterGroup, *container.Container) {}
+
+// Migrations não declara migrações
+func (Base) Migrations() fs.FS { return nil }
+
+// Subscribe não inscreve consumidores
+func (Base) Subscribe(*container.Container, *events.EventBus) error { return nil }
+
+// Health considera o módulo sempre operacional
+func (Base) Health(context.Context, *container.Container) error { return nil }
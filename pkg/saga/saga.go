@@ -0,0 +1,461 @@
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go-modular-monolith/pkg/contracts"
+
+	"github.com/google/uuid"
+)
+
+// ErrSagaNotFound é retornado quando a instância não existe no store
+var ErrSagaNotFound = errors.New("saga not found")
+
+// Status representa o estado de uma instância de saga
+type Status string
+
+const (
+	StatusRunning      Status = "running"      // Executando os passos
+	StatusCompensating Status = "compensating" // Desfazendo os passos concluídos
+	StatusCompleted    Status = "completed"    // Todos os passos concluídos
+	StatusCompensated  Status = "compensated"  // Todos os passos concluídos foram desfeitos
+)
+
+// Finished indica se a instância não tem mais trabalho pendente
+func (s Status) Finished() bool {
+	return s == StatusCompleted || s == StatusCompensated
+}
+
+// RecoveryPolicy define o que fazer com uma saga interrompida em StatusRunning
+type RecoveryPolicy string
+
+const (
+	RecoverResume     RecoveryPolicy = "resume"     // Continua a partir do próximo passo
+	RecoverCompensate RecoveryPolicy = "compensate" // Desfaz os passos concluídos
+)
+
+// Instance é o estado persistido de uma execução de saga.
+// Os passos [0, Step) estão concluídos; Data é o estado da saga em JSON.
+// Owner é o processo que executa a instância enquanto LeaseExpiresAt não passa.
+type Instance struct {
+	ID             string          `json:"id"`
+	Name           string          `json:"name"`
+	Status         Status          `json:"status"`
+	Step           int             `json:"step"`
+	Data           json.RawMessage `json:"data"`
+	LastError      string          `json:"last_error,omitempty"`
+	Owner          string          `json:"owner,omitempty"`
+	LeaseExpiresAt *time.Time      `json:"lease_expires_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// Step é um passo da saga com a ação e a compensação que a desfaz.
+// Compensate pode ser nil para passos sem efeito (validações, por exemplo).
+type Step[T any] struct {
+	Name       string
+	Action     func(ctx context.Context, data *T) error
+	Compensate func(ctx context.Context, data *T) error
+}
+
+// Definition declara os passos de uma saga e a política de recuperação
+type Definition[T any] struct {
+	Name     string
+	Steps    []Step[T]
+	Recovery RecoveryPolicy
+}
+
+// runner é a parte da definição que o coordenador usa sem conhecer T
+type runner interface {
+	resume(ctx context.Context, instance *Instance) error
+}
+
+// Coordinator executa sagas persistindo o progresso a cada passo.
+// Com um TransactionManager, cada passo e a gravação do progresso são
+// confirmados na mesma transação: após uma queda o estado persistido
+// reflete exatamente os passos concluídos.
+//
+// Cada instância em execução tem uma lease no store, renovada enquanto o
+// processo trabalha nela. Com várias réplicas (ou durante um deploy) a
+// recuperação só assume instâncias cuja lease expirou.
+type Coordinator struct {
+	store     Store
+	txManager contracts.TransactionManager
+	logger    contracts.Logger
+	owner     string
+	lease     time.Duration
+
+	mu      sync.RWMutex
+	runners map[string]runner
+	running map[string]struct{} // Instâncias em execução neste processo
+}
+
+// DefaultLease é a duração padrão da lease de uma instância
+const DefaultLease = 30 * time.Second
+
+// Option configura o coordenador
+type Option func(*Coordinator)
+
+// WithOwner identifica o processo nas leases; o padrão é um UUID por coordenador
+func WithOwner(owner string) Option {
+	return func(c *Coordinator) {
+		c.owner = owner
+	}
+}
+
+// WithLease define por quanto tempo uma instância fica reservada ao processo
+// sem renovação. A lease é renovada a cada terço da duração; ela deve ser bem
+// maior que a diferença entre os relógios das réplicas.
+func WithLease(lease time.Duration) Option {
+	return func(c *Coordinator) {
+		if lease > 0 {
+			c.lease = lease
+		}
+	}
+}
+
+// NewCoordinator cria um coordenador de sagas. txManager pode ser nil.
+func NewCoordinator(store Store, txManager contracts.TransactionManager, logger contracts.Logger, opts ...Option) *Coordinator {
+	c := &Coordinator{
+		store:     store,
+		txManager: txManager,
+		logger:    logger,
+		owner:     uuid.New().String(),
+		lease:     DefaultLease,
+		runners:   make(map[string]runner),
+		running:   make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Saga é uma definição registrada em um coordenador
+type Saga[T any] struct {
+	definition  Definition[T]
+	coordinator *Coordinator
+}
+
+// Register registra a definição no coordenador para execução e recuperação
+func Register[T any](c *Coordinator, definition Definition[T]) (*Saga[T], error) {
+	if definition.Name == "" {
+		return nil, errors.New("saga name is required")
+	}
+	if len(definition.Steps) == 0 {
+		return nil, fmt.Errorf("saga %s has no steps", definition.Name)
+	}
+	if definition.Recovery == "" {
+		definition.Recovery = RecoverCompensate
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.runners[definition.Name]; exists {
+		return nil, fmt.Errorf("saga %s already registered", definition.Name)
+	}
+
+	s := &Saga[T]{definition: definition, coordinator: c}
+	c.runners[definition.Name] = s
+	return s, nil
+}
+
+// MustRegister é como Register, mas entra em pânico em caso de erro
+func MustRegister[T any](c *Coordinator, definition Definition[T]) *Saga[T] {
+	s, err := Register(c, definition)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Run executa a saga do início. Se um passo falha, os passos concluídos são
+// compensados em ordem inversa e o erro do passo é retornado.
+func (s *Saga[T]) Run(ctx context.Context, data *T) (*Instance, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode saga data: %w", err)
+	}
+
+	now := time.Now()
+	leaseExpiresAt := now.Add(s.coordinator.lease)
+	instance := &Instance{
+		ID:             uuid.New().String(),
+		Name:           s.definition.Name,
+		Status:         StatusRunning,
+		Data:           encoded,
+		Owner:          s.coordinator.owner,
+		LeaseExpiresAt: &leaseExpiresAt,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	s.coordinator.acquire(instance.ID)
+	defer s.coordinator.release(instance.ID)
+
+	if err := s.coordinator.store.Create(ctx, instance); err != nil {
+		return nil, err
+	}
+
+	stop := s.coordinator.keepAlive(instance.ID)
+	defer stop()
+
+	return instance, s.execute(ctx, instance, data)
+}
+
+// resume retoma uma instância interrompida conforme o status e a política
+func (s *Saga[T]) resume(ctx context.Context, instance *Instance) error {
+	data := new(T)
+	if err := json.Unmarshal(instance.Data, data); err != nil {
+		return fmt.Errorf("failed to decode saga data: %w", err)
+	}
+
+	if instance.Status == StatusRunning && s.definition.Recovery == RecoverCompensate {
+		return s.compensate(ctx, instance, data, errors.New("saga interrupted"))
+	}
+	if instance.Status == StatusCompensating {
+		return s.compensate(ctx, instance, data, errors.New(instance.LastError))
+	}
+	return s.execute(ctx, instance, data)
+}
+
+// execute roda os passos pendentes a partir de instance.Step
+func (s *Saga[T]) execute(ctx context.Context, instance *Instance, data *T) error {
+	for instance.Step < len(s.definition.Steps) {
+		current := instance.Step
+		step := s.definition.Steps[current]
+
+		err := s.coordinator.withinTransaction(ctx, func(ctx context.Context) error {
+			if err := step.Action(ctx, data); err != nil {
+				return err
+			}
+			instance.Step++
+			if instance.Step == len(s.definition.Steps) {
+				instance.Status = StatusCompleted
+			}
+			return s.coordinator.save(ctx, instance, data)
+		})
+		if err != nil {
+			// A transação do passo foi desfeita: restaura o progresso em memória
+			instance.Status = StatusRunning
+			instance.Step = current
+
+			stepErr := fmt.Errorf("saga %s failed at step %s: %w", s.definition.Name, step.Name, err)
+			if ctx.Err() != nil {
+				// Cancelado: a recuperação decide o destino da instância
+				return stepErr
+			}
+			return s.compensate(ctx, instance, data, stepErr)
+		}
+	}
+	return nil
+}
+
+// compensate desfaz os passos concluídos em ordem inversa. Se uma compensação
+// falha a instância permanece em StatusCompensating para nova tentativa na recuperação.
+func (s *Saga[T]) compensate(ctx context.Context, instance *Instance, data *T, cause error) error {
+	instance.Status = StatusCompensating
+	if instance.Step == 0 {
+		// Falhou no primeiro passo: não há o que desfazer
+		instance.Status = StatusCompensated
+	}
+	instance.LastError = cause.Error()
+	if err := s.coordinator.save(ctx, instance, data); err != nil {
+		return errors.Join(cause, err)
+	}
+
+	for instance.Step > 0 {
+		current := instance.Step
+		step := s.definition.Steps[current-1]
+
+		err := s.coordinator.withinTransaction(ctx, func(ctx context.Context) error {
+			if step.Compensate != nil {
+				if err := step.Compensate(ctx, data); err != nil {
+					return err
+				}
+			}
+			instance.Step--
+			if instance.Step == 0 {
+				instance.Status = StatusCompensated
+			}
+			return s.coordinator.save(ctx, instance, data)
+		})
+		if err != nil {
+			instance.Status = StatusCompensating
+			instance.Step = current
+
+			compErr := fmt.Errorf("saga %s failed to compensate step %s: %w", s.definition.Name, step.Name, err)
+			s.coordinator.logger.Error("Saga compensation failed",
+				contracts.Field{Key: "saga", Value: s.definition.Name},
+				contracts.Field{Key: "saga_id", Value: instance.ID},
+				contracts.Field{Key: "step", Value: step.Name},
+				contracts.Field{Key: "error", Value: err.Error()},
+			)
+			return errors.Join(cause, compErr)
+		}
+	}
+	return cause
+}
+
+// save grava o progresso e o estado atual da saga
+func (c *Coordinator) save(ctx context.Context, instance *Instance, data interface{}) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode saga data: %w", err)
+	}
+	instance.Data = encoded
+	instance.UpdatedAt = time.Now()
+	return c.store.Update(ctx, instance)
+}
+
+// acquire marca a instância como em execução; retorna false se já estava
+func (c *Coordinator) acquire(id string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, exists := c.running[id]; exists {
+		return false
+	}
+	c.running[id] = struct{}{}
+	return true
+}
+
+func (c *Coordinator) release(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.running, id)
+}
+
+// claim assume a lease da instância para este processo. Instâncias sem lease
+// (gravadas antes das leases existirem) só são assumidas depois de uma
+// duração de lease sem progresso.
+func (c *Coordinator) claim(ctx context.Context, instance *Instance) (bool, error) {
+	if instance.LeaseExpiresAt == nil && time.Since(instance.UpdatedAt) < c.lease {
+		return false, nil
+	}
+	return c.store.Lease(ctx, instance.ID, c.owner, time.Now().Add(c.lease))
+}
+
+// keepAlive renova a lease da instância até stop ser chamada; stop libera a
+// lease para que a recuperação de qualquer processo possa assumir a instância
+func (c *Coordinator) keepAlive(id string) (stop func()) {
+	done := make(chan struct{})
+	finished := make(chan struct{})
+
+	go func() {
+		defer close(finished)
+		ticker := time.NewTicker(c.lease / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				renewed, err := c.store.Lease(context.Background(), id, c.owner, time.Now().Add(c.lease))
+				if err != nil || !renewed {
+					c.logger.Error("Failed to renew saga lease",
+						contracts.Field{Key: "saga_id", Value: id},
+						contracts.Field{Key: "renewed", Value: renewed},
+						contracts.Field{Key: "error", Value: err},
+					)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-finished
+		if _, err := c.store.Lease(context.Background(), id, c.owner, time.Now()); err != nil {
+			c.logger.Warn("Failed to release saga lease",
+				contracts.Field{Key: "saga_id", Value: id},
+				contracts.Field{Key: "error", Value: err.Error()},
+			)
+		}
+	}
+}
+
+func (c *Coordinator) withinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if c.txManager == nil {
+		return fn(ctx)
+	}
+	return c.txManager.WithinTransaction(ctx, fn)
+}
+
+// RecoveryResult resume uma execução de Recover
+type RecoveryResult struct {
+	Resumed     int `json:"resumed"`
+	Compensated int `json:"compensated"`
+	Failed      int `json:"failed"`
+	Skipped     int `json:"skipped"` // Com lease válida de outro processo
+}
+
+// Recover retoma as instâncias não finalizadas, normalmente na inicialização.
+// Sagas em execução seguem a política da definição; sagas em compensação
+// continuam sendo compensadas. Instâncias em andamento neste processo ou com
+// lease válida de outro processo são ignoradas.
+func (c *Coordinator) Recover(ctx context.Context) (RecoveryResult, error) {
+	var result RecoveryResult
+
+	instances, err := c.store.ListUnfinished(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	for _, instance := range instances {
+		c.mu.RLock()
+		r, exists := c.runners[instance.Name]
+		c.mu.RUnlock()
+
+		if !exists {
+			c.logger.Warn("Skipping saga without registered definition",
+				contracts.Field{Key: "saga", Value: instance.Name},
+				contracts.Field{Key: "saga_id", Value: instance.ID},
+			)
+			continue
+		}
+
+		if !c.acquire(instance.ID) {
+			continue
+		}
+
+		claimed, err := c.claim(ctx, instance)
+		if err != nil || !claimed {
+			c.release(instance.ID)
+			if err != nil {
+				return result, fmt.Errorf("failed to claim saga %s: %w", instance.ID, err)
+			}
+			result.Skipped++
+			continue
+		}
+
+		stop := c.keepAlive(instance.ID)
+		err = r.resume(ctx, instance)
+		stop()
+		c.release(instance.ID)
+
+		if err != nil {
+			c.logger.Warn("Saga recovery did not complete",
+				contracts.Field{Key: "saga", Value: instance.Name},
+				contracts.Field{Key: "saga_id", Value: instance.ID},
+				contracts.Field{Key: "error", Value: err.Error()},
+			)
+		}
+
+		switch instance.Status {
+		case StatusCompleted:
+			result.Resumed++
+		case StatusCompensated:
+			result.Compensated++
+		default:
+			result.Failed++
+		}
+	}
+
+	return result, nil
+}
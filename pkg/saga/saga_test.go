@@ -0,0 +1,296 @@
+package saga_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"go-modular-monolith/pkg/contracts"
+	"go-modular-monolith/pkg/saga"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...contracts.Field)           {}
+func (nopLogger) Info(string, ...contracts.Field)            {}
+func (nopLogger) Warn(string, ...contracts.Field)            {}
+func (nopLogger) Error(string, ...contracts.Field)           {}
+func (nopLogger) Fatal(string, ...contracts.Field)           {}
+func (l nopLogger) With(...contracts.Field) contracts.Logger { return l }
+
+type transferData struct {
+	Log []string `json:"log"`
+}
+
+// steps monta três passos que registram ações e compensações em data.Log.
+// failAction e failCompensation indicam o passo que deve falhar.
+func steps(failAction, failCompensation string) []saga.Step[transferData] {
+	var result []saga.Step[transferData]
+	for _, name := range []string{"a", "b", "c"} {
+		name := name
+		result = append(result, saga.Step[transferData]{
+			Name: name,
+			Action: func(ctx context.Context, data *transferData) error {
+				if name == failAction {
+					return errors.New("action " + name + " failed")
+				}
+				data.Log = append(data.Log, "do "+name)
+				return nil
+			},
+			Compensate: func(ctx context.Context, data *transferData) error {
+				if name == failCompensation {
+					return errors.New("compensation " + name + " failed")
+				}
+				data.Log = append(data.Log, "undo "+name)
+				return nil
+			},
+		})
+	}
+	return result
+}
+
+func TestRunCompletesAllSteps(t *testing.T) {
+	store := saga.NewMemoryStore()
+	s := saga.MustRegister(saga.NewCoordinator(store, nil, nopLogger{}), saga.Definition[transferData]{
+		Name:  "transfer",
+		Steps: steps("", ""),
+	})
+
+	data := &transferData{}
+	instance, err := s.Run(context.Background(), data)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"do a", "do b", "do c"}, data.Log)
+
+	stored, err := store.Get(context.Background(), instance.ID)
+	require.NoError(t, err)
+	assert.Equal(t, saga.StatusCompleted, stored.Status)
+	assert.Equal(t, 3, stored.Step)
+}
+
+func TestFailedStepCompensatesCompletedStepsInReverse(t *testing.T) {
+	store := saga.NewMemoryStore()
+	s := saga.MustRegister(saga.NewCoordinator(store, nil, nopLogger{}), saga.Definition[transferData]{
+		Name:  "transfer",
+		Steps: steps("c", ""),
+	})
+
+	data := &transferData{}
+	instance, err := s.Run(context.Background(), data)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "action c failed")
+	assert.Equal(t, []string{"do a", "do b", "undo b", "undo a"}, data.Log)
+
+	stored, err := store.Get(context.Background(), instance.ID)
+	require.NoError(t, err)
+	assert.Equal(t, saga.StatusCompensated, stored.Status)
+	assert.Zero(t, stored.Step)
+}
+
+func TestFailedFirstStepFinishesCompensated(t *testing.T) {
+	store := saga.NewMemoryStore()
+	coordinator := saga.NewCoordinator(store, nil, nopLogger{})
+	s := saga.MustRegister(coordinator, saga.Definition[transferData]{
+		Name:  "transfer",
+		Steps: steps("a", ""),
+	})
+
+	instance, err := s.Run(context.Background(), &transferData{})
+	require.ErrorContains(t, err, "action a failed")
+
+	stored, err := store.Get(context.Background(), instance.ID)
+	require.NoError(t, err)
+	assert.Equal(t, saga.StatusCompensated, stored.Status)
+
+	// Finalizada, a instância não volta na recuperação
+	result, err := coordinator.Recover(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, saga.RecoveryResult{}, result)
+}
+
+func TestFailedCompensationIsRetriedOnRecover(t *testing.T) {
+	ctx := context.Background()
+	store := saga.NewMemoryStore()
+	s := saga.MustRegister(saga.NewCoordinator(store, nil, nopLogger{}), saga.Definition[transferData]{
+		Name:  "transfer",
+		Steps: steps("c", "a"),
+	})
+
+	instance, err := s.Run(ctx, &transferData{})
+	require.Error(t, err)
+
+	stored, err := store.Get(ctx, instance.ID)
+	require.NoError(t, err)
+	assert.Equal(t, saga.StatusCompensating, stored.Status)
+	assert.Equal(t, 1, stored.Step)
+
+	// Após o restart a compensação volta a funcionar
+	recovering := saga.NewCoordinator(store, nil, nopLogger{})
+	saga.MustRegister(recovering, saga.Definition[transferData]{
+		Name:  "transfer",
+		Steps: steps("", ""),
+	})
+
+	result, err := recovering.Recover(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, saga.RecoveryResult{Compensated: 1}, result)
+
+	stored, err = store.Get(ctx, instance.ID)
+	require.NoError(t, err)
+	assert.Equal(t, saga.StatusCompensated, stored.Status)
+
+	var data transferData
+	require.NoError(t, json.Unmarshal(stored.Data, &data))
+	assert.Equal(t, []string{"do a", "do b", "undo b", "undo a"}, data.Log)
+}
+
+func TestRecoverInterruptedSaga(t *testing.T) {
+	ctx := context.Background()
+
+	// Instância interrompida depois do passo "a"
+	interrupted := func(store saga.Store) {
+		data, _ := json.Marshal(transferData{Log: []string{"do a"}})
+		require.NoError(t, store.Create(ctx, &saga.Instance{
+			ID:        "saga-1",
+			Name:      "transfer",
+			Status:    saga.StatusRunning,
+			Step:      1,
+			Data:      data,
+			CreatedAt: time.Now(),
+		}))
+	}
+
+	tests := []struct {
+		policy   saga.RecoveryPolicy
+		status   saga.Status
+		log      []string
+		expected saga.RecoveryResult
+	}{
+		{saga.RecoverResume, saga.StatusCompleted, []string{"do a", "do b", "do c"}, saga.RecoveryResult{Resumed: 1}},
+		{saga.RecoverCompensate, saga.StatusCompensated, []string{"do a", "undo a"}, saga.RecoveryResult{Compensated: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			store := saga.NewMemoryStore()
+			interrupted(store)
+
+			coordinator := saga.NewCoordinator(store, nil, nopLogger{})
+			saga.MustRegister(coordinator, saga.Definition[transferData]{
+				Name:     "transfer",
+				Steps:    steps("", ""),
+				Recovery: tt.policy,
+			})
+
+			result, err := coordinator.Recover(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+
+			stored, err := store.Get(ctx, "saga-1")
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, stored.Status)
+
+			var data transferData
+			require.NoError(t, json.Unmarshal(stored.Data, &data))
+			assert.Equal(t, tt.log, data.Log)
+		})
+	}
+}
+
+func TestRecoverRespectsLeases(t *testing.T) {
+	ctx := context.Background()
+	future := time.Now().Add(time.Minute)
+	past := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name      string
+		owner     string
+		lease     *time.Time
+		updatedAt time.Time
+		expected  saga.RecoveryResult
+	}{
+		{"leased by another process", "replica-b", &future, time.Now(), saga.RecoveryResult{Skipped: 1}},
+		{"expired lease", "replica-b", &past, time.Now(), saga.RecoveryResult{Compensated: 1}},
+		{"recent instance without lease", "", nil, time.Now(), saga.RecoveryResult{Skipped: 1}},
+		{"stale instance without lease", "", nil, time.Now().Add(-time.Hour), saga.RecoveryResult{Compensated: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := saga.NewMemoryStore()
+			data, _ := json.Marshal(transferData{Log: []string{"do a"}})
+			require.NoError(t, store.Create(ctx, &saga.Instance{
+				ID:             "saga-1",
+				Name:           "transfer",
+				Status:         saga.StatusRunning,
+				Step:           1,
+				Data:           data,
+				Owner:          tt.owner,
+				LeaseExpiresAt: tt.lease,
+				CreatedAt:      tt.updatedAt,
+				UpdatedAt:      tt.updatedAt,
+			}))
+
+			coordinator := saga.NewCoordinator(store, nil, nopLogger{}, saga.WithOwner("replica-a"))
+			saga.MustRegister(coordinator, saga.Definition[transferData]{
+				Name:  "transfer",
+				Steps: steps("", ""),
+			})
+
+			result, err := coordinator.Recover(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestRunningSagaKeepsItsLease(t *testing.T) {
+	ctx := context.Background()
+	store := saga.NewMemoryStore()
+	lease := 30 * time.Millisecond
+
+	started := make(chan string, 1)
+	proceed := make(chan struct{})
+	running := saga.NewCoordinator(store, nil, nopLogger{}, saga.WithOwner("replica-a"), saga.WithLease(lease))
+	s := saga.MustRegister(running, saga.Definition[transferData]{
+		Name: "transfer",
+		Steps: []saga.Step[transferData]{{
+			Name: "slow",
+			Action: func(ctx context.Context, data *transferData) error {
+				list, _ := store.List(ctx, saga.Filter{})
+				started <- list[0].ID
+				<-proceed
+				return nil
+			},
+		}},
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := s.Run(ctx, &transferData{})
+		done <- err
+	}()
+	id := <-started
+
+	// Várias durações de lease depois, a renovação ainda impede outra réplica de assumir
+	time.Sleep(4 * lease)
+	recovering := saga.NewCoordinator(store, nil, nopLogger{}, saga.WithOwner("replica-b"), saga.WithLease(lease))
+	saga.MustRegister(recovering, saga.Definition[transferData]{Name: "transfer", Steps: steps("", "")})
+
+	result, err := recovering.Recover(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, saga.RecoveryResult{Skipped: 1}, result)
+
+	close(proceed)
+	require.NoError(t, <-done)
+
+	stored, err := store.Get(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, saga.StatusCompleted, stored.Status)
+	assert.Equal(t, "replica-a", stored.Owner)
+	assert.False(t, stored.LeaseExpiresAt.After(time.Now()), "finished runs release the lease")
+}
@@ -0,0 +1,149 @@
+package saga
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Filter filtra a listagem de instâncias
+type Filter struct {
+	Name   *string
+	Status *Status
+	Limit  int
+	Offset int
+}
+
+// Store persiste as instâncias de saga. Update é chamado dentro da transação
+// do passo e deve participar dela quando houver uma no contexto; ele não
+// altera Owner nem LeaseExpiresAt.
+type Store interface {
+	Create(ctx context.Context, instance *Instance) error
+	Update(ctx context.Context, instance *Instance) error
+	Get(ctx context.Context, id string) (*Instance, error)
+	List(ctx context.Context, filter Filter) ([]*Instance, error)
+	ListUnfinished(ctx context.Context) ([]*Instance, error)
+
+	// Lease reserva a instância para owner até until, atomicamente, se ela já
+	// é de owner ou se a lease atual expirou. Retorna false quando outro
+	// processo detém a lease. until no passado libera a lease.
+	Lease(ctx context.Context, id, owner string, until time.Time) (bool, error)
+}
+
+// MemoryStore implementa Store em memória
+type MemoryStore struct {
+	instances map[string]*Instance
+	mu        sync.RWMutex
+}
+
+// NewMemoryStore cria um store de sagas em memória
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		instances: make(map[string]*Instance),
+	}
+}
+
+func (s *MemoryStore) Create(ctx context.Context, instance *Instance) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored := *instance
+	s.instances[instance.ID] = &stored
+	return nil
+}
+
+func (s *MemoryStore) Update(ctx context.Context, instance *Instance) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.instances[instance.ID]; !exists {
+		return ErrSagaNotFound
+	}
+	stored := *instance
+	stored.Owner = s.instances[instance.ID].Owner
+	stored.LeaseExpiresAt = s.instances[instance.ID].LeaseExpiresAt
+	s.instances[instance.ID] = &stored
+	return nil
+}
+
+func (s *MemoryStore) Lease(ctx context.Context, id, owner string, until time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	instance, exists := s.instances[id]
+	if !exists {
+		return false, ErrSagaNotFound
+	}
+	if instance.Owner != owner && instance.LeaseExpiresAt != nil && instance.LeaseExpiresAt.After(time.Now()) {
+		return false, nil
+	}
+
+	updated := *instance
+	updated.Owner = owner
+	updated.LeaseExpiresAt = &until
+	s.instances[id] = &updated
+	return true, nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, id string) (*Instance, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	instance, exists := s.instances[id]
+	if !exists {
+		return nil, ErrSagaNotFound
+	}
+	found := *instance
+	return &found, nil
+}
+
+func (s *MemoryStore) List(ctx context.Context, filter Filter) ([]*Instance, error) {
+	result := s.find(func(instance *Instance) bool {
+		return filter.matches(instance)
+	})
+
+	if filter.Offset > 0 {
+		if filter.Offset >= len(result) {
+			return []*Instance{}, nil
+		}
+		result = result[filter.Offset:]
+	}
+	if filter.Limit > 0 && filter.Limit < len(result) {
+		result = result[:filter.Limit]
+	}
+
+	return result, nil
+}
+
+func (s *MemoryStore) ListUnfinished(ctx context.Context) ([]*Instance, error) {
+	return s.find(func(instance *Instance) bool {
+		return !instance.Status.Finished()
+	}), nil
+}
+
+// find retorna cópias das instâncias aceitas, da mais antiga para a mais nova
+func (s *MemoryStore) find(accept func(*Instance) bool) []*Instance {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []*Instance
+	for _, instance := range s.instances {
+		if accept(instance) {
+			found := *instance
+			result = append(result, &found)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result
+}
+
+func (f Filter) matches(instance *Instance) bool {
+	if f.Name != nil && instance.Name != *f.Name {
+		return false
+	}
+	if f.Status != nil && instance.Status != *f.Status {
+		return false
+	}
+	return true
+}